@@ -0,0 +1,59 @@
+// Checks that the wire types of pkg/client still match the json of the handler entities, it exits
+// with an error listing every drifted type. Run it with make check-client before a release
+package main
+
+import (
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/request"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/response"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/pkg/openapi"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/pkg/client"
+	log "github.com/sirupsen/logrus"
+	"os"
+)
+
+// clientTypes pairs the wire types of pkg/client with the entities of the handlers
+var clientTypes = []struct {
+	client  interface{}
+	handler interface{}
+}{
+	{client.GCPDatacenterRequest{}, request.GCPDatacenterData{}},
+	{client.GCPRegisterClusterRequest{}, request.GCPRegisterClusterData{}},
+	{client.EventDataRequest{}, request.EventDataRequest{}},
+	{client.UpdateEventDataRequest{}, request.UpdateEventDataRequest{}},
+	{client.EventValidationRequest{}, request.EventValidationRequest{}},
+	{client.NotificationChannelRequest{}, request.NotificationChannelRequest{}},
+	{client.NotificationSubscriptionRequest{}, request.NotificationSubscriptionRequest{}},
+	{client.GCPDatacenter{}, response.GCPDatacenterData{}},
+	{client.GCPDatacenterClusters{}, response.GCPDatacenterClusters{}},
+	{client.Cluster{}, response.Cluster{}},
+	{client.SimpleHPA{}, response.SimpleHPA{}},
+	{client.Event{}, response.EventSimpleResponse{}},
+	{client.EventDetail{}, response.EventDetailedResponse{}},
+	{client.EventValidation{}, response.EventValidationResponse{}},
+	{client.EventRecommendation{}, response.EventRecommendation{}},
+	{client.EventReport{}, response.EventReport{}},
+	{client.EventImport{}, response.EventImportResponse{}},
+	{client.NodePoolStatus{}, response.NodePoolStatus{}},
+	{client.HPAStatus{}, response.HPAStatus{}},
+	{client.PodStatus{}, response.PodStatus{}},
+	{client.EventStreamHPAStatus{}, response.EventStreamHPAStatus{}},
+	{client.EventStreamNodePoolStatus{}, response.EventStreamNodePoolStatus{}},
+	{client.EventStreamEventStatus{}, response.EventStreamEventStatus{}},
+	{client.NotificationChannel{}, response.NotificationChannel{}},
+	{client.NotificationSubscription{}, response.NotificationSubscription{}},
+	{client.NotificationDelivery{}, response.NotificationDelivery{}},
+}
+
+func main() {
+	failed := false
+	for _, types := range clientTypes {
+		if err := openapi.CompareShapes(types.client, types.handler); err != nil {
+			log.Errorf("client type %T does not match %T : %s", types.client, types.handler, err.Error())
+			failed = true
+		}
+	}
+	if failed {
+		os.Exit(1)
+	}
+	log.Infof("%d client types match the handler entities", len(clientTypes))
+}
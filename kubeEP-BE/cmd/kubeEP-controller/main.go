@@ -0,0 +1,25 @@
+package main
+
+import (
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/config"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/controller"
+	log "github.com/sirupsen/logrus"
+)
+
+func main() {
+	customFormatter := new(log.TextFormatter)
+	customFormatter.TimestampFormat = "2006-01-02 15:04:05"
+	customFormatter.FullTimestamp = true
+	log.SetFormatter(customFormatter)
+
+	configData, err := config.Load()
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	controllerInst, err := controller.BuildController(configData.Controller)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	controllerInst.Start()
+}
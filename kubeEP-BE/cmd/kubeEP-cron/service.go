@@ -7,8 +7,11 @@ import (
 	"github.com/go-redis/redis/v8"
 	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/config"
 	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/cron"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/pkg/metrics"
 	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository/model"
 	useCase "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/usecase"
+	"github.com/prometheus/client_golang/prometheus"
 	log "github.com/sirupsen/logrus"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
@@ -57,7 +60,7 @@ func runService(configData *config.Config) {
 	}
 	defer dbSQL.Close()
 
-	err = repository.Migrate(db)
+	err = repository.Migrate(db, configData.Timescale)
 	if err != nil {
 		log.Fatal(err.Error())
 	}
@@ -87,10 +90,30 @@ func runService(configData *config.Config) {
 		DB:            db,
 		ValidatorInst: validatorInst,
 		Redis:         redisClient,
+		Config:        configData,
 	}
 
 	repositories := repository.BuildRepositories(resources)
 	useCases := useCase.BuildUseCases(resources, repositories)
+	defer useCases.ClientManager.Close()
+
+	// Metrics
+	prometheus.MustRegister(
+		metrics.NewEventStatusCollector(
+			func(ctx context.Context) (map[model.EventStatus]int64, error) {
+				return useCases.Event.CountEventByStatus(db.WithContext(ctx))
+			},
+		),
+	)
+	metricsAddress := configData.Metrics.CronAddress
+	if metricsAddress == "" {
+		metricsAddress = metrics.DefaultCronAddress
+	}
+	go metrics.Serve(metricsAddress)
+	if configData.Metrics.RemoteWrite.URL != "" {
+		go metrics.NewRemoteWriter(configData.Metrics.RemoteWrite).Run(ctx)
+	}
+
 	cronInst := cron.BuildCron(useCases, resources)
 	cronInst.Start()
 }
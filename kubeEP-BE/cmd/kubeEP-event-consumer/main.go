@@ -0,0 +1,109 @@
+// Example consumer of the kubeEP CloudEvents published to the redis stream, it reads the stream
+// with a consumer group and logs every event before acknowledging it
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/go-redis/redis/v8"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/config"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/pkg/cloudevent"
+	log "github.com/sirupsen/logrus"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+)
+
+func main() {
+	customFormatter := new(log.TextFormatter)
+	customFormatter.TimestampFormat = "2006-01-02 15:04:05"
+	customFormatter.FullTimestamp = true
+	log.SetFormatter(customFormatter)
+
+	group := flag.String("group", "kubeep-event-consumer", "consumer group name")
+	consumer := flag.String("consumer", "consumer-1", "consumer name inside the group")
+	flag.Parse()
+
+	configData, err := config.Load()
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	stream := configData.CloudEvents.RedisStream.Stream
+	if stream == "" {
+		stream = cloudevent.DefaultRedisStream
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGTERM, syscall.SIGINT)
+	defer cancel()
+
+	redisClient := redis.NewClient(
+		&redis.Options{
+			Addr: fmt.Sprintf(
+				"%s:%s",
+				configData.Database.Redis.Host,
+				configData.Database.Redis.Port,
+			),
+			Password: configData.Database.Redis.Password,
+			DB:       0,
+		},
+	)
+	defer redisClient.Close()
+
+	err = redisClient.XGroupCreateMkStream(ctx, stream, *group, "$").Err()
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		log.Fatal(err.Error())
+	}
+
+	log.Infof("[EventConsumer] Reading stream %s as %s/%s", stream, *group, *consumer)
+	// Pending messages of this consumer are read first, then the new ones
+	lastID := "0"
+	for ctx.Err() == nil {
+		streams, err := redisClient.XReadGroup(
+			ctx, &redis.XReadGroupArgs{
+				Group:    *group,
+				Consumer: *consumer,
+				Streams:  []string{stream, lastID},
+				Count:    100,
+				Block:    5 * time.Second,
+			},
+		).Result()
+		if errors.Is(err, redis.Nil) {
+			lastID = ">"
+			continue
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				break
+			}
+			log.Errorf("[EventConsumer] Error reading stream : %s", err.Error())
+			time.Sleep(time.Second)
+			continue
+		}
+
+		for _, streamData := range streams {
+			if len(streamData.Messages) == 0 {
+				lastID = ">"
+			}
+			for _, message := range streamData.Messages {
+				event, err := cloudevent.DecodeRedisStreamMessage(message)
+				if err != nil {
+					log.Errorf("[EventConsumer] Error decoding message %s : %s", message.ID, err.Error())
+				} else {
+					log.Infof(
+						"[EventConsumer] %s %s subject=%s data=%s",
+						event.Time.Format(time.RFC3339),
+						event.Type,
+						event.Subject,
+						string(event.Data),
+					)
+				}
+				if err := redisClient.XAck(ctx, stream, *group, message.ID).Err(); err != nil {
+					log.Errorf("[EventConsumer] Error acknowledging message %s : %s", message.ID, err.Error())
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,370 @@
+package main
+
+import (
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/request"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/response"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/pkg/auth"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/pkg/openapi"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository/model"
+	"net/http"
+)
+
+// buildOpenAPI describes the routes of buildRoute, a new route is added here with the entities of
+// its handler
+func buildOpenAPI() *openapi.Document {
+	b := openapi.NewBuilder(
+		openapi.Info{
+			Title:       "kubeEP API",
+			Description: "Schedules the pre-scaling of the HPA and node pools of a cluster for an event",
+			Version:     "v1",
+		},
+	)
+	b.Tag("gcp", "GCP datacenters and clusters")
+	b.Tag("cluster", "Registered clusters")
+	b.Tag("event", "Scheduled events")
+	b.Tag("statistic", "Samples watched during the events")
+	b.Tag("notification", "Notification channels, subscriptions and deliveries")
+
+	b.Enum(model.DatacenterProvider(""), model.GCP)
+	b.Enum(
+		model.EventStatus(""),
+		model.EventPending,
+		model.EventExecuting,
+		model.EventPrescaled,
+		model.EventWatching,
+		model.EventScalingDown,
+		model.EventSuccess,
+		model.EventFailed,
+	)
+	b.Enum(
+		model.ScaleDownGate(""),
+		model.ScaleDownGateNone,
+		model.ScaleDownGateCurrentReplicas,
+		model.ScaleDownGateHPARecommendation,
+	)
+	b.Enum(constant.ValidationLevel(""), constant.ValidationError, constant.ValidationWarning)
+
+	buildGCPOpenAPI(b)
+	buildClusterOpenAPI(b)
+	buildEventOpenAPI(b)
+	buildNotificationOpenAPI(b)
+	return b.Document()
+}
+
+func buildGCPOpenAPI(b *openapi.Builder) {
+	b.Add(
+		http.MethodPost, "/gcp/register/datacenter", &openapi.Operation{
+			Tags:        []string{"gcp"},
+			Summary:     "Register a GCP datacenter with its service account key",
+			Description: "A temporary datacenter is kept until its clusters are registered",
+			OperationID: "registerGCPDatacenter",
+			RequestBody: b.JSONBody(request.GCPDatacenterData{}),
+			Responses:   b.JSONResponses(response.GCPDatacenterData{}),
+		},
+	)
+	b.Add(
+		http.MethodPost, "/gcp/register/clusters", &openapi.Operation{
+			Tags:        []string{"gcp"},
+			Summary:     "Register the clusters of a GCP datacenter",
+			OperationID: "registerGCPClusters",
+			RequestBody: b.JSONBody(request.GCPRegisterClusterData{}),
+			Responses:   b.JSONResponses([]response.GCPCluster{}),
+		},
+	)
+	b.Add(
+		http.MethodGet, "/gcp/clusters", &openapi.Operation{
+			Tags:        []string{"gcp"},
+			Summary:     "List the clusters of a GCP datacenter",
+			OperationID: "listGCPClusters",
+			Parameters:  b.QueryParameters(request.GCPExistingDatacenterData{}),
+			Responses:   b.JSONResponses(response.GCPDatacenterClusters{}),
+		},
+	)
+}
+
+func buildClusterOpenAPI(b *openapi.Builder) {
+	b.Add(
+		http.MethodGet, "/cluster/list", &openapi.Operation{
+			Tags:        []string{"cluster"},
+			Summary:     "List the registered clusters",
+			OperationID: "listClusters",
+			Responses:   b.JSONResponses([]response.Cluster{}),
+		},
+	)
+	b.Add(
+		http.MethodGet, "/cluster/:cluster_id/hpa", &openapi.Operation{
+			Tags:        []string{"cluster"},
+			Summary:     "List the HPA of a cluster",
+			OperationID: "listClusterHPAs",
+			Responses:   b.JSONResponses([]response.SimpleHPA{}),
+		},
+	)
+	b.Add(
+		http.MethodGet, "/cluster/:cluster_id", &openapi.Operation{
+			Tags:        []string{"cluster"},
+			Summary:     "Get a cluster",
+			OperationID: "getCluster",
+			Responses:   b.JSONResponses(response.Cluster{}),
+		},
+	)
+}
+
+func buildEventOpenAPI(b *openapi.Builder) {
+	b.Add(
+		http.MethodPost, "/event/register", &openapi.Operation{
+			Tags:        []string{"event"},
+			Summary:     "Register an event",
+			OperationID: "registerEvent",
+			RequestBody: b.JSONBody(request.EventDataRequest{}),
+			Responses:   b.JSONResponses(response.EventCreationResponse{}),
+		},
+	)
+	b.Add(
+		http.MethodPost, "/event/validate", &openapi.Operation{
+			Tags:        []string{"event"},
+			Summary:     "Validate the HPA configs and node pools of an event before registering it",
+			OperationID: "validateEvent",
+			RequestBody: b.JSONBody(request.EventValidationRequest{}),
+			Responses:   b.JSONResponses(response.EventValidationResponse{}),
+		},
+	)
+	b.Add(
+		http.MethodPut, "/event/update", &openapi.Operation{
+			Tags:        []string{"event"},
+			Summary:     "Update a pending event",
+			Description: "The guard rules are replaced only when guard_rules is set",
+			OperationID: "updateEvent",
+			RequestBody: b.JSONBody(request.UpdateEventDataRequest{}),
+			Responses:   b.JSONResponses(response.EventCreationResponse{}),
+		},
+	)
+	b.Add(
+		http.MethodGet, "/event/list", &openapi.Operation{
+			Tags:        []string{"event"},
+			Summary:     "List the events of a cluster",
+			OperationID: "listEvents",
+			Parameters:  b.QueryParameters(request.EventListRequest{}),
+			Responses:   b.JSONResponses([]response.EventSimpleResponse{}),
+		},
+	)
+	b.Add(
+		http.MethodGet, "/event/recommendation", &openapi.Operation{
+			Tags:        []string{"event"},
+			Summary:     "Recommend an event from the previous events of a cluster",
+			Description: "hpa selects the HPA with namespace/name values, the times are RFC3339",
+			OperationID: "getEventRecommendation",
+			Parameters:  b.QueryParameters(request.EventRecommendationRequest{}),
+			Responses:   b.JSONResponses(response.EventRecommendation{}),
+		},
+	)
+
+	documentFormat := openapi.QueryParameter("format", "Format of the document", "yaml", "json")
+	documentResponses := b.ErrorResponses()
+	documentResponses["200"] = openapi.Response{
+		Description: "Event document, the json format has the schema of EventDocument",
+		Content: map[string]openapi.MediaType{
+			"application/yaml": {Schema: &openapi.Schema{Type: "string"}},
+			"application/json": {Schema: b.Schema(response.EventDocument{})},
+		},
+	}
+	exportParameters := append(
+		b.QueryParameters(request.EventExportRequest{}),
+		documentFormat,
+	)
+	b.Add(
+		http.MethodGet, "/event/export", &openapi.Operation{
+			Tags:        []string{"event"},
+			Summary:     "Export the events of a cluster as an event document",
+			OperationID: "exportClusterEvents",
+			Parameters:  exportParameters,
+			Responses:   documentResponses,
+		},
+	)
+	importResponses := b.JSONResponses(response.EventImportResponse{})
+	importResponses["400"] = openapi.Response{
+		Description: "Invalid document, an invalid event is answered with the validation of every event",
+		Content: map[string]openapi.MediaType{
+			"application/json": {Schema: b.Envelope(b.Schema(response.EventImportResponse{}))},
+		},
+	}
+	b.Add(
+		http.MethodPost, "/event/import", &openapi.Operation{
+			Tags:        []string{"event"},
+			Summary:     "Import the events of an event document",
+			Description: "Every event is registered or none, dry_run only validates the events",
+			OperationID: "importEvents",
+			Parameters: []openapi.Parameter{
+				openapi.QueryParameter("dry_run", "Validate the events without registering them", "true", "false"),
+			},
+			RequestBody: &openapi.RequestBody{
+				Required: true,
+				Content: map[string]openapi.MediaType{
+					"application/yaml": {Schema: &openapi.Schema{Type: "string"}},
+					"application/json": {Schema: b.Schema(request.EventDocument{})},
+				},
+			},
+			Responses: importResponses,
+		},
+	)
+
+	b.Add(
+		http.MethodGet, "/event/status/node-pool/:updated_node_pool_id", &openapi.Operation{
+			Tags:        []string{"statistic"},
+			Summary:     "List the samples of an updated node pool",
+			OperationID: "listNodePoolStatus",
+			Responses:   b.JSONResponses([]response.NodePoolStatus{}),
+		},
+	)
+	b.Add(
+		http.MethodGet, "/event/status/hpa/:scheduled_hpa_config_id", &openapi.Operation{
+			Tags:        []string{"statistic"},
+			Summary:     "List the samples of a scheduled HPA config",
+			OperationID: "listHPAStatus",
+			Responses:   b.JSONResponses([]response.HPAStatus{}),
+		},
+	)
+	b.Add(
+		http.MethodGet, "/event/status/pod/:scheduled_hpa_config_id", &openapi.Operation{
+			Tags:        []string{"statistic"},
+			Summary:     "List the pod samples of a scheduled HPA config",
+			OperationID: "listPodStatus",
+			Responses:   b.JSONResponses([]response.PodStatus{}),
+		},
+	)
+
+	reportResponses := b.ErrorResponses()
+	reportResponses["200"] = openapi.Response{
+		Description: "Report of the event",
+		Content: map[string]openapi.MediaType{
+			"application/json": {Schema: b.Envelope(b.Schema(response.EventReport{}))},
+			"text/markdown":    {Schema: &openapi.Schema{Type: "string"}},
+			"text/html":        {Schema: &openapi.Schema{Type: "string"}},
+		},
+	}
+	b.Add(
+		http.MethodGet, "/event/:event_id/report", &openapi.Operation{
+			Tags:        []string{"event"},
+			Summary:     "Get the report of an event",
+			OperationID: "getEventReport",
+			Parameters: []openapi.Parameter{
+				openapi.QueryParameter("format", "Format of the report", "json", "markdown", "html"),
+			},
+			Responses: reportResponses,
+		},
+	)
+	streamResponses := b.ErrorResponses()
+	streamResponses["200"] = openapi.RawResponse(
+		"Server-sent events named hpa_status (EventStreamHPAStatus), node_pool_status "+
+			"(EventStreamNodePoolStatus) and event_status (EventStreamEventStatus)",
+		"text/event-stream",
+	)
+	// The streamed messages are only referenced from the description, they are registered to
+	// describe their schema
+	b.Schema(response.EventStreamHPAStatus{})
+	b.Schema(response.EventStreamNodePoolStatus{})
+	b.Schema(response.EventStreamEventStatus{})
+	b.Add(
+		http.MethodGet, eventStreamPath, &openapi.Operation{
+			Tags:        []string{"event"},
+			Summary:     "Stream the samples and status transitions of an event",
+			Description: "The stream starts with the current status and ends when the event succeeds or fails",
+			OperationID: "streamEvent",
+			Parameters: []openapi.Parameter{
+				openapi.QueryParameter(
+					auth.QueryToken,
+					"Auth token of the clients which cannot send the Authorization header, like the browser EventSource",
+				),
+			},
+			Responses: streamResponses,
+		},
+	)
+	b.Add(
+		http.MethodGet, "/event/:event_id/export", &openapi.Operation{
+			Tags:        []string{"event"},
+			Summary:     "Export an event as an event document",
+			OperationID: "exportEvent",
+			Parameters:  []openapi.Parameter{documentFormat},
+			Responses:   documentResponses,
+		},
+	)
+	b.Add(
+		http.MethodGet, "/event/:event_id", &openapi.Operation{
+			Tags:        []string{"event"},
+			Summary:     "Get the details of an event",
+			OperationID: "getEvent",
+			Responses:   b.JSONResponses(response.EventDetailedResponse{}),
+		},
+	)
+	b.Add(
+		http.MethodDelete, "/event/:event_id", &openapi.Operation{
+			Tags:        []string{"event"},
+			Summary:     "Delete an event",
+			OperationID: "deleteEvent",
+			Responses:   b.JSONResponses(constant.ActionDone),
+		},
+	)
+}
+
+func buildNotificationOpenAPI(b *openapi.Builder) {
+	b.Add(
+		http.MethodPost, "/notification/channel", &openapi.Operation{
+			Tags:        []string{"notification"},
+			Summary:     "Register a notification channel",
+			OperationID: "registerNotificationChannel",
+			RequestBody: b.JSONBody(request.NotificationChannelRequest{}),
+			Responses:   b.JSONResponses(response.NotificationCreationResponse{}),
+		},
+	)
+	b.Add(
+		http.MethodGet, "/notification/channel/list", &openapi.Operation{
+			Tags:        []string{"notification"},
+			Summary:     "List the notification channels",
+			OperationID: "listNotificationChannels",
+			Responses:   b.JSONResponses([]response.NotificationChannel{}),
+		},
+	)
+	b.Add(
+		http.MethodDelete, "/notification/channel/:channel_id", &openapi.Operation{
+			Tags:        []string{"notification"},
+			Summary:     "Delete a notification channel",
+			OperationID: "deleteNotificationChannel",
+			Responses:   b.JSONResponses(constant.ActionDone),
+		},
+	)
+	b.Add(
+		http.MethodPost, "/notification/subscription", &openapi.Operation{
+			Tags:        []string{"notification"},
+			Summary:     "Subscribe a channel to the transitions of a cluster or an event",
+			OperationID: "registerNotificationSubscription",
+			RequestBody: b.JSONBody(request.NotificationSubscriptionRequest{}),
+			Responses:   b.JSONResponses(response.NotificationCreationResponse{}),
+		},
+	)
+	b.Add(
+		http.MethodGet, "/notification/subscription/list", &openapi.Operation{
+			Tags:        []string{"notification"},
+			Summary:     "List the notification subscriptions",
+			OperationID: "listNotificationSubscriptions",
+			Parameters:  b.QueryParameters(request.NotificationSubscriptionListRequest{}),
+			Responses:   b.JSONResponses([]response.NotificationSubscription{}),
+		},
+	)
+	b.Add(
+		http.MethodDelete, "/notification/subscription/:subscription_id", &openapi.Operation{
+			Tags:        []string{"notification"},
+			Summary:     "Delete a notification subscription",
+			OperationID: "deleteNotificationSubscription",
+			Responses:   b.JSONResponses(constant.ActionDone),
+		},
+	)
+	b.Add(
+		http.MethodGet, "/notification/delivery/list", &openapi.Operation{
+			Tags:        []string{"notification"},
+			Summary:     "List the notification deliveries of an event",
+			OperationID: "listNotificationDeliveries",
+			Parameters:  b.QueryParameters(request.NotificationDeliveryListRequest{}),
+			Responses:   b.JSONResponses([]response.NotificationDelivery{}),
+		},
+	)
+}
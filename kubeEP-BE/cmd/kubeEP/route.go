@@ -6,6 +6,10 @@ import (
 	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/handler"
 )
 
+// eventStreamPath is the full path of the event stream, the auth middleware accepts the token of
+// its requests in the query
+const eventStreamPath = "/event/:event_id/stream"
+
 func buildRoute(handlers *handler.Handlers, router fiber.Router) {
 	router.Use(
 		cors.New(
@@ -43,8 +47,12 @@ func buildRoute(handlers *handler.Handlers, router fiber.Router) {
 	router.Route(
 		"/event", func(router fiber.Router) {
 			router.Post("/register", handlers.EventHandler.RegisterEvents)
+			router.Post("/validate", handlers.EventHandler.ValidateEvent)
 			router.Put("/update", handlers.EventHandler.UpdateEvent)
 			router.Get("/list", handlers.EventHandler.ListEventByCluster)
+			router.Get("/recommendation", handlers.EventHandler.GetEventRecommendation)
+			router.Get("/export", handlers.EventHandler.ExportClusterEvents)
+			router.Post("/import", handlers.EventHandler.ImportEvents)
 			router.Get(
 				"/status/node-pool/:updated_node_pool_id",
 				handlers.EventHandler.ListNodePoolStatusByUpdatedNodePool,
@@ -53,8 +61,38 @@ func buildRoute(handlers *handler.Handlers, router fiber.Router) {
 				"/status/hpa/:scheduled_hpa_config_id",
 				handlers.EventHandler.ListHPAStatusByScheduledHPAConfig,
 			)
+			router.Get(
+				"/status/pod/:scheduled_hpa_config_id",
+				handlers.EventHandler.ListPodStatusByScheduledHPAConfig,
+			)
+			router.Get("/:event_id/report", handlers.EventHandler.GetEventReport)
+			router.Get("/:event_id/stream", handlers.EventHandler.StreamEvent)
+			router.Get("/:event_id/export", handlers.EventHandler.ExportEvent)
 			router.Get("/:event_id", handlers.EventHandler.GetDetailedEvent)
 			router.Delete("/:event_id", handlers.EventHandler.DeleteEvent)
 		},
 	)
+
+	router.Route(
+		"/notification", func(router fiber.Router) {
+			router.Route(
+				"/channel", func(router fiber.Router) {
+					router.Post("/", handlers.NotificationHandler.RegisterChannel)
+					router.Get("/list", handlers.NotificationHandler.ListChannel)
+					router.Delete("/:channel_id", handlers.NotificationHandler.DeleteChannel)
+				},
+			)
+			router.Route(
+				"/subscription", func(router fiber.Router) {
+					router.Post("/", handlers.NotificationHandler.RegisterSubscription)
+					router.Get("/list", handlers.NotificationHandler.ListSubscription)
+					router.Delete(
+						"/:subscription_id",
+						handlers.NotificationHandler.DeleteSubscription,
+					)
+				},
+			)
+			router.Get("/delivery/list", handlers.NotificationHandler.ListDelivery)
+		},
+	)
 }
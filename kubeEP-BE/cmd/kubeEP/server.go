@@ -9,8 +9,13 @@ import (
 	"github.com/gofiber/fiber/v2/middleware/cors"
 	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/config"
 	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/handler"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/pkg/auth"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/pkg/metrics"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/pkg/openapi"
 	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository/model"
 	useCase "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/usecase"
+	"github.com/prometheus/client_golang/prometheus"
 	log "github.com/sirupsen/logrus"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
@@ -23,6 +28,10 @@ func runServer(configData *config.Config) {
 	ctx := context.Background()
 	app := fiber.New()
 
+	// Metrics
+	app.Use(metrics.FiberMiddleware())
+	app.Get(metrics.Path, metrics.FiberHandler())
+
 	// CORS
 
 	app.Use(
@@ -34,6 +43,17 @@ func runServer(configData *config.Config) {
 		),
 	)
 
+	// OpenAPI
+	openAPIHandler, err := openapi.FiberHandler(buildOpenAPI())
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	app.Get(openapi.Path, openAPIHandler)
+	app.Get(openapi.SwaggerUIPath, openapi.SwaggerUIHandler())
+
+	// Auth
+	app.Use(auth.FiberMiddleware(configData.Auth.Tokens, eventStreamPath))
+
 	// Bootstrap DB
 	newDBLogger := logger.New(
 		log.StandardLogger(),
@@ -72,7 +92,7 @@ func runServer(configData *config.Config) {
 	}
 	defer dbSQL.Close()
 
-	err = repository.Migrate(db)
+	err = repository.Migrate(db, configData.Timescale)
 	if err != nil {
 		log.Fatal(err.Error())
 	}
@@ -102,10 +122,19 @@ func runServer(configData *config.Config) {
 		DB:            db,
 		ValidatorInst: validatorInst,
 		Redis:         redisClient,
+		Config:        configData,
 	}
 
 	repositories := repository.BuildRepositories(resources)
 	useCases := useCase.BuildUseCases(resources, repositories)
+	defer useCases.ClientManager.Close()
+	prometheus.MustRegister(
+		metrics.NewEventStatusCollector(
+			func(ctx context.Context) (map[model.EventStatus]int64, error) {
+				return useCases.Event.CountEventByStatus(db.WithContext(ctx))
+			},
+		),
+	)
 	handlers := handler.BuildHandlers(useCases, resources)
 	buildRoute(handlers, app)
 
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	configEnv  = "KUBEEPCONFIG"
+	contextEnv = "KUBEEP_CONTEXT"
+	serverEnv  = "KUBEEP_SERVER"
+	tokenEnv   = "KUBEEP_TOKEN"
+)
+
+// ctlConfig lists the kubeEP instances known by kubeepctl, like the contexts of a kubeconfig
+type ctlConfig struct {
+	CurrentContext string       `json:"current-context"`
+	Contexts       []ctlContext `json:"contexts"`
+}
+
+type ctlContext struct {
+	Name   string `json:"name"`
+	Server string `json:"server"`
+	Token  string `json:"token,omitempty"`
+}
+
+func defaultConfigPath() string {
+	if path := os.Getenv(configEnv); path != "" {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(".kubeep", "config")
+	}
+	return filepath.Join(home, ".kubeep", "config")
+}
+
+// loadConfig returns an empty config when the file does not exist yet
+func loadConfig(path string) (*ctlConfig, error) {
+	config := &ctlConfig{}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return config, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := yaml.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("%s : %s", path, err.Error())
+	}
+	return config, nil
+}
+
+// saveConfig keeps the file private, the contexts hold the api tokens
+func saveConfig(path string, config *ctlConfig) error {
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+func (c *ctlConfig) getContext(name string) *ctlContext {
+	for i := range c.Contexts {
+		if c.Contexts[i].Name == name {
+			return &c.Contexts[i]
+		}
+	}
+	return nil
+}
+
+func (c *ctlConfig) deleteContext(name string) bool {
+	for i := range c.Contexts {
+		if c.Contexts[i].Name == name {
+			c.Contexts = append(c.Contexts[:i], c.Contexts[i+1:]...)
+			if c.CurrentContext == name {
+				c.CurrentContext = ""
+			}
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"text/tabwriter"
+)
+
+func runConfig(_ context.Context, c *cli, args []string) error {
+	if len(args) == 0 {
+		return errors.New(
+			"usage: kubeepctl config get-contexts|current-context|use-context|set-context|delete-context",
+		)
+	}
+	config, err := loadConfig(c.configPath)
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "get-contexts":
+		w := tabwriter.NewWriter(c.out, 0, 0, 3, ' ', 0)
+		fmt.Fprintln(w, "CURRENT\tNAME\tSERVER")
+		for _, ctlCtx := range config.Contexts {
+			current := ""
+			if ctlCtx.Name == config.CurrentContext {
+				current = "*"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\n", current, ctlCtx.Name, ctlCtx.Server)
+		}
+		return w.Flush()
+	case "current-context":
+		if config.CurrentContext == "" {
+			return errors.New("current context is not set")
+		}
+		fmt.Fprintln(c.out, config.CurrentContext)
+		return nil
+	case "use-context":
+		if err := requireArgs(args[1:], 1, "config use-context NAME"); err != nil {
+			return err
+		}
+		if config.getContext(args[1]) == nil {
+			return fmt.Errorf("context %q does not exist", args[1])
+		}
+		config.CurrentContext = args[1]
+		if err := saveConfig(c.configPath, config); err != nil {
+			return err
+		}
+		fmt.Fprintf(c.out, "Switched to context %q\n", args[1])
+		return nil
+	case "set-context":
+		return setContext(c, config, args[1:])
+	case "delete-context":
+		if err := requireArgs(args[1:], 1, "config delete-context NAME"); err != nil {
+			return err
+		}
+		if !config.deleteContext(args[1]) {
+			return fmt.Errorf("context %q does not exist", args[1])
+		}
+		if err := saveConfig(c.configPath, config); err != nil {
+			return err
+		}
+		fmt.Fprintf(c.out, "Deleted context %q\n", args[1])
+		return nil
+	default:
+		return fmt.Errorf("unknown config command %q", args[0])
+	}
+}
+
+// setContext creates or updates a context, the fields without flag are kept. The first context
+// becomes the current context
+func setContext(c *cli, config *ctlConfig, args []string) error {
+	fs := newFlagSet("set-context")
+	server := fs.String("server", "", "kubeEP api url")
+	token := fs.String("token", "", "api token")
+	args, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := requireArgs(args, 1, "config set-context NAME --server URL [--token TOKEN]"); err != nil {
+		return err
+	}
+
+	ctlCtx := config.getContext(args[0])
+	if ctlCtx == nil {
+		if *server == "" {
+			return errors.New("--server is required for a new context")
+		}
+		config.Contexts = append(config.Contexts, ctlContext{Name: args[0]})
+		ctlCtx = &config.Contexts[len(config.Contexts)-1]
+	}
+	if *server != "" {
+		ctlCtx.Server = *server
+	}
+	if *token != "" {
+		ctlCtx.Token = *token
+	}
+	if config.CurrentContext == "" {
+		config.CurrentContext = ctlCtx.Name
+	}
+	if err := saveConfig(c.configPath, config); err != nil {
+		return err
+	}
+	fmt.Fprintf(c.out, "Context %q set\n", args[0])
+	return nil
+}
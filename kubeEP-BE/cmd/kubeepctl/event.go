@@ -0,0 +1,239 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/pkg/client"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+)
+
+// runCreate imports an event document, the events are only validated with --dry-run
+func runCreate(ctx context.Context, c *cli, args []string) error {
+	fs := newFlagSet("create")
+	file := fs.String("f", "", "event document (yaml or json), - reads stdin")
+	dryRun := fs.Bool("dry-run", false, "validate the events without creating them")
+	output := fs.String("o", "table", "output format (table, json, yaml)")
+	args, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if *file == "" || len(args) != 0 {
+		return errors.New("usage: kubeepctl create -f FILE [--dry-run]")
+	}
+	document, err := readInput(*file)
+	if err != nil {
+		return err
+	}
+	api, err := c.client()
+	if err != nil {
+		return err
+	}
+
+	// An invalid document is answered with the validation of every event
+	res, err := api.ImportEvents(ctx, document, *dryRun)
+	if res == nil {
+		return err
+	}
+
+	if *output != "table" {
+		if printErr := c.printStructured(res, *output); printErr != nil {
+			return printErr
+		}
+	} else {
+		printImportResult(c, res)
+	}
+	if !res.Valid {
+		return errors.New("the event document is invalid, no event is created")
+	}
+	return nil
+}
+
+func printImportResult(c *cli, res *client.EventImport) {
+	w := tabwriter.NewWriter(c.out, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "NAME\tCLUSTER\tVALID\tEVENT ID")
+	for _, event := range res.Events {
+		eventID := "-"
+		if event.EventID != nil {
+			eventID = event.EventID.String()
+		}
+		fmt.Fprintf(w, "%s\t%s\t%t\t%s\n", event.Name, event.Cluster, event.Valid, eventID)
+	}
+	w.Flush()
+
+	for _, event := range res.Events {
+		issues := event.Errors
+		if event.Validation != nil {
+			for _, hpa := range event.Validation.HPAs {
+				for _, issue := range hpa.Issues {
+					issues = append(
+						issues,
+						fmt.Sprintf("%s hpa %s/%s : %s", issue.Level, hpa.Namespace, hpa.Name, issue.Message),
+					)
+				}
+			}
+			for _, nodePool := range event.Validation.NodePools {
+				for _, issue := range nodePool.Issues {
+					issues = append(
+						issues,
+						fmt.Sprintf("%s node pool %s : %s", issue.Level, nodePool.Name, issue.Message),
+					)
+				}
+			}
+		}
+		for _, issue := range issues {
+			fmt.Fprintf(c.out, "%s : %s\n", event.Name, issue)
+		}
+	}
+	if res.DryRun && res.Valid {
+		fmt.Fprintln(c.out, "Dry run, no event is created")
+	}
+}
+
+// runExport prints the event document of an event or of every event of a cluster, the output can
+// be given back to create
+func runExport(ctx context.Context, c *cli, args []string) error {
+	fs := newFlagSet("export")
+	clusterID := fs.String("cluster", "", "cluster id (events)")
+	output := fs.String("o", "yaml", "output format (yaml, json)")
+	args, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	api, err := c.client()
+	if err != nil {
+		return err
+	}
+
+	var document []byte
+	switch {
+	case len(args) == 2 && args[0] == "event":
+		eventID, err := parseID(args[1], "event id")
+		if err != nil {
+			return err
+		}
+		document, err = api.ExportEvent(ctx, eventID, *output)
+		if err != nil {
+			return err
+		}
+	case len(args) == 1 && args[0] == "events":
+		id, err := parseClusterFlag(*clusterID)
+		if err != nil {
+			return err
+		}
+		document, err = api.ExportClusterEvents(ctx, id, *output)
+		if err != nil {
+			return err
+		}
+	default:
+		return errors.New("usage: kubeepctl export event ID|events --cluster ID [-o yaml|json]")
+	}
+	_, err = c.out.Write(document)
+	return err
+}
+
+func runReport(ctx context.Context, c *cli, args []string) error {
+	fs := newFlagSet("report")
+	format := fs.String("format", "markdown", "report format (markdown, html, json)")
+	args, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := requireArgs(args, 1, "report ID [--format markdown|html|json]"); err != nil {
+		return err
+	}
+	eventID, err := parseID(args[0], "event id")
+	if err != nil {
+		return err
+	}
+	api, err := c.client()
+	if err != nil {
+		return err
+	}
+
+	if *format == "json" {
+		report, err := api.GetEventReport(ctx, eventID)
+		if err != nil {
+			return err
+		}
+		return c.printStructured(report, "json")
+	}
+	report, err := api.GetEventReportDocument(ctx, eventID, *format)
+	if err != nil {
+		return err
+	}
+	_, err = c.out.Write(report)
+	return err
+}
+
+// runRecommend prints the event recommended from the previous events of the cluster
+func runRecommend(ctx context.Context, c *cli, args []string) error {
+	fs := newFlagSet("recommend")
+	clusterID := fs.String("cluster", "", "cluster id")
+	hpas := fs.String("hpa", "", "comma separated namespace/name of the hpa to recommend")
+	startTime := fs.String("start", "", "start time of the event (RFC3339)")
+	endTime := fs.String("end", "", "end time of the event (RFC3339)")
+	output := fs.String("o", "yaml", "output format (yaml, json)")
+	args, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) != 0 {
+		return errors.New(
+			"usage: kubeepctl recommend --cluster ID [--hpa NS/NAME,...] [--start T] [--end T]",
+		)
+	}
+	id, err := parseClusterFlag(*clusterID)
+	if err != nil {
+		return err
+	}
+	api, err := c.client()
+	if err != nil {
+		return err
+	}
+
+	req := &client.EventRecommendationRequest{ClusterID: &id}
+	if *hpas != "" {
+		req.HPAs = strings.Split(*hpas, ",")
+	}
+	if *startTime != "" {
+		req.StartTime = startTime
+	}
+	if *endTime != "" {
+		req.EndTime = endTime
+	}
+	recommendation, err := api.GetEventRecommendation(ctx, req)
+	if err != nil {
+		return err
+	}
+	return c.printStructured(recommendation, *output)
+}
+
+func runDelete(ctx context.Context, c *cli, args []string) error {
+	if len(args) != 2 || args[0] != "event" {
+		return errors.New("usage: kubeepctl delete event ID")
+	}
+	eventID, err := parseID(args[1], "event id")
+	if err != nil {
+		return err
+	}
+	api, err := c.client()
+	if err != nil {
+		return err
+	}
+	if err := api.DeleteEvent(ctx, eventID); err != nil {
+		return err
+	}
+	fmt.Fprintf(c.out, "Event %s deleted\n", args[1])
+	return nil
+}
+
+func readInput(file string) ([]byte, error) {
+	if file == "-" {
+		return io.ReadAll(os.Stdin)
+	}
+	return os.ReadFile(file)
+}
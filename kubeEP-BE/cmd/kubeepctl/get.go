@@ -0,0 +1,169 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/pkg/client"
+	"text/tabwriter"
+	"time"
+)
+
+func runGet(ctx context.Context, c *cli, args []string) error {
+	fs := newFlagSet("get")
+	clusterID := fs.String("cluster", "", "cluster id (hpas, events)")
+	output := fs.String("o", "table", "output format (table, json, yaml)")
+	args, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		return errors.New("usage: kubeepctl get clusters|hpas|events|event")
+	}
+	api, err := c.client()
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "clusters":
+		clusters, err := api.ListClusters(ctx)
+		if err != nil {
+			return err
+		}
+		if *output != "table" {
+			return c.printStructured(clusters, *output)
+		}
+		w := tabwriter.NewWriter(c.out, 0, 0, 3, ' ', 0)
+		fmt.Fprintln(w, "ID\tNAME\tDATACENTER\tDATACENTER NAME")
+		for _, cluster := range clusters {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", cluster.ID, cluster.Name, cluster.Datacenter, cluster.DatacenterName)
+		}
+		return w.Flush()
+	case "hpas":
+		id, err := parseClusterFlag(*clusterID)
+		if err != nil {
+			return err
+		}
+		hpas, err := api.ListClusterHPAs(ctx, id)
+		if err != nil {
+			return err
+		}
+		if *output != "table" {
+			return c.printStructured(hpas, *output)
+		}
+		w := tabwriter.NewWriter(c.out, 0, 0, 3, ' ', 0)
+		fmt.Fprintln(w, "NAMESPACE\tNAME\tMIN\tMAX\tREPLICAS")
+		for _, hpa := range hpas {
+			fmt.Fprintf(
+				w,
+				"%s\t%s\t%s\t%d\t%d\n",
+				hpa.Namespace,
+				hpa.Name,
+				formatOptionalInt32(hpa.MinReplicas),
+				hpa.MaxReplicas,
+				hpa.CurrentReplicas,
+			)
+		}
+		return w.Flush()
+	case "events":
+		id, err := parseClusterFlag(*clusterID)
+		if err != nil {
+			return err
+		}
+		events, err := api.ListEvents(ctx, id)
+		if err != nil {
+			return err
+		}
+		if *output != "table" {
+			return c.printStructured(events, *output)
+		}
+		w := tabwriter.NewWriter(c.out, 0, 0, 3, ' ', 0)
+		fmt.Fprintln(w, "ID\tNAME\tSTATUS\tSTART\tEND")
+		for _, event := range events {
+			fmt.Fprintf(
+				w,
+				"%s\t%s\t%s\t%s\t%s\n",
+				event.ID,
+				event.Name,
+				event.Status,
+				event.StartTime.Format(time.RFC3339),
+				event.EndTime.Format(time.RFC3339),
+			)
+		}
+		return w.Flush()
+	case "event":
+		if err := requireArgs(args[1:], 1, "get event ID [-o table|json|yaml]"); err != nil {
+			return err
+		}
+		eventID, err := parseID(args[1], "event id")
+		if err != nil {
+			return err
+		}
+		event, err := api.GetEvent(ctx, eventID)
+		if err != nil {
+			return err
+		}
+		if *output != "table" {
+			return c.printStructured(event, *output)
+		}
+		printEvent(c, event)
+		return nil
+	default:
+		return fmt.Errorf("unknown resource %q", args[0])
+	}
+}
+
+func parseClusterFlag(clusterID string) (uuid.UUID, error) {
+	if clusterID == "" {
+		return uuid.UUID{}, errors.New("--cluster is required")
+	}
+	return parseID(clusterID, "cluster id")
+}
+
+func printEvent(c *cli, event *client.EventDetail) {
+	w := tabwriter.NewWriter(c.out, 0, 0, 3, ' ', 0)
+	fmt.Fprintf(w, "ID:\t%s\n", event.ID)
+	fmt.Fprintf(w, "Name:\t%s\n", event.Name)
+	fmt.Fprintf(w, "Cluster:\t%s (%s)\n", event.Cluster.Name, event.Cluster.DatacenterName)
+	fmt.Fprintf(w, "Status:\t%s\n", event.Status)
+	fmt.Fprintf(w, "Execute config at:\t%s\n", event.ExecuteConfigAt.Format(time.RFC3339))
+	fmt.Fprintf(w, "Watching at:\t%s\n", event.WatchingAt.Format(time.RFC3339))
+	fmt.Fprintf(w, "Start:\t%s\n", event.StartTime.Format(time.RFC3339))
+	fmt.Fprintf(w, "End:\t%s\n", event.EndTime.Format(time.RFC3339))
+	w.Flush()
+
+	fmt.Fprintln(c.out, "\nHPA configs:")
+	w = tabwriter.NewWriter(c.out, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAMESPACE\tNAME\tMIN\tMAX")
+	for _, hpaConfig := range event.ModifiedHPAConfigs {
+		fmt.Fprintf(
+			w,
+			"%s\t%s\t%s\t%s\t%d\n",
+			hpaConfig.ID,
+			hpaConfig.Namespace,
+			hpaConfig.Name,
+			formatOptionalInt32(hpaConfig.MinReplicas),
+			hpaConfig.MaxReplicas,
+		)
+	}
+	w.Flush()
+
+	if len(event.UpdatedNodePools) > 0 {
+		fmt.Fprintln(c.out, "\nNode pools:")
+		w = tabwriter.NewWriter(c.out, 0, 0, 3, ' ', 0)
+		fmt.Fprintln(w, "ID\tNAME\tMAX NODES")
+		for _, nodePool := range event.UpdatedNodePools {
+			fmt.Fprintf(w, "%s\t%s\t%d\n", nodePool.ID, nodePool.NodePoolName, nodePool.MaxNode)
+		}
+		w.Flush()
+	}
+}
+
+func formatOptionalInt32(value *int32) string {
+	if value == nil {
+		return "-"
+	}
+	return fmt.Sprint(*value)
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/pkg/client"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"sigs.k8s.io/yaml"
+	"syscall"
+	"time"
+)
+
+// The stream of watch is not bounded by the timeout
+const requestTimeout = 60 * time.Second
+
+const usage = `kubeepctl controls the kubeEP events through the kubeEP api.
+
+Usage:
+  kubeepctl [global flags] <command> [args]
+
+Commands:
+  config get-contexts|current-context|use-context|set-context|delete-context
+  get clusters|hpas|events|event      List the clusters, hpa and events
+  create -f FILE [--dry-run]          Create the events of an event document
+  export event ID|events --cluster ID Print the event document of an event or a cluster
+  watch ID                            Show the live replicas and node counts of an event
+  stats hpa|node-pool|pod ID          Print the statistics of an event as CSV
+  report ID [--format F]              Print the report of an event
+  recommend --cluster ID              Recommend the hpa configs of an event
+  delete event ID                     Delete an event
+
+Global flags:
+`
+
+type command func(ctx context.Context, c *cli, args []string) error
+
+var commands = map[string]command{
+	"config":    runConfig,
+	"get":       runGet,
+	"create":    runCreate,
+	"export":    runExport,
+	"watch":     runWatch,
+	"stats":     runStats,
+	"report":    runReport,
+	"recommend": runRecommend,
+	"delete":    runDelete,
+}
+
+// cli holds the global flags, the context is resolved by the commands calling the api
+type cli struct {
+	configPath string
+	context    string
+	server     string
+	token      string
+	out        io.Writer
+}
+
+func main() {
+	c := &cli{out: os.Stdout}
+	flag.StringVar(
+		&c.configPath,
+		"config",
+		defaultConfigPath(),
+		"kubeepctl config file ($"+configEnv+")",
+	)
+	flag.StringVar(&c.context, "context", os.Getenv(contextEnv), "context to use ($"+contextEnv+")")
+	flag.StringVar(
+		&c.server,
+		"server",
+		os.Getenv(serverEnv),
+		"kubeEP api url, overrides the context ($"+serverEnv+")",
+	)
+	flag.StringVar(
+		&c.token,
+		"token",
+		os.Getenv(tokenEnv),
+		"api token, overrides the context ($"+tokenEnv+")",
+	)
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	cmd, ok := commands[flag.Arg(0)]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n", flag.Arg(0))
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGTERM, syscall.SIGINT)
+	err := cmd(ctx, c, flag.Args()[1:])
+	cancel()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
+		os.Exit(1)
+	}
+}
+
+// client resolves the api of the selected context, the server and token flags take precedence
+func (c *cli) client() (*client.Client, error) {
+	server, token := c.server, c.token
+	if server == "" || token == "" {
+		config, err := loadConfig(c.configPath)
+		if err != nil {
+			return nil, err
+		}
+		name := c.context
+		if name == "" {
+			name = config.CurrentContext
+		}
+		if name != "" {
+			ctlCtx := config.getContext(name)
+			if ctlCtx == nil {
+				return nil, fmt.Errorf("context %q does not exist", name)
+			}
+			if server == "" {
+				server = ctlCtx.Server
+			}
+			if token == "" {
+				token = ctlCtx.Token
+			}
+		}
+	}
+	if server == "" {
+		return nil, errors.New("no server, set a context or --server")
+	}
+	return client.New(
+		server,
+		client.WithToken(token),
+		client.WithHTTPClient(&http.Client{Timeout: requestTimeout}),
+	), nil
+}
+
+func parseID(value, name string) (uuid.UUID, error) {
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return uuid.UUID{}, fmt.Errorf("invalid %s %q", name, value)
+	}
+	return id, nil
+}
+
+// parseArgs parses the flags placed anywhere between the positional args
+func parseArgs(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+func newFlagSet(name string) *flag.FlagSet {
+	return flag.NewFlagSet(name, flag.ContinueOnError)
+}
+
+func requireArgs(args []string, n int, usage string) error {
+	if len(args) != n {
+		return fmt.Errorf("usage: kubeepctl %s", usage)
+	}
+	return nil
+}
+
+// printStructured prints data as json or yaml, yaml uses the json field names
+func (c *cli) printStructured(data interface{}, format string) error {
+	switch format {
+	case "json":
+		encoder := json.NewEncoder(c.out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(data)
+	case "yaml":
+		output, err := yaml.Marshal(data)
+		if err != nil {
+			return err
+		}
+		_, err = c.out.Write(output)
+		return err
+	default:
+		return fmt.Errorf("unknown output format %q", format)
+	}
+}
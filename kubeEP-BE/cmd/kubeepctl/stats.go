@@ -0,0 +1,153 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// runStats prints the samples of a scheduled hpa config (hpa, pod) or an updated node pool as CSV
+func runStats(ctx context.Context, c *cli, args []string) error {
+	if len(args) != 2 {
+		return errors.New("usage: kubeepctl stats hpa|node-pool|pod ID")
+	}
+	id, err := parseID(args[1], "id")
+	if err != nil {
+		return err
+	}
+	api, err := c.client()
+	if err != nil {
+		return err
+	}
+	w := csv.NewWriter(c.out)
+
+	switch args[0] {
+	case "hpa":
+		statuses, err := api.ListHPAStatus(ctx, id)
+		if err != nil {
+			return err
+		}
+		w.Write(
+			[]string{
+				"created_at",
+				"replicas",
+				"available_replicas",
+				"ready_replicas",
+				"unavailable_replicas",
+				"hpa_min_replicas",
+				"hpa_max_replicas",
+				"hpa_current_replicas",
+				"hpa_desired_replicas",
+				"scaling_limited",
+				"scaling_limited_reason",
+				"metrics",
+			},
+		)
+		for _, status := range statuses {
+			var metrics []string
+			for _, metric := range status.Metrics {
+				metrics = append(metrics, fmt.Sprintf("%s=%s/%s", metric.Name, metric.Current, metric.Target))
+			}
+			w.Write(
+				[]string{
+					status.CreatedAt.Format(time.RFC3339),
+					formatInt(int64(status.Replicas)),
+					formatInt(int64(status.AvailableReplicas)),
+					formatInt(int64(status.ReadyReplicas)),
+					formatInt(int64(status.UnavailableReplicas)),
+					formatInt(int64(status.HPAMinReplicas)),
+					formatInt(int64(status.HPAMaxReplicas)),
+					formatInt(int64(status.HPACurrentReplicas)),
+					formatInt(int64(status.HPADesiredReplicas)),
+					strconv.FormatBool(status.ScalingLimited),
+					status.ScalingLimitedReason,
+					strings.Join(metrics, ";"),
+				},
+			)
+		}
+	case "node-pool":
+		statuses, err := api.ListNodePoolStatus(ctx, id)
+		if err != nil {
+			return err
+		}
+		w.Write(
+			[]string{
+				"created_at",
+				"count",
+				"allocatable_cpu_millis",
+				"allocatable_memory_bytes",
+				"requested_cpu_millis",
+				"requested_memory_bytes",
+				"usage_cpu_millis",
+				"usage_memory_bytes",
+			},
+		)
+		for _, status := range statuses {
+			w.Write(
+				[]string{
+					status.CreatedAt.Format(time.RFC3339),
+					formatInt(int64(status.Count)),
+					formatInt(status.AllocatableCPUMillis),
+					formatInt(status.AllocatableMemoryBytes),
+					formatInt(status.RequestedCPUMillis),
+					formatInt(status.RequestedMemoryBytes),
+					formatOptionalInt64(status.UsageCPUMillis),
+					formatOptionalInt64(status.UsageMemoryBytes),
+				},
+			)
+		}
+	case "pod":
+		statuses, err := api.ListPodStatus(ctx, id)
+		if err != nil {
+			return err
+		}
+		w.Write(
+			[]string{
+				"created_at",
+				"pending_pods",
+				"unschedulable_pods",
+				"failed_scheduling_events",
+				"restart_count",
+				"oom_killed_containers",
+				"started_pods",
+				"avg_startup_latency_seconds",
+				"max_startup_latency_seconds",
+			},
+		)
+		for _, status := range statuses {
+			w.Write(
+				[]string{
+					status.CreatedAt.Format(time.RFC3339),
+					formatInt(int64(status.PendingPods)),
+					formatInt(int64(status.UnschedulablePods)),
+					formatInt(int64(status.FailedSchedulingEvents)),
+					formatInt(int64(status.RestartCount)),
+					formatInt(int64(status.OOMKilledContainers)),
+					formatInt(int64(status.StartedPods)),
+					strconv.FormatFloat(status.AvgStartupLatencySeconds, 'f', -1, 64),
+					strconv.FormatFloat(status.MaxStartupLatencySeconds, 'f', -1, 64),
+				},
+			)
+		}
+	default:
+		return fmt.Errorf("unknown statistic %q", args[0])
+	}
+
+	w.Flush()
+	return w.Error()
+}
+
+func formatInt(value int64) string {
+	return strconv.FormatInt(value, 10)
+}
+
+func formatOptionalInt64(value *int64) string {
+	if value == nil {
+		return ""
+	}
+	return formatInt(*value)
+}
@@ -0,0 +1,241 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/pkg/client"
+	"os"
+	"text/tabwriter"
+	"time"
+)
+
+const clearScreen = "\033[H\033[2J"
+
+type watchedHPA struct {
+	namespace   string
+	name        string
+	minReplicas *int32
+	maxReplicas int32
+	status      *client.HPAStatus
+}
+
+type watchedNodePool struct {
+	name    string
+	maxNode int32
+	status  *client.NodePoolStatus
+}
+
+// eventWatch keeps the latest sample of every hpa and node pool of the watched event
+type eventWatch struct {
+	event         *client.EventDetail
+	status        client.EventStatus
+	message       string
+	hpas          []*watchedHPA
+	hpaByID       map[uuid.UUID]*watchedHPA
+	nodePools     []*watchedNodePool
+	nodePoolByID  map[uuid.UUID]*watchedNodePool
+	lastUpdatedAt time.Time
+}
+
+// runWatch follows the event stream until the event succeeds or fails, a terminal gets a live
+// table while a pipe (e.g. a CI log) gets one line per update. A failed event fails the command
+func runWatch(ctx context.Context, c *cli, args []string) error {
+	if err := requireArgs(args, 1, "watch ID"); err != nil {
+		return err
+	}
+	eventID, err := parseID(args[0], "event id")
+	if err != nil {
+		return err
+	}
+	api, err := c.client()
+	if err != nil {
+		return err
+	}
+	event, err := api.GetEvent(ctx, eventID)
+	if err != nil {
+		return err
+	}
+
+	watch := newEventWatch(event)
+	live := isTerminal(os.Stdout)
+	if live {
+		watch.render(c)
+	}
+	err = api.StreamEvent(
+		ctx, eventID, func(message *client.StreamMessage) error {
+			line := watch.apply(message)
+			if live {
+				watch.render(c)
+			} else {
+				fmt.Fprintln(c.out, line)
+			}
+			return nil
+		},
+	)
+	// Interrupting the watch is not a failure
+	if err != nil && ctx.Err() == nil {
+		return err
+	}
+	if watch.status == client.EventFailed {
+		return fmt.Errorf("event %s failed : %s", event.Name, watch.message)
+	}
+	return nil
+}
+
+func newEventWatch(event *client.EventDetail) *eventWatch {
+	watch := &eventWatch{
+		event:        event,
+		status:       event.Status,
+		hpaByID:      map[uuid.UUID]*watchedHPA{},
+		nodePoolByID: map[uuid.UUID]*watchedNodePool{},
+	}
+	for _, hpaConfig := range event.ModifiedHPAConfigs {
+		hpa := &watchedHPA{
+			namespace:   hpaConfig.Namespace,
+			name:        hpaConfig.Name,
+			minReplicas: hpaConfig.MinReplicas,
+			maxReplicas: hpaConfig.MaxReplicas,
+		}
+		watch.hpas = append(watch.hpas, hpa)
+		watch.hpaByID[hpaConfig.ID] = hpa
+	}
+	for _, nodePoolData := range event.UpdatedNodePools {
+		nodePool := &watchedNodePool{name: nodePoolData.NodePoolName, maxNode: nodePoolData.MaxNode}
+		watch.nodePools = append(watch.nodePools, nodePool)
+		watch.nodePoolByID[nodePoolData.ID] = nodePool
+	}
+	return watch
+}
+
+// apply stores a stream message and describes it in one line
+func (w *eventWatch) apply(message *client.StreamMessage) string {
+	switch {
+	case message.HPAStatus != nil:
+		status := message.HPAStatus
+		hpa, ok := w.hpaByID[status.ScheduledHPAConfigID]
+		if !ok {
+			hpa = &watchedHPA{namespace: status.Namespace, name: status.Name}
+			w.hpas = append(w.hpas, hpa)
+			w.hpaByID[status.ScheduledHPAConfigID] = hpa
+		}
+		hpa.status = &status.HPAStatus
+		w.lastUpdatedAt = status.CreatedAt
+		return fmt.Sprintf(
+			"%s hpa %s/%s replicas=%d ready=%d desired=%d max=%d",
+			status.CreatedAt.Format(time.RFC3339),
+			status.Namespace,
+			status.Name,
+			status.Replicas,
+			status.ReadyReplicas,
+			status.HPADesiredReplicas,
+			status.HPAMaxReplicas,
+		)
+	case message.NodePoolStatus != nil:
+		status := message.NodePoolStatus
+		nodePool, ok := w.nodePoolByID[status.UpdatedNodePoolID]
+		if !ok {
+			nodePool = &watchedNodePool{name: status.NodePoolName}
+			w.nodePools = append(w.nodePools, nodePool)
+			w.nodePoolByID[status.UpdatedNodePoolID] = nodePool
+		}
+		nodePool.status = &status.NodePoolStatus
+		w.lastUpdatedAt = status.CreatedAt
+		return fmt.Sprintf(
+			"%s node pool %s nodes=%d",
+			status.CreatedAt.Format(time.RFC3339),
+			status.NodePoolName,
+			status.Count,
+		)
+	default:
+		status := message.EventStatus
+		w.status = client.EventStatus(status.Status)
+		w.message = status.Message
+		line := fmt.Sprintf("%s event %s status=%s", time.Now().Format(time.RFC3339), w.event.Name, status.Status)
+		if status.Message != "" {
+			line += " message=" + status.Message
+		}
+		return line
+	}
+}
+
+func (w *eventWatch) render(c *cli) {
+	fmt.Fprint(c.out, clearScreen)
+	fmt.Fprintf(c.out, "Event: %s (%s)\n", w.event.Name, w.event.ID)
+	fmt.Fprintf(c.out, "Status: %s", w.status)
+	if w.message != "" {
+		fmt.Fprintf(c.out, " (%s)", w.message)
+	}
+	fmt.Fprintln(c.out)
+	if !w.lastUpdatedAt.IsZero() {
+		fmt.Fprintf(c.out, "Last sample: %s\n", w.lastUpdatedAt.Format(time.RFC3339))
+	}
+
+	fmt.Fprintln(c.out)
+	tw := tabwriter.NewWriter(c.out, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, "NAMESPACE\tHPA\tREPLICAS\tREADY\tDESIRED\tMIN\tMAX\tLIMITED")
+	for _, hpa := range w.hpas {
+		if hpa.status == nil {
+			fmt.Fprintf(
+				tw,
+				"%s\t%s\t-\t-\t-\t%s\t%d\t-\n",
+				hpa.namespace,
+				hpa.name,
+				formatOptionalInt32(hpa.minReplicas),
+				hpa.maxReplicas,
+			)
+			continue
+		}
+		fmt.Fprintf(
+			tw,
+			"%s\t%s\t%d\t%d\t%d\t%d\t%d\t%t\n",
+			hpa.namespace,
+			hpa.name,
+			hpa.status.Replicas,
+			hpa.status.ReadyReplicas,
+			hpa.status.HPADesiredReplicas,
+			hpa.status.HPAMinReplicas,
+			hpa.status.HPAMaxReplicas,
+			hpa.status.ScalingLimited,
+		)
+	}
+	tw.Flush()
+
+	if len(w.nodePools) == 0 {
+		return
+	}
+	fmt.Fprintln(c.out)
+	tw = tabwriter.NewWriter(c.out, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, "NODE POOL\tNODES\tMAX NODES\tCPU REQUESTED\tMEMORY REQUESTED")
+	for _, nodePool := range w.nodePools {
+		if nodePool.status == nil {
+			fmt.Fprintf(tw, "%s\t-\t%d\t-\t-\n", nodePool.name, nodePool.maxNode)
+			continue
+		}
+		fmt.Fprintf(
+			tw,
+			"%s\t%d\t%d\t%s\t%s\n",
+			nodePool.name,
+			nodePool.status.Count,
+			nodePool.maxNode,
+			formatRatio(nodePool.status.RequestedCPUMillis, nodePool.status.AllocatableCPUMillis),
+			formatRatio(nodePool.status.RequestedMemoryBytes, nodePool.status.AllocatableMemoryBytes),
+		)
+	}
+	tw.Flush()
+}
+
+func formatRatio(requested, allocatable int64) string {
+	if allocatable == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.0f%%", float64(requested)*100/float64(allocatable))
+}
+
+func isTerminal(file *os.File) bool {
+	info, err := file.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
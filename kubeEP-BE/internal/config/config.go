@@ -6,8 +6,126 @@ import (
 )
 
 type Config struct {
-	Database databaseConfig `yaml:"database"`
-	Cors     corsConfig     `yaml:"cors"`
+	Database     databaseConfig     `yaml:"database"`
+	Cors         corsConfig         `yaml:"cors"`
+	Capacity     CapacityConfig     `yaml:"capacity"`
+	Cron         CronConfig         `yaml:"cron"`
+	Timescale    TimescaleConfig    `yaml:"timescale"`
+	ClientCache  ClientCacheConfig  `yaml:"client-cache"`
+	Metrics      MetricsConfig      `yaml:"metrics"`
+	Notification NotificationConfig `yaml:"notification"`
+	CloudEvents  CloudEventsConfig  `yaml:"cloud-events"`
+	Controller   ControllerConfig   `yaml:"controller"`
+	Auth         AuthConfig         `yaml:"auth"`
+}
+
+// AuthConfig holds the tokens accepted as bearer token by the api, the api is open when Tokens is
+// empty. /metrics and the OpenAPI document stay unauthenticated
+type AuthConfig struct {
+	Tokens []string `yaml:"tokens"`
+}
+
+// ControllerConfig is used by the controller reconciling the ScheduledEvent resources through the
+// kubeEP api, the controller uses the in cluster config unless Kubeconfig is set and watches every
+// namespace when Namespace is empty
+type ControllerConfig struct {
+	APIURL         string `yaml:"api-url"`
+	APIToken       string `yaml:"api-token"`
+	Kubeconfig     string `yaml:"kubeconfig"`
+	Namespace      string `yaml:"namespace"`
+	ResyncSeconds  int64  `yaml:"resync-seconds"`
+	Workers        int    `yaml:"workers"`
+	TimeoutSeconds int64  `yaml:"timeout-seconds"`
+}
+
+// CloudEventsConfig selects the sink of the published state changes, publishing is disabled when
+// Sink is empty
+type CloudEventsConfig struct {
+	Sink        string            `yaml:"sink"`
+	Source      string            `yaml:"source"`
+	RedisStream RedisStreamConfig `yaml:"redis-stream"`
+}
+
+type RedisStreamConfig struct {
+	Stream string `yaml:"stream"`
+	MaxLen int64  `yaml:"max-len"`
+}
+
+// NotificationConfig holds the delivery retry policy and the smtp server of the email channels, the
+// backoff doubles after every failed attempt up to MaxBackoffSeconds
+type NotificationConfig struct {
+	MaxAttempts           int32      `yaml:"max-attempts"`
+	InitialBackoffSeconds int64      `yaml:"initial-backoff-seconds"`
+	MaxBackoffSeconds     int64      `yaml:"max-backoff-seconds"`
+	TimeoutSeconds        int64      `yaml:"timeout-seconds"`
+	SMTP                  SMTPConfig `yaml:"smtp"`
+}
+
+type SMTPConfig struct {
+	Host     string `yaml:"host"`
+	Port     string `yaml:"port"`
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+	From     string `yaml:"from"`
+}
+
+type ClientCacheConfig struct {
+	TTLMinutes int64 `yaml:"ttl-minutes"`
+}
+
+// MetricsConfig holds the address of the cron metrics server, the api serves the metrics on its
+// own port
+type MetricsConfig struct {
+	CronAddress string            `yaml:"cron-address"`
+	RemoteWrite RemoteWriteConfig `yaml:"remote-write"`
+}
+
+// RemoteWriteConfig pushes the watch samples of the cron to a Prometheus remote write endpoint,
+// disabled when URL is empty
+type RemoteWriteConfig struct {
+	URL             string            `yaml:"url"`
+	IntervalSeconds int64             `yaml:"interval-seconds"`
+	TimeoutSeconds  int64             `yaml:"timeout-seconds"`
+	Username        string            `yaml:"username"`
+	Password        string            `yaml:"password"`
+	BearerToken     string            `yaml:"bearer-token"`
+	ExternalLabels  map[string]string `yaml:"external-labels"`
+}
+
+type CronConfig struct {
+	SchedulerIntervalSeconds int64         `yaml:"scheduler-interval-seconds"`
+	Watcher                  WatcherConfig `yaml:"watcher"`
+}
+
+// WatcherConfig holds the sampling defaults of events without their own watch config, with
+// adaptive sampling the interval doubles every DenseWindowMinutes after the event start time
+type WatcherConfig struct {
+	DefaultIntervalSeconds int64 `yaml:"default-interval-seconds"`
+	DenseWindowMinutes     int64 `yaml:"dense-window-minutes"`
+	// Changes of the watched objects are sampled at most once per MinChangeIntervalSeconds
+	MinChangeIntervalSeconds int64 `yaml:"min-change-interval-seconds"`
+}
+
+// TimescaleConfig bounds the storage of the status hypertables, zero disables the policy
+type TimescaleConfig struct {
+	// The event reports and recommendations read the raw samples, keep it disabled to keep their history
+	RawRetentionDays       int64 `yaml:"raw-retention-days"`
+	AggregateRetentionDays int64 `yaml:"aggregate-retention-days"`
+}
+
+type CapacityConfig struct {
+	SidecarProfiles []SidecarProfile `yaml:"sidecar-profiles"`
+}
+
+// SidecarProfile describes a container injected at admission (e.g. istio-proxy) which is absent
+// from the deployment template but still requests resources on the node. An init container of the
+// template with the profile name is counted as a native sidecar
+type SidecarProfile struct {
+	Name             string   `yaml:"name"`
+	Namespaces       []string `yaml:"namespaces"`
+	OptOutAnnotation string   `yaml:"opt-out-annotation"`
+	CPU              string   `yaml:"cpu"`
+	Memory           string   `yaml:"memory"`
 }
 
 type corsConfig struct {
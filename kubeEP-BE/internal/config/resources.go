@@ -10,4 +10,5 @@ type KubeEPResources struct {
 	DB            *gorm.DB
 	ValidatorInst *validator.Validate
 	Redis         *redis.Client
+	Config        *Config
 }
@@ -3,6 +3,7 @@ package errorConstant
 const (
 	ClusterNotFound       = "cluster %s not found"
 	ClusterExists         = "cluster %s already exist"
+	ClusterAmbiguous      = "cluster %s exists in several datacenters"
 	HPAListError          = "hpa list error"
 	HPAError              = "hpa error"
 	GetClusterListError   = "get cluster list error"
@@ -10,4 +11,8 @@ const (
 	TargetRefResolveError = "target ref resolve error"
 	DeploymentNotFound    = "deployment not found"
 	NoExistingNode        = "no existing node found"
+	NoInstanceGroup       = "no instance group found"
+	HPANotFound           = "hpa not found"
+	InformerSyncError     = "informer cache sync failed"
+	GKEOperationTimeout   = "gke operation %s of node pool %s timed out"
 )
@@ -0,0 +1,5 @@
+package errorConstant
+
+const (
+	ControllerAPIURLRequired = "controller api url is required"
+)
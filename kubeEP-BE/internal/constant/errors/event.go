@@ -1,6 +1,9 @@
 package errorConstant
 
 const (
-	EventExist    = "event already exist"
-	EventNotExist = "event not exist"
+	EventExist                   = "event already exist"
+	EventNotExist                = "event not exist"
+	EventTimePassed              = "event start time or end time has passed"
+	EventReportNotExist          = "event report not exist"
+	EventRecommendationNoHistory = "no successful past event on the cluster"
 )
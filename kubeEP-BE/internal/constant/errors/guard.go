@@ -0,0 +1,12 @@
+package errorConstant
+
+const (
+	GuardConditionUnknown    = "guard rule condition unknown"
+	GuardActionUnknown       = "guard rule action unknown"
+	GuardActionValueRequired = "guard rule action value is required"
+	GuardNodePoolRequired    = "guard rule node pool name is required"
+	GuardNodePoolNotExist    = "guard rule node pool not exist"
+	GuardNodePoolUnsupported = "guard rule node pool action is not supported by the datacenter"
+	GuardHPANotExist         = "guard rule hpa not exist"
+	GuardHPANotModified      = "guard rule hpa %s namespace %s is not modified by the event"
+)
@@ -0,0 +1,9 @@
+package errorConstant
+
+const (
+	NotificationChannelTypeUnknown        = "notification channel type unknown"
+	NotificationChannelURLRequired        = "notification channel url is required"
+	NotificationChannelRecipientsRequired = "notification channel recipients are required"
+	NotificationChannelNotExist           = "notification channel not exist"
+	NotificationTransitionUnknown         = "notification transition unknown"
+)
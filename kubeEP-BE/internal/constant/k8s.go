@@ -9,7 +9,22 @@ const Deployment = "Deployment"
 const NameAndNamespaceKeyFormat = "%s|%s"
 
 const (
-	GCPNodePoolLabel = "cloud.google.com/gke-nodepool"
+	GCPNodePoolLabel         = "cloud.google.com/gke-nodepool"
+	GCPKubeLabelsMetadataKey = "kube-labels"
+	K8sInstanceTypeLabel     = "node.kubernetes.io/instance-type"
+	K8sTopologyZoneLabel     = "topology.kubernetes.io/zone"
+)
+
+const GPUResourceName = "nvidia.com/gpu"
+
+const (
+	K8sFailedSchedulingReason = "FailedScheduling"
+	K8sOOMKilledReason        = "OOMKilled"
+)
+
+const (
+	GKEMaxNodePerZone  = int32(1000)
+	GCPCPUsQuotaMetric = "CPUS"
 )
 
 var (
@@ -8,3 +8,7 @@ const (
 	AutoscalingV2Beta1 HPAVersion = "autoscaling/v2beta1"
 	AutoscalingV2      HPAVersion = "autoscaling/v2"
 )
+
+// HPATooManyReplicasReason is the ScalingLimited condition reason when the desired replicas is
+// capped by max replicas
+const HPATooManyReplicasReason = "TooManyReplicas"
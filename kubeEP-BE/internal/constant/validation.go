@@ -0,0 +1,22 @@
+package constant
+
+type ValidationLevel string
+
+const (
+	ValidationError   ValidationLevel = "ERROR"
+	ValidationWarning ValidationLevel = "WARNING"
+)
+
+const (
+	ValidationInvalidReplicaRange          = "INVALID_REPLICA_RANGE"
+	ValidationInvalidMinReplicas           = "INVALID_MIN_REPLICAS"
+	ValidationInvalidPostEventReplicaRange = "INVALID_POST_EVENT_REPLICA_RANGE"
+	ValidationDuplicateHPA                 = "DUPLICATE_HPA"
+	ValidationHPANotFound                  = "HPA_NOT_FOUND"
+	ValidationUnsupportedTarget            = "UNSUPPORTED_TARGET"
+	ValidationTargetNotFound               = "TARGET_NOT_FOUND"
+	ValidationMaxReplicasBelowCurrent      = "MAX_REPLICAS_BELOW_CURRENT"
+	ValidationAutoscalingDisabled          = "AUTOSCALING_DISABLED"
+	ValidationMaxPodsConstraintMissing     = "MAX_PODS_CONSTRAINT_MISSING"
+	ValidationNoExistingNode               = "NO_EXISTING_NODE"
+)
@@ -0,0 +1,27 @@
+package controller
+
+import (
+	"errors"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/pkg/client"
+	"net/http"
+)
+
+// specError is an invalid resource spec, retrying gives the same error until the spec changes
+type specError struct {
+	message string
+}
+
+func (e *specError) Error() string {
+	return e.message
+}
+
+// isPermanentError reports the errors which are not retried: the invalid specs and the errors
+// answered by the kubeEP api, only the server errors of the api are retried
+func isPermanentError(err error) bool {
+	var specErr *specError
+	if errors.As(err, &specErr) {
+		return true
+	}
+	var apiErr *client.Error
+	return errors.As(err, &apiErr) && apiErr.StatusCode < http.StatusInternalServerError
+}
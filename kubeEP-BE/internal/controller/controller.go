@@ -0,0 +1,178 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/config"
+	errorConstant "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant/errors"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/pkg/k8s/crd"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/pkg/client"
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/util/workqueue"
+	"net/http"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+const (
+	defaultResyncSeconds  = 30
+	defaultWorkers        = 2
+	defaultTimeoutSeconds = 30
+)
+
+type Controller interface {
+	Start()
+}
+
+type controller struct {
+	dynamicClient dynamic.Interface
+	factory       dynamicinformer.DynamicSharedInformerFactory
+	informer      cache.SharedIndexInformer
+	queue         workqueue.RateLimitingInterface
+	api           *client.Client
+	workers       int
+}
+
+func newController(
+	dynamicClient dynamic.Interface,
+	api *client.Client,
+	namespace string,
+	resync time.Duration,
+	workers int,
+) Controller {
+	factory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(
+		dynamicClient,
+		resync,
+		namespace,
+		nil,
+	)
+	c := &controller{
+		dynamicClient: dynamicClient,
+		factory:       factory,
+		informer:      factory.ForResource(crd.ScheduledEventGVR).Informer(),
+		queue: workqueue.NewNamedRateLimitingQueue(
+			workqueue.DefaultControllerRateLimiter(),
+			crd.ScheduledEventResource,
+		),
+		api:     api,
+		workers: workers,
+	}
+	// The resync enqueues every resource again, it refreshes the status of the running events
+	c.informer.AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc: c.enqueue,
+			UpdateFunc: func(_, obj interface{}) {
+				c.enqueue(obj)
+			},
+			DeleteFunc: c.enqueue,
+		},
+	)
+	return c
+}
+
+func BuildController(controllerConfig config.ControllerConfig) (Controller, error) {
+	if controllerConfig.APIURL == "" {
+		return nil, errors.New(errorConstant.ControllerAPIURLRequired)
+	}
+
+	var restConfig *rest.Config
+	var err error
+	if controllerConfig.Kubeconfig != "" {
+		restConfig, err = clientcmd.BuildConfigFromFlags("", controllerConfig.Kubeconfig)
+	} else {
+		restConfig, err = rest.InClusterConfig()
+	}
+	if err != nil {
+		return nil, err
+	}
+	dynamicClient, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	resyncSeconds := controllerConfig.ResyncSeconds
+	if resyncSeconds <= 0 {
+		resyncSeconds = defaultResyncSeconds
+	}
+	workers := controllerConfig.Workers
+	if workers <= 0 {
+		workers = defaultWorkers
+	}
+	timeoutSeconds := controllerConfig.TimeoutSeconds
+	if timeoutSeconds <= 0 {
+		timeoutSeconds = defaultTimeoutSeconds
+	}
+
+	return newController(
+		dynamicClient,
+		client.New(
+			controllerConfig.APIURL,
+			client.WithToken(controllerConfig.APIToken),
+			client.WithHTTPClient(
+				&http.Client{Timeout: time.Duration(timeoutSeconds) * time.Second},
+			),
+		),
+		controllerConfig.Namespace,
+		time.Duration(resyncSeconds)*time.Second,
+		workers,
+	), nil
+}
+
+func (c *controller) enqueue(obj interface{}) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		log.Errorf("[Controller] Error building key : %s", err.Error())
+		return
+	}
+	c.queue.Add(key)
+}
+
+func (c *controller) Start() {
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGTERM, syscall.SIGINT)
+	defer cancel()
+	defer c.queue.ShutDown()
+
+	c.factory.Start(ctx.Done())
+	log.Info("[Controller] Waiting for the scheduled event cache")
+	if !cache.WaitForCacheSync(ctx.Done(), c.informer.HasSynced) {
+		log.Error("[Controller] Scheduled event cache is not synced")
+		return
+	}
+
+	log.Infof("[Controller] Starting %d workers", c.workers)
+	for i := 0; i < c.workers; i++ {
+		go wait.UntilWithContext(ctx, c.runWorker, time.Second)
+	}
+
+	<-ctx.Done()
+	log.Info("[Controller] Stopping")
+}
+
+func (c *controller) runWorker(ctx context.Context) {
+	for c.processNextItem(ctx) {
+	}
+}
+
+func (c *controller) processNextItem(ctx context.Context) bool {
+	item, shutdown := c.queue.Get()
+	if shutdown {
+		return false
+	}
+	defer c.queue.Done(item)
+
+	key := item.(string)
+	if err := c.reconcile(ctx, key); err != nil {
+		log.Errorf("[Controller] Scheduled event %s, Reconcile error : %s", key, err.Error())
+		c.queue.AddRateLimited(key)
+		return true
+	}
+	c.queue.Forget(key)
+	return true
+}
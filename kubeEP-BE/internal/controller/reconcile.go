@@ -0,0 +1,392 @@
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	errorConstant "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant/errors"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/pkg/k8s/crd"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/pkg/client"
+	log "github.com/sirupsen/logrus"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/util/retry"
+	"time"
+)
+
+// reconcile pushes the resource spec to kubeEP and writes the kubeEP event status back, errors
+// answered by the api are reported in the status instead of being retried
+func (c *controller) reconcile(ctx context.Context, key string) error {
+	obj, exists, err := c.informer.GetIndexer().GetByKey(key)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return nil
+	}
+	object := obj.(*unstructured.Unstructured)
+	scheduledEvent, err := crd.FromUnstructured(object)
+	if err != nil {
+		return err
+	}
+
+	if scheduledEvent.DeletionTimestamp != nil {
+		return c.finalize(ctx, object, scheduledEvent)
+	}
+	if !hasFinalizer(object) {
+		updated := object.DeepCopy()
+		updated.SetFinalizers(append(updated.GetFinalizers(), crd.EventFinalizer))
+		_, err := c.dynamicClient.Resource(crd.ScheduledEventGVR).
+			Namespace(object.GetNamespace()).
+			Update(ctx, updated, metav1.UpdateOptions{})
+		return err
+	}
+
+	status := scheduledEvent.Status
+	status.ObservedGeneration = scheduledEvent.Generation
+	specHash, err := hashSpec(scheduledEvent.Spec)
+	if err != nil {
+		return err
+	}
+
+	if err := c.syncEvent(ctx, scheduledEvent, &status, specHash); err != nil {
+		if !isPermanentError(err) {
+			return err
+		}
+		log.Errorf("[Controller] Scheduled event %s, Sync error : %s", key, err.Error())
+		status.Phase = crd.PhaseSyncFailed
+		status.Message = err.Error()
+		return c.updateStatus(ctx, object, scheduledEvent.Status, status)
+	}
+	// The new event id is persisted before anything else can fail, the status update enqueues the
+	// resource again
+	if scheduledEvent.Status.EventID == "" {
+		status.Message = ""
+		return c.updateStatus(ctx, object, scheduledEvent.Status, status)
+	}
+
+	eventID, _ := uuid.Parse(status.EventID)
+	eventData, err := c.api.GetEvent(ctx, eventID)
+	if err != nil {
+		if !isPermanentError(err) {
+			return err
+		}
+		status.Phase = crd.PhaseSyncFailed
+		status.Message = err.Error()
+		return c.updateStatus(ctx, object, scheduledEvent.Status, status)
+	}
+	status.Phase = crd.ScheduledEventPhase(eventData.Status)
+	status.Message = ""
+
+	return c.updateStatus(ctx, object, scheduledEvent.Status, status)
+}
+
+// syncEvent registers the event on the first sync and updates it when the spec hash changes, an
+// event already registered for the resource is adopted and updated
+func (c *controller) syncEvent(
+	ctx context.Context,
+	scheduledEvent *crd.ScheduledEvent,
+	status *crd.ScheduledEventStatus,
+	specHash string,
+) error {
+	if status.EventID == "" {
+		clusterID, err := uuid.Parse(scheduledEvent.Spec.ClusterID)
+		if err != nil {
+			return &specError{message: fmt.Sprintf(errorConstant.ParamInvalid, "clusterID")}
+		}
+		eventID, err := c.findEvent(ctx, clusterID, eventOwner(scheduledEvent))
+		if err != nil {
+			return err
+		}
+		if eventID == nil {
+			registeredID, err := c.api.RegisterEvent(
+				ctx,
+				buildEventDataRequest(scheduledEvent, clusterID),
+			)
+			if err != nil {
+				return err
+			}
+			log.Infof(
+				"[Controller] Scheduled event %s/%s, Registered event %s",
+				scheduledEvent.Namespace,
+				scheduledEvent.Name,
+				registeredID,
+			)
+			status.EventID = registeredID.String()
+			status.SpecHash = specHash
+			return nil
+		}
+
+		log.Infof(
+			"[Controller] Scheduled event %s/%s, Adopted event %s",
+			scheduledEvent.Namespace,
+			scheduledEvent.Name,
+			eventID,
+		)
+		// The spec may have changed since the registration, the adopted event is updated
+		status.EventID = eventID.String()
+		status.SpecHash = ""
+	}
+
+	if status.SpecHash == specHash && status.Phase != crd.PhaseSyncFailed {
+		return nil
+	}
+	eventID, err := uuid.Parse(status.EventID)
+	if err != nil {
+		return &specError{message: err.Error()}
+	}
+	if err := c.api.UpdateEvent(ctx, buildUpdateEventDataRequest(scheduledEvent, eventID)); err != nil {
+		return err
+	}
+	log.Infof(
+		"[Controller] Scheduled event %s/%s, Updated event %s",
+		scheduledEvent.Namespace,
+		scheduledEvent.Name,
+		eventID,
+	)
+	status.SpecHash = specHash
+	return nil
+}
+
+// findEvent looks the event registered for the resource up by its owner, a registration whose status
+// was not persisted is adopted instead of being registered again. The events of other owners are
+// never adopted, even with the same name
+func (c *controller) findEvent(
+	ctx context.Context,
+	clusterID uuid.UUID,
+	owner string,
+) (*uuid.UUID, error) {
+	events, err := c.api.ListEvents(ctx, clusterID)
+	if err != nil {
+		return nil, err
+	}
+	for _, event := range events {
+		if event.Owner == owner {
+			eventID := event.ID
+			return &eventID, nil
+		}
+	}
+	return nil, nil
+}
+
+// finalize deletes the kubeEP event before releasing the resource
+func (c *controller) finalize(
+	ctx context.Context,
+	object *unstructured.Unstructured,
+	scheduledEvent *crd.ScheduledEvent,
+) error {
+	if !hasFinalizer(object) {
+		return nil
+	}
+	if eventID, err := uuid.Parse(scheduledEvent.Status.EventID); err == nil {
+		err := c.api.DeleteEvent(ctx, eventID)
+		if err != nil && err.Error() != errorConstant.EventNotExist {
+			return err
+		}
+		log.Infof(
+			"[Controller] Scheduled event %s/%s, Deleted event %s",
+			scheduledEvent.Namespace,
+			scheduledEvent.Name,
+			eventID,
+		)
+	}
+
+	updated := object.DeepCopy()
+	var finalizers []string
+	for _, finalizer := range updated.GetFinalizers() {
+		if finalizer != crd.EventFinalizer {
+			finalizers = append(finalizers, finalizer)
+		}
+	}
+	updated.SetFinalizers(finalizers)
+	_, err := c.dynamicClient.Resource(crd.ScheduledEventGVR).
+		Namespace(object.GetNamespace()).
+		Update(ctx, updated, metav1.UpdateOptions{})
+	return err
+}
+
+// updateStatus skips unchanged status so the resync does not write the resource every time
+func (c *controller) updateStatus(
+	ctx context.Context,
+	object *unstructured.Unstructured,
+	previous, status crd.ScheduledEventStatus,
+) error {
+	previous.LastSyncTime = nil
+	status.LastSyncTime = nil
+	if previous == status {
+		return nil
+	}
+	now := metav1.NewTime(time.Now())
+	status.LastSyncTime = &now
+
+	// A lost event id is recovered by owner on the next sync, conflicts are retried on the latest object
+	resource := c.dynamicClient.Resource(crd.ScheduledEventGVR).Namespace(object.GetNamespace())
+	return retry.RetryOnConflict(
+		retry.DefaultRetry, func() error {
+			updated, err := crd.StatusToUnstructured(object, status)
+			if err != nil {
+				return err
+			}
+			_, err = resource.UpdateStatus(ctx, updated, metav1.UpdateOptions{})
+			if !k8sErrors.IsConflict(err) {
+				return err
+			}
+			latest, getErr := resource.Get(ctx, object.GetName(), metav1.GetOptions{})
+			if getErr != nil {
+				return getErr
+			}
+			object = latest
+			return err
+		},
+	)
+}
+
+func hasFinalizer(object *unstructured.Unstructured) bool {
+	for _, finalizer := range object.GetFinalizers() {
+		if finalizer == crd.EventFinalizer {
+			return true
+		}
+	}
+	return false
+}
+
+func hashSpec(spec crd.ScheduledEventSpec) (string, error) {
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func eventOwner(scheduledEvent *crd.ScheduledEvent) string {
+	return fmt.Sprintf(crd.EventOwnerFormat, scheduledEvent.UID)
+}
+
+func eventName(scheduledEvent *crd.ScheduledEvent) string {
+	if scheduledEvent.Spec.Name != "" {
+		return scheduledEvent.Spec.Name
+	}
+	return scheduledEvent.Name
+}
+
+func buildEventDataRequest(
+	scheduledEvent *crd.ScheduledEvent,
+	clusterID uuid.UUID,
+) *client.EventDataRequest {
+	spec := scheduledEvent.Spec
+	name := eventName(scheduledEvent)
+	owner := eventOwner(scheduledEvent)
+	return &client.EventDataRequest{
+		Name:               &name,
+		StartTime:          &spec.StartTime.Time,
+		EndTime:            &spec.EndTime.Time,
+		ClusterID:          &clusterID,
+		CalculateNodePool:  spec.CalculateNodePool,
+		ExecuteConfigAt:    &spec.ExecuteConfigAt.Time,
+		WatchingAt:         &spec.WatchingAt.Time,
+		ModifiedHPAConfigs: buildModifiedHPAConfigRequests(spec.ModifiedHPAConfigs),
+		ScaleDown:          buildScaleDownRequest(spec.ScaleDown),
+		Watch:              buildWatchRequest(spec.Watch),
+		GuardRules:         buildGuardRuleRequests(spec.GuardRules),
+		Owner:              &owner,
+	}
+}
+
+func buildUpdateEventDataRequest(
+	scheduledEvent *crd.ScheduledEvent,
+	eventID uuid.UUID,
+) *client.UpdateEventDataRequest {
+	// The cluster of an event can not be changed, the update request has no cluster
+	req := buildEventDataRequest(scheduledEvent, uuid.UUID{})
+	guardRules := req.GuardRules
+	// An empty slice clears the rules removed from the spec
+	if guardRules == nil {
+		guardRules = []client.EventGuardRuleData{}
+	}
+	return &client.UpdateEventDataRequest{
+		Name:               req.Name,
+		StartTime:          req.StartTime,
+		EndTime:            req.EndTime,
+		ModifiedHPAConfigs: req.ModifiedHPAConfigs,
+		CalculateNodePool:  req.CalculateNodePool,
+		ExecuteConfigAt:    req.ExecuteConfigAt,
+		WatchingAt:         req.WatchingAt,
+		EventID:            &eventID,
+		ScaleDown:          req.ScaleDown,
+		Watch:              req.Watch,
+		GuardRules:         guardRules,
+	}
+}
+
+func buildModifiedHPAConfigRequests(
+	configs []crd.ModifiedHPAConfig,
+) []client.EventModifiedHPAConfigData {
+	var output []client.EventModifiedHPAConfigData
+	for i := range configs {
+		hpaConfig := &configs[i]
+		output = append(
+			output, client.EventModifiedHPAConfigData{
+				Name:                 &hpaConfig.Name,
+				Namespace:            &hpaConfig.Namespace,
+				MinReplicas:          &hpaConfig.MinReplicas,
+				MaxReplicas:          &hpaConfig.MaxReplicas,
+				PostEventMinReplicas: hpaConfig.PostEventMinReplicas,
+				PostEventMaxReplicas: hpaConfig.PostEventMaxReplicas,
+			},
+		)
+	}
+	return output
+}
+
+func buildScaleDownRequest(scaleDown *crd.ScaleDownConfig) *client.EventScaleDownConfig {
+	if scaleDown == nil {
+		return nil
+	}
+	req := &client.EventScaleDownConfig{
+		WindowMinutes: &scaleDown.WindowMinutes,
+		Steps:         &scaleDown.Steps,
+	}
+	if scaleDown.Gate != "" {
+		req.Gate = &scaleDown.Gate
+	}
+	return req
+}
+
+func buildWatchRequest(watch *crd.WatchConfig) *client.EventWatchConfig {
+	if watch == nil {
+		return nil
+	}
+	return &client.EventWatchConfig{
+		IntervalSeconds:    &watch.IntervalSeconds,
+		MaxIntervalSeconds: watch.MaxIntervalSeconds,
+	}
+}
+
+func buildGuardRuleRequests(rules []crd.GuardRule) []client.EventGuardRuleData {
+	var output []client.EventGuardRuleData
+	for i := range rules {
+		rule := &rules[i]
+		req := client.EventGuardRuleData{
+			Condition:       &rule.Condition,
+			Threshold:       rule.Threshold,
+			DurationSeconds: &rule.DurationSeconds,
+			Action:          &rule.Action,
+			ActionValue:     rule.ActionValue,
+			CooldownSeconds: rule.CooldownSeconds,
+		}
+		if rule.HPAName != "" || rule.HPANamespace != "" {
+			req.HPAName = &rule.HPAName
+			req.HPANamespace = &rule.HPANamespace
+		}
+		if rule.NodePoolName != "" {
+			req.NodePoolName = &rule.NodePoolName
+		}
+		output = append(output, req)
+	}
+	return output
+}
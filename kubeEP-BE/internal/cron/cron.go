@@ -0,0 +1,715 @@
+package cron
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/config"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant"
+	UCEntity "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/usecase"
+	k8sInformer "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/pkg/k8s/informer"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/pkg/metrics"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository/model"
+	useCase "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/usecase"
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	v1 "k8s.io/api/autoscaling/v1"
+	"k8s.io/api/autoscaling/v2beta1"
+	"k8s.io/api/autoscaling/v2beta2"
+	v1Core "k8s.io/api/core/v1"
+	v1Option "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+const watchedObjectKeyFormat = "%s/%s"
+
+type Cron interface {
+	Start()
+}
+
+type cron struct {
+	eventUC              useCase.Event
+	clusterUC            useCase.Cluster
+	gcpClusterUC         useCase.GCPCluster
+	gcpDatacenterUC      useCase.GCPDatacenter
+	scheduledHPAConfigUC useCase.ScheduledHPAConfig
+	updatedNodePoolUC    useCase.Statistic
+	scaleDownUC          useCase.ScaleDown
+	capacityConfig       config.CapacityConfig
+	cronConfig           config.CronConfig
+	informerManager      k8sInformer.Manager
+	clientManager        useCase.ClientManager
+	eventReportUC        useCase.EventReport
+	notificationUC       useCase.Notification
+	cloudEventUC         useCase.CloudEvent
+	eventStreamUC        useCase.EventStream
+	guardUC              useCase.Guard
+	tx                   *gorm.DB
+}
+
+func newCron(
+	eventUC useCase.Event,
+	clusterUC useCase.Cluster,
+	gcpClusterUC useCase.GCPCluster,
+	gcpDatacenterUC useCase.GCPDatacenter,
+	scheduledHPAConfigUC useCase.ScheduledHPAConfig,
+	updatedNodePoolUC useCase.Statistic,
+	scaleDownUC useCase.ScaleDown,
+	capacityConfig config.CapacityConfig,
+	cronConfig config.CronConfig,
+	informerManager k8sInformer.Manager,
+	clientManager useCase.ClientManager,
+	eventReportUC useCase.EventReport,
+	notificationUC useCase.Notification,
+	cloudEventUC useCase.CloudEvent,
+	eventStreamUC useCase.EventStream,
+	guardUC useCase.Guard,
+	tx *gorm.DB,
+) Cron {
+	return &cron{
+		eventUC:              eventUC,
+		tx:                   tx,
+		clusterUC:            clusterUC,
+		gcpClusterUC:         gcpClusterUC,
+		gcpDatacenterUC:      gcpDatacenterUC,
+		scheduledHPAConfigUC: scheduledHPAConfigUC,
+		updatedNodePoolUC:    updatedNodePoolUC,
+		scaleDownUC:          scaleDownUC,
+		capacityConfig:       capacityConfig,
+		cronConfig:           cronConfig,
+		informerManager:      informerManager,
+		clientManager:        clientManager,
+		eventReportUC:        eventReportUC,
+		notificationUC:       notificationUC,
+		cloudEventUC:         cloudEventUC,
+		eventStreamUC:        eventStreamUC,
+		guardUC:              guardUC,
+	}
+}
+
+// updateEvent saves the event without a transaction, so its status change is published right away
+func (c *cron) updateEvent(db *gorm.DB, e *UCEntity.Event) error {
+	transition, err := c.eventUC.UpdateEvent(db, e)
+	if err != nil {
+		return err
+	}
+	c.eventUC.PublishEventStatusTransition(db, transition)
+	return nil
+}
+
+func (c *cron) handleExecEventError(db *gorm.DB, e *UCEntity.Event, errMsg string) {
+	e.Status = model.EventFailed
+	e.Message = errMsg
+	err := c.updateEvent(db, e)
+	if err != nil {
+		log.Errorf("[EventCronJob] Error Update Event : %s", err.Error())
+	}
+	log.Errorf("[EventCronJob] Event : %s, Error : %s", e.Name, errMsg)
+	c.notificationUC.NotifyEventTransition(db, e, model.TransitionFailed, errMsg)
+}
+
+func (c *cron) notifyFinishedEvent(db *gorm.DB, eventID uuid.UUID) {
+	e, err := c.eventUC.GetEventByID(db, eventID)
+	if err != nil {
+		log.Errorf(
+			"[EventCronJob] Event ID : %s, Error getting finished event : %s",
+			eventID,
+			err.Error(),
+		)
+		return
+	}
+	c.notificationUC.NotifyEventTransition(db, e, model.TransitionFinished, e.Message)
+}
+
+func (c *cron) generateEventReport(db *gorm.DB, eventID uuid.UUID) {
+	report, err := c.eventReportUC.GenerateEventReport(db, eventID)
+	if err != nil {
+		log.Errorf(
+			"[EventCronJob] Event ID : %s, Error generating event report : %s",
+			eventID,
+			err.Error(),
+		)
+		return
+	}
+	log.Infof("[EventCronJob] Event : %s, Generated event report", report.EventName)
+}
+
+func (c *cron) handleWatchEvent(db *gorm.DB, e *UCEntity.Event, errMsg string) {
+	e.Message = errMsg
+	err := c.updateEvent(db, e)
+	if err != nil {
+		log.Errorf("[EventCronJob] Error Update Event : %s", err.Error())
+	}
+	log.Errorf("[EventCronJob] Watching event : %s, Error : %s", e.Name, errMsg)
+}
+
+func (c *cron) watchNodePool(
+	client kubernetes.Interface,
+	clusterCache *k8sInformer.ClusterCache,
+	db *gorm.DB,
+	provider model.DatacenterProvider,
+	event *UCEntity.Event,
+	now time.Time,
+	ctx context.Context,
+	updatedNodePoolMap map[string]uuid.UUID,
+) {
+	nodes, err := clusterCache.Nodes.List(labels.Everything())
+	if err != nil {
+		log.Errorf(
+			"[EventCronJob] Watching event : %s, Watch node pool error : %s",
+			event.Name,
+			err.Error(),
+		)
+		return
+	}
+	// Requested resources are optional like the usage, the node count is sampled without them
+	var nodesRequested map[string]v1Core.ResourceList
+	pods, err := clusterCache.Pods.List(labels.Everything())
+	if err != nil {
+		log.Warnf(
+			"[EventCronJob] Watching event : %s, Node requested resources not available : %s",
+			event.Name,
+			err.Error(),
+		)
+	} else {
+		nodesRequested = c.clusterUC.CalculateNodesRequestedResources(pods)
+	}
+	// Usage is optional, metrics.k8s.io is not available in every cluster
+	nodesUsage, err := c.clusterUC.GetNodesResourceUsage(ctx, client)
+	if err != nil {
+		log.Warnf(
+			"[EventCronJob] Watching event : %s, Node usage not available : %s",
+			event.Name,
+			err.Error(),
+		)
+		nodesUsage = nil
+	}
+
+	nodePoolStatusMap := map[string]*model.NodePoolStatus{}
+	var nodePoolNames []string
+	for _, node := range nodes {
+		nodeLabels := node.Labels
+		var nodePoolName string
+		switch provider {
+		case model.GCP:
+			nodePoolName = nodeLabels[constant.GCPNodePoolLabel]
+		}
+		nodePoolStatus, ok := nodePoolStatusMap[nodePoolName]
+		if !ok {
+			nodePoolStatus = &model.NodePoolStatus{CreatedAt: now}
+			if nodesUsage != nil {
+				nodePoolStatus.UsageCPU = new(int64)
+				nodePoolStatus.UsageMemory = new(int64)
+			}
+			nodePoolStatusMap[nodePoolName] = nodePoolStatus
+			nodePoolNames = append(nodePoolNames, nodePoolName)
+		}
+
+		nodePoolStatus.NodeCount += 1
+		nodePoolStatus.AllocatableCPU += node.Status.Allocatable.Cpu().MilliValue()
+		nodePoolStatus.AllocatableMemory += node.Status.Allocatable.Memory().Value()
+		requested := nodesRequested[node.Name]
+		nodePoolStatus.RequestedCPU += requested.Cpu().MilliValue()
+		nodePoolStatus.RequestedMemory += requested.Memory().Value()
+		if nodesUsage != nil {
+			usage := nodesUsage[node.Name]
+			*nodePoolStatus.UsageCPU += usage.Cpu().MilliValue()
+			*nodePoolStatus.UsageMemory += usage.Memory().Value()
+		}
+	}
+
+	var nodePoolStatusObjects []model.NodePoolStatus
+	for _, nodePoolName := range nodePoolNames {
+		updatedNodePoolID, ok := updatedNodePoolMap[nodePoolName]
+		if !ok {
+			continue
+		}
+		nodePoolStatus := nodePoolStatusMap[nodePoolName]
+		nodePoolStatus.UpdatedNodePoolID.SetUUID(updatedNodePoolID)
+		metrics.SetNodePoolWatchSample(event.Name, event.Cluster.Name, nodePoolName, nodePoolStatus)
+		nodePoolStatusObjects = append(nodePoolStatusObjects, *nodePoolStatus)
+	}
+	if len(nodePoolStatusObjects) == 0 {
+		return
+	}
+
+	err = db.Create(&nodePoolStatusObjects).Error
+	if err != nil {
+		log.Errorf(
+			"[EventCronJob] Watching event : %s, Watch node pool error : %s",
+			event.Name,
+			err.Error(),
+		)
+		return
+	}
+	metrics.ObserveSampleLag(metrics.SampleNodePool, now)
+	c.eventStreamUC.PublishNodePoolStatus(db, event.ID, updatedNodePoolMap, nodePoolStatusObjects)
+	log.Infof(
+		"[EventCronJob] Watching event : %s, Watching node pool at : %s",
+		event.Name,
+		now,
+	)
+}
+
+func (c *cron) watchHPA(
+	deploymentDataMapFunc func(since time.Time) (map[string]*DeploymentPodData, error),
+	db *gorm.DB,
+	event *UCEntity.Event,
+	scheduledHPAConfigs []*UCEntity.EventModifiedHPAConfigData,
+	guard *eventGuard,
+	now time.Time,
+	since time.Time,
+) {
+	deploymentDataMap, err := deploymentDataMapFunc(since)
+	if err != nil {
+		log.Errorf(
+			"[EventCronJob] Watching event : %s, Watch hpa error : %s",
+			event.Name,
+			err.Error(),
+		)
+		return
+	}
+
+	var selectedHPAStatuses []model.HPAStatus
+	var podStatuses []model.PodStatus
+	for _, scheduledHPAConfig := range scheduledHPAConfigs {
+		key := fmt.Sprintf(
+			constant.NameAndNamespaceKeyFormat,
+			scheduledHPAConfig.Name,
+			scheduledHPAConfig.Namespace,
+		)
+		data := deploymentDataMap[key]
+		hpaStatus := model.HPAStatus{
+			CreatedAt:           now,
+			Replicas:            data.Replicas,
+			AvailableReplicas:   data.AvailableReplicas,
+			UnavailableReplicas: data.UnavailableReplicas,
+			ReadyReplicas:       data.ReadyReplicas,
+		}
+		if data.HPAStatus != nil {
+			hpaStatus.HPAMinReplicas = data.HPAStatus.MinReplicas
+			hpaStatus.HPAMaxReplicas = data.HPAStatus.MaxReplicas
+			hpaStatus.HPACurrentReplicas = data.HPAStatus.CurrentReplicas
+			hpaStatus.HPADesiredReplicas = data.HPAStatus.DesiredReplicas
+			hpaStatus.ScalingLimited = data.HPAStatus.ScalingLimited
+			hpaStatus.ScalingLimitedReason = data.HPAStatus.ScalingLimitedReason
+			if metrics, err := json.Marshal(data.HPAStatus.Metrics); err == nil {
+				hpaStatus.Metrics.SetRawMessage(metrics)
+			}
+		}
+		hpaStatus.ScheduledHPAConfigID.SetUUID(scheduledHPAConfig.ID)
+		selectedHPAStatuses = append(selectedHPAStatuses, hpaStatus)
+
+		if data.PodStatistic == nil {
+			metrics.SetHPAWatchSample(
+				event.Name,
+				event.Cluster.Name,
+				scheduledHPAConfig.Namespace,
+				scheduledHPAConfig.Name,
+				&hpaStatus,
+				nil,
+			)
+			continue
+		}
+		podStatistic := data.PodStatistic
+		podStatus := model.PodStatus{
+			CreatedAt:                now,
+			PendingPods:              podStatistic.PendingPods,
+			UnschedulablePods:        podStatistic.UnschedulablePods,
+			FailedSchedulingEvents:   podStatistic.FailedSchedulingEvents,
+			RestartCount:             podStatistic.RestartCount,
+			OOMKilledContainers:      podStatistic.OOMKilledContainers,
+			StartedPods:              podStatistic.StartedPods,
+			AvgStartupLatencySeconds: podStatistic.AvgStartupLatencySeconds,
+			MaxStartupLatencySeconds: podStatistic.MaxStartupLatencySeconds,
+		}
+		podStatus.ScheduledHPAConfigID.SetUUID(scheduledHPAConfig.ID)
+		podStatuses = append(podStatuses, podStatus)
+		metrics.SetHPAWatchSample(
+			event.Name,
+			event.Cluster.Name,
+			scheduledHPAConfig.Namespace,
+			scheduledHPAConfig.Name,
+			&hpaStatus,
+			&podStatus,
+		)
+	}
+
+	err = db.Create(&selectedHPAStatuses).Error
+	if err != nil {
+		log.Errorf(
+			"[EventCronJob] Watching event : %s, Watch hpa error : %s",
+			event.Name,
+			err.Error(),
+		)
+		return
+	}
+
+	if len(podStatuses) != 0 {
+		err = db.Create(&podStatuses).Error
+		if err != nil {
+			log.Errorf(
+				"[EventCronJob] Watching event : %s, Watch pod error : %s",
+				event.Name,
+				err.Error(),
+			)
+			return
+		}
+	}
+	metrics.ObserveSampleLag(metrics.SampleHPA, now)
+	c.eventStreamUC.PublishHPAStatus(db, event.ID, scheduledHPAConfigs, selectedHPAStatuses)
+	c.evaluateGuard(db, guard, scheduledHPAConfigs, deploymentDataMap, now)
+
+	log.Infof(
+		"[EventCronJob] Watching event : %s, Watching hpa at : %s",
+		event.Name,
+		now,
+	)
+}
+
+func (c *cron) watchEvent(e *UCEntity.Event, db *gorm.DB, ctx context.Context) {
+	log.Infof("[EventCronJob] Watching event %s", e.Name)
+	e.Status = model.EventWatching
+
+	err := c.updateEvent(db, e)
+	if err != nil {
+		log.Errorf("[EventCronJob] Error update event : %s", err.Error())
+		return
+	}
+	c.notificationUC.NotifyEventTransition(db, e, model.TransitionWatching, "")
+
+	clusterID := e.Cluster.ID
+	clusterData, err := c.clusterUC.GetClusterAndDatacenterDataByClusterID(db, clusterID)
+	if err != nil {
+		c.handleWatchEvent(db, e, err.Error())
+		return
+	}
+	datacenter := clusterData.Datacenter.Datacenter
+	var kubernetesClient kubernetes.Interface
+
+	// Get Clients
+	switch datacenter {
+	case model.GCP:
+		kubernetesClient, err = c.clientManager.GetKubernetesClient(ctx, clusterData)
+		if err != nil {
+			c.handleWatchEvent(db, e, err.Error())
+			return
+		}
+	}
+
+	scheduledHPAConfigs, err := c.scheduledHPAConfigUC.ListScheduledHPAConfigByEventID(db, e.ID)
+	if err != nil {
+		c.handleWatchEvent(db, e, err.Error())
+		return
+	}
+
+	clusterCache, err := c.informerManager.Acquire(
+		ctx,
+		clusterID,
+		kubernetesClient,
+		clusterData.LatestHPAAPIVersion,
+	)
+	if err != nil {
+		c.handleWatchEvent(db, e, err.Error())
+		return
+	}
+	defer c.informerManager.Release(clusterID)
+	defer metrics.DeleteEventWatchSeries(e.Name)
+	// The in-flight samplers must finish before the series are deleted and the cache is released,
+	// otherwise a late sample sets the gauges of an ended watch again
+	var samplers sync.WaitGroup
+	defer samplers.Wait()
+
+	mapHPAScaleTargetRef := map[string]interface{}{}
+	for _, scheduledHPAConfig := range scheduledHPAConfigs {
+		hpaObject, err := clusterCache.GetHPA(scheduledHPAConfig.Name, scheduledHPAConfig.Namespace)
+		if err != nil {
+			continue
+		}
+		key := fmt.Sprintf(
+			constant.NameAndNamespaceKeyFormat,
+			scheduledHPAConfig.Name,
+			scheduledHPAConfig.Namespace,
+		)
+		switch h := hpaObject.(type) {
+		case *v1.HorizontalPodAutoscaler:
+			mapHPAScaleTargetRef[key] = h.Spec.ScaleTargetRef
+		case *v2beta1.HorizontalPodAutoscaler:
+			mapHPAScaleTargetRef[key] = h.Spec.ScaleTargetRef
+		case *v2beta2.HorizontalPodAutoscaler:
+			mapHPAScaleTargetRef[key] = h.Spec.ScaleTargetRef
+		}
+	}
+
+	getAllDeploymentsFunc := func(since time.Time) (map[string]*DeploymentPodData, error) {
+		mapDeploymentsPodData := map[string]*DeploymentPodData{}
+		for key, val := range mapHPAScaleTargetRef {
+			nameSplit := strings.Split(key, "|")
+			name, namespace := nameSplit[0], nameSplit[1]
+			data := &DeploymentPodData{
+				Name:      name,
+				Namespace: namespace,
+			}
+			mapDeploymentsPodData[key] = data
+
+			// HPA status is optional, a failure must not drop the deployment status
+			hpaObject, err := clusterCache.GetHPA(name, namespace)
+			if err == nil {
+				data.HPAStatus, err = c.clusterUC.GetHPAStatusSnapshot(hpaObject)
+			}
+			if err != nil {
+				log.Errorf(
+					"[EventCronJob] Watching event : %s, HPA %s namespace %s, Watch hpa status error : %s",
+					e.Name,
+					name,
+					namespace,
+					err.Error(),
+				)
+			}
+
+			deployment, err := c.clusterUC.ResolveScaleTargetRefByLister(
+				val,
+				namespace,
+				clusterCache.Deployments,
+			)
+			if err != nil {
+				return nil, err
+			}
+			status := deployment.Status
+			data.Replicas = status.Replicas
+			data.UnavailableReplicas = status.UnavailableReplicas
+			data.ReadyReplicas = status.ReadyReplicas
+			data.AvailableReplicas = status.AvailableReplicas
+
+			// Pod statistic is optional, a failure must not drop the hpa status
+			selector, err := v1Option.LabelSelectorAsSelector(deployment.Spec.Selector)
+			if err != nil {
+				log.Errorf(
+					"[EventCronJob] Watching event : %s, Deployment %s namespace %s, Watch pod error : %s",
+					e.Name,
+					deployment.Name,
+					deployment.Namespace,
+					err.Error(),
+				)
+				continue
+			}
+			pods, err := clusterCache.Pods.Pods(namespace).List(selector)
+			if err != nil {
+				return nil, err
+			}
+			failedSchedulingEvents, err := clusterCache.Events.Events(namespace).List(labels.Everything())
+			if err != nil {
+				return nil, err
+			}
+			data.PodStatistic = c.clusterUC.CalculateDeploymentPodStatistic(
+				pods,
+				failedSchedulingEvents,
+				since,
+			)
+		}
+
+		return mapDeploymentsPodData, nil
+	}
+
+	guard, err := c.newEventGuard(ctx, db, e, clusterData, kubernetesClient, clusterCache)
+	if err != nil {
+		c.handleWatchEvent(db, e, err.Error())
+		return
+	}
+
+	updatedNodePools, err := c.updatedNodePoolUC.GetAllUpdatedNodePoolByEvent(db, e.ID)
+	if err != nil {
+		c.handleWatchEvent(db, e, err.Error())
+		return
+	}
+	updatedNodePoolMap := map[string]uuid.UUID{}
+	for _, updatedNodePool := range updatedNodePools {
+		updatedNodePoolMap[updatedNodePool.NodePoolName] = updatedNodePool.ID
+	}
+
+	// Changes of the watched deployments and hpa and node pool resizes trigger a sample between the
+	// periodic snapshots
+	watchedObjects := map[string]bool{}
+	for key, val := range mapHPAScaleTargetRef {
+		namespace := strings.Split(key, "|")[1]
+		watchedObjects[fmt.Sprintf(watchedObjectKeyFormat, k8sInformer.HPAKind, key)] = true
+		deployment, err := c.clusterUC.ResolveScaleTargetRefByLister(
+			val,
+			namespace,
+			clusterCache.Deployments,
+		)
+		if err != nil {
+			continue
+		}
+		watchedObjects[fmt.Sprintf(
+			watchedObjectKeyFormat,
+			k8sInformer.DeploymentKind,
+			fmt.Sprintf(constant.NameAndNamespaceKeyFormat, deployment.Name, deployment.Namespace),
+		)] = true
+	}
+	changes, unsubscribe := clusterCache.Subscribe(
+		func(kind k8sInformer.ObjectKind, namespace, name string) bool {
+			if kind == k8sInformer.NodeKind {
+				return true
+			}
+			return watchedObjects[fmt.Sprintf(
+				watchedObjectKeyFormat,
+				kind,
+				fmt.Sprintf(constant.NameAndNamespaceKeyFormat, name, namespace),
+			)]
+		},
+	)
+	defer unsubscribe()
+
+	endTime := e.EndTime
+	minChangeInterval := c.minChangeSampleInterval()
+	lastWatch := time.Now()
+	watcherTimer := time.NewTimer(c.watchInterval(e, lastWatch))
+	defer watcherTimer.Stop()
+	var changeTimer <-chan time.Time
+	sample := func(now time.Time) {
+		since := lastWatch
+		lastWatch = now
+		changeTimer = nil
+		if !watcherTimer.Stop() {
+			select {
+			case <-watcherTimer.C:
+			default:
+			}
+		}
+		watcherTimer.Reset(c.watchInterval(e, now))
+
+		samplers.Add(2)
+		go func() {
+			defer samplers.Done()
+			c.watchNodePool(kubernetesClient, clusterCache, db, datacenter, e, now, ctx, updatedNodePoolMap)
+		}()
+		go func() {
+			defer samplers.Done()
+			c.watchHPA(getAllDeploymentsFunc, db, e, scheduledHPAConfigs, guard, now, since)
+		}()
+	}
+	for {
+		select {
+		case now := <-watcherTimer.C:
+			if now.After(endTime) {
+				return
+			}
+			sample(now)
+		case <-changes:
+			if changeTimer == nil {
+				changeTimer = time.After(time.Until(lastWatch.Add(minChangeInterval)))
+			}
+		case now := <-changeTimer:
+			if now.After(endTime) {
+				return
+			}
+			sample(now)
+		case <-ctx.Done():
+			return
+		}
+	}
+
+}
+
+func (c *cron) Start() {
+	log.Infof("Starting event cron job")
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGTERM, syscall.SIGINT)
+	defer cancel()
+	db := c.tx.WithContext(ctx)
+	go c.notificationUC.ResumePendingDelivery(db)
+	schedulerInterval := time.Duration(c.cronConfig.SchedulerIntervalSeconds) * time.Second
+	if schedulerInterval <= 0 {
+		schedulerInterval = defaultSchedulerInterval
+	}
+	mainTicker := time.NewTicker(schedulerInterval)
+	defer mainTicker.Stop()
+	for {
+		select {
+		case now := <-mainTicker.C:
+			go func() {
+				pendingEvents, err := c.eventUC.GetAllPendingExecutableEvent(db, now)
+				if err != nil {
+					log.Errorf(
+						"[EventCronJob] Error getting pending executable events : %s",
+						err.Error(),
+					)
+				}
+				if len(pendingEvents) != 0 && err == nil {
+					for _, pendingEvent := range pendingEvents {
+						switch pendingEvent.Cluster.Datacenter.Datacenter {
+						case model.GCP:
+							go c.execGCPEvent(pendingEvent, db, ctx)
+
+						}
+					}
+				}
+			}()
+
+			go func() {
+				prescaledEvents, err := c.eventUC.GetAllPrescaledEvent(db, now)
+				if err != nil {
+					log.Errorf(
+						"[EventCronJob] Error getting prescaled events : %s",
+						err.Error(),
+					)
+				}
+				if len(prescaledEvents) != 0 && err == nil {
+					for _, prescaledEvent := range prescaledEvents {
+						go c.watchEvent(prescaledEvent, db, ctx)
+					}
+				}
+			}()
+
+			go func() {
+				finishedEventIDs, err := c.eventUC.FinishAllWatchedEvent(db, now)
+				if err != nil {
+					log.Errorf("[EventCronJob] Error update watched events : %s", err.Error())
+				}
+				for _, eventID := range finishedEventIDs {
+					go c.generateEventReport(db, eventID)
+					go c.notifyFinishedEvent(db, eventID)
+				}
+			}()
+
+			go func() {
+				finishedEvents, err := c.eventUC.GetAllFinishedWatchedEventWithScaleDown(db, now)
+				if err != nil {
+					log.Errorf(
+						"[EventCronJob] Error getting finished watched events : %s",
+						err.Error(),
+					)
+				}
+				if len(finishedEvents) != 0 && err == nil {
+					for _, finishedEvent := range finishedEvents {
+						go c.startScaleDownEvent(finishedEvent, db)
+					}
+				}
+			}()
+
+			go func() {
+				scalingDownEvents, err := c.eventUC.GetAllScalingDownEvent(db, now)
+				if err != nil {
+					log.Errorf(
+						"[EventCronJob] Error getting scaling down events : %s",
+						err.Error(),
+					)
+				}
+				if len(scalingDownEvents) != 0 && err == nil {
+					for _, scalingDownEvent := range scalingDownEvents {
+						go c.execScaleDownEvent(scalingDownEvent, db, ctx, now)
+					}
+				}
+			}()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
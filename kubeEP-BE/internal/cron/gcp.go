@@ -0,0 +1,1201 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant"
+	errorConstant "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant/errors"
+	UCEntity "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/usecase"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/pkg/metrics"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/pkg/scheduler"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/pkg/util"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository/model"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/genproto/googleapis/container/v1"
+	"gorm.io/gorm"
+	v1Apps "k8s.io/api/apps/v1"
+	"k8s.io/api/autoscaling/v1"
+	"k8s.io/api/autoscaling/v2beta1"
+	"k8s.io/api/autoscaling/v2beta2"
+	v1Core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	gkeOperationSetNodePoolAutoscaling = "set_node_pool_autoscaling"
+	// gkeOperationTimeout bounds the wait of a node pool update, GKE queues the operations of a
+	// cluster so a stuck operation would otherwise block the caller
+	gkeOperationTimeout      = 10 * time.Minute
+	gkeOperationPollInterval = 100 * time.Millisecond
+)
+
+func (c *cron) getAllGCPClient(
+	ctx context.Context,
+	clusterData *UCEntity.ClusterData,
+) (kubernetes.Interface, *GCPClients, error) {
+	datacenter := clusterData.Datacenter.Datacenter
+	if datacenter != model.GCP {
+		return nil, nil, errors.New(errorConstant.DatacenterMismatch)
+	}
+	googleClients, release, err := c.clientManager.GetGCPClients(ctx, clusterData.Datacenter)
+	if err != nil {
+		return nil, nil, err
+	}
+	kubernetesClient, err := c.clientManager.GetKubernetesClient(ctx, clusterData)
+	if err != nil {
+		release()
+		return nil, nil, err
+	}
+	return kubernetesClient, &GCPClients{
+		clusterClient:               googleClients.ClusterClient,
+		instanceGroupManagersClient: googleClients.InstanceGroupManagersClient,
+		instanceTemplatesClient:     googleClients.InstanceTemplatesClient,
+		machineTypesClient:          googleClients.MachineTypesClient,
+		regionsClient:               googleClients.RegionsClient,
+		release:                     release,
+	}, nil
+}
+
+func (c *cron) execGCPEvent(e *UCEntity.Event, db *gorm.DB, ctx context.Context) {
+	log.Infof("[EventCronJob] Executing event %s", e.Name)
+	e.Status = model.EventExecuting
+
+	err := c.updateEvent(db, e)
+	if err != nil {
+		log.Errorf("[EventCronJob] Error Update Event : %s", err.Error())
+		return
+	}
+
+	if !e.CalculateNodePool {
+		log.Infof("[EventCronJob] Event %s, skipping node pool calculation", e.Name)
+	}
+
+	clusterID := e.Cluster.ID
+	clusterData, err := c.clusterUC.GetClusterAndDatacenterDataByClusterID(db, clusterID)
+	if err != nil {
+		c.handleExecEventError(db, e, err.Error())
+		return
+	}
+
+	// Get Clients
+	kubernetesClient, googleClients, err := c.getAllGCPClient(ctx, clusterData)
+	if err != nil {
+		c.handleExecEventError(db, e, err.Error())
+		return
+	}
+	defer googleClients.release()
+
+	googleContainerClient := googleClients.clusterClient
+
+	modifiedHPAs, err := c.scheduledHPAConfigUC.ListScheduledHPAConfigByEventID(db, e.ID)
+	if err != nil {
+		c.handleExecEventError(db, e, err.Error())
+		return
+	}
+
+	// Check HPA
+	log.Infof("[EventCronJob] Event : %s, Checking HPAs", e.Name)
+	hpaFetchStart := time.Now()
+	existingK8sHPA, err := c.clusterUC.GetAllK8sHPAObjectInCluster(
+		ctx,
+		kubernetesClient,
+		clusterID,
+		clusterData.LatestHPAAPIVersion,
+	)
+	metrics.ObservePhase(metrics.PhaseHPAFetch, hpaFetchStart)
+	if err != nil {
+		c.handleExecEventError(db, e, err.Error())
+		return
+	}
+
+	// Search selected and unselected hpa
+	var selectedK8sHPAs []interface{}
+	var unselectedK8sHPAs []interface{}
+	var selectedK8sHPANames []string
+	var unselectedK8sHPANames []string
+	var existingModifiedHPAs []*UCEntity.EventModifiedHPAConfigData
+	modifiedHPAMap := map[string]*UCEntity.EventModifiedHPAConfigData{}
+	for _, modifiedHPA := range modifiedHPAs {
+		key := fmt.Sprintf(constant.NameNSKeyFormat, modifiedHPA.Name, modifiedHPA.Namespace)
+		modifiedHPAMap[key] = modifiedHPA
+	}
+
+	for _, data := range existingK8sHPA {
+		var name, namespace string
+		var deepCopy interface{}
+		switch h := data.HPAObject.(type) {
+		case v1.HorizontalPodAutoscaler:
+			name = h.Name
+			namespace = h.Namespace
+			deepCopy = h.DeepCopy()
+		case v2beta1.HorizontalPodAutoscaler:
+			name = h.Name
+			namespace = h.Namespace
+			deepCopy = h.DeepCopy()
+		case v2beta2.HorizontalPodAutoscaler:
+			name = h.Name
+			namespace = h.Namespace
+			deepCopy = h.DeepCopy()
+		default:
+			continue
+		}
+		key := fmt.Sprintf(constant.NameNSKeyFormat, name, namespace)
+		modifiedHPA, ok := modifiedHPAMap[key]
+		if ok && modifiedHPA != nil {
+			selectedK8sHPAs = append(selectedK8sHPAs, deepCopy)
+			selectedK8sHPANames = append(selectedK8sHPANames, key)
+			existingModifiedHPAs = append(existingModifiedHPAs, modifiedHPA)
+			delete(modifiedHPAMap, key)
+			continue
+		}
+		unselectedK8sHPAs = append(unselectedK8sHPAs, deepCopy)
+		unselectedK8sHPANames = append(unselectedK8sHPANames, key)
+	}
+
+	//Give error message to missing hpa
+	for _, modifiedHPA := range modifiedHPAMap {
+		err := c.scheduledHPAConfigUC.UpdateScheduledHPAConfigStatusMessage(
+			db,
+			modifiedHPA.ID,
+			model.HPAUpdateFailed,
+			"hpa not found",
+		)
+		if err != nil {
+			log.Errorf(
+				"[EventCronJob] Event : %s, Error Update HPA %s Namespace %s : %s",
+				e.Name,
+				modifiedHPA.Name,
+				modifiedHPA.Namespace,
+				err.Error(),
+			)
+		}
+	}
+
+	if len(selectedK8sHPAs) == 0 {
+		c.handleExecEventError(db, e, "no hpa exist")
+		return
+	}
+
+	log.Infof(
+		"[EventCronJob] Event : %s, Selected HPAs:\n%s\nUnselected HPAs:\n%s",
+		e.Name,
+		strings.Join(selectedK8sHPANames, "\n"),
+		strings.Join(unselectedK8sHPANames, "\n"),
+	)
+
+	// Parse GCP Cluster Name
+	clusterMetadata := strings.Split(clusterData.Name, "_")
+	project := clusterMetadata[1]
+	location := clusterMetadata[3]
+	name := clusterMetadata[2]
+
+	// Get GCP Node Pools
+	googleClusterData, err := c.gcpClusterUC.GetGCPClusterObject(
+		ctx,
+		googleContainerClient,
+		project,
+		location,
+		name,
+	)
+	if err != nil {
+		c.handleExecEventError(db, e, err.Error())
+		return
+	}
+
+	// Get Linux Daemonsets and Calculate Required Resources
+	var daemonSetsDataList []*DaemonSetData
+	var planWarnings []string
+	var runtimeClassOverheads map[string]v1Core.ResourceList
+	if e.CalculateNodePool {
+		// The pod overhead is set at admission, the templates only name their runtime class
+		runtimeClassOverheads, err = c.clusterUC.GetRuntimeClassOverheads(ctx, kubernetesClient)
+		if err != nil {
+			log.Warnf(
+				"[EventCronJob] Event : %s, Error fetching runtime classes : %s",
+				e.Name,
+				err.Error(),
+			)
+			planWarnings = append(
+				planWarnings,
+				"runtime class overhead skipped, error fetching runtime classes",
+			)
+		}
+
+		log.Infof("[EventCronJob] Event : %s, Calculate daemonsets resources", e.Name)
+		daemonSetCalcStart := time.Now()
+		daemonSetsData, err := c.clusterUC.GetAllDaemonSetsInNamespace(
+			ctx,
+			kubernetesClient,
+			"",
+		)
+		if err != nil {
+			c.handleExecEventError(db, e, err.Error())
+			return
+		}
+		daemonSets := daemonSetsData.DaemonSetListObject
+
+		for _, daemonSet := range daemonSets.Items {
+			spec := daemonSet.Spec.Template.Spec
+			requestedResources := c.calculatePodRequests(
+				&daemonSet.Spec.Template,
+				daemonSet.Namespace,
+				runtimeClassOverheads,
+			)
+			var nodeAffinity *v1Core.NodeAffinity
+			if spec.Affinity != nil {
+				if spec.Affinity.NodeAffinity != nil {
+					nodeAffinity = spec.Affinity.NodeAffinity
+				}
+			}
+			daemonSetsDataList = append(
+				daemonSetsDataList, &DaemonSetData{
+					NodeSelector:       labels.Set(spec.NodeSelector).AsSelector(),
+					NodeAffinity:       nodeAffinity,
+					Tolerations:        spec.Tolerations,
+					RequestedResources: requestedResources,
+					Name:               daemonSet.Name,
+					Namespace:          daemonSet.Namespace,
+				},
+			)
+			log.Infof(
+				"[EventCronJob] Event : %s, Registering daemonset %s namespace %s, requested resources %s",
+				e.Name,
+				daemonSet.Name,
+				daemonSet.Namespace,
+				util.FormatResourceList(requestedResources),
+			)
+		}
+		metrics.ObservePhase(metrics.PhaseDaemonSetCalc, daemonSetCalcStart)
+	}
+
+	// Get Maximum Resources each Node Pools
+	log.Infof(
+		"[EventCronJob] Event : %s, Calculate maximum available resources in node pools",
+		e.Name,
+	)
+	nodePools := googleClusterData.ClusterObject.NodePools
+	nodePoolsMaxResources := map[string]*NodePoolResourceData{}
+	nodePoolsMap := map[string]*container.NodePool{}
+	var updatedNodePools []*model.UpdatedNodePool
+	// nodePoolsList holds the node pools sized by the calculation, plannedNodePools is in the same
+	// order
+	var nodePoolsList []string
+	var plannedNodePools []*model.UpdatedNodePool
+	errGroup, ctxEg := errgroup.WithContext(ctx)
+	for _, nodePool := range nodePools {
+		updatedNodePool := &model.UpdatedNodePool{
+			NodePoolName: nodePool.Name,
+		}
+		if nodePool.Autoscaling != nil {
+			updatedNodePool.MaxNode = nodePool.Autoscaling.MaxNodeCount
+		}
+		updatedNodePool.EventID.SetUUID(e.ID)
+
+		updatedNodePools = append(updatedNodePools, updatedNodePool)
+
+		if e.CalculateNodePool {
+			// The node pool may have changed since the event was registered, a node pool the
+			// calculation can't size is skipped instead of failing the event
+			if skipReason := gcpNodePoolSkipReason(nodePool); skipReason != "" {
+				log.Warnf(
+					"[EventCronJob] Event : %s, Node pool %s, skipped, %s",
+					e.Name,
+					nodePool.Name,
+					skipReason,
+				)
+				updatedNodePool.Message = "skipped, " + skipReason
+				planWarnings = append(
+					planWarnings,
+					fmt.Sprintf("node pool %s: skipped, %s", nodePool.Name, skipReason),
+				)
+				continue
+			}
+			nodePoolsList = append(nodePoolsList, nodePool.Name)
+			plannedNodePools = append(plannedNodePools, updatedNodePool)
+			resourceData := &NodePoolResourceData{}
+			nodePoolsMaxResources[nodePool.Name] = resourceData
+			nodePoolsMap[nodePool.Name] = nodePool
+
+			loadFunc := func(nP *container.NodePool, rD *NodePoolResourceData) func() error {
+				return func() error {
+					nodePoolMaxPods := nP.MaxPodsConstraint.MaxPodsPerNode
+					nodePoolMaxNode := nP.Autoscaling.MaxNodeCount
+
+					// Fetch nodepool labels from existing node
+					var allocatable v1Core.ResourceList
+					nodeData, err := c.gcpClusterUC.GetNodesFromGCPNodePool(
+						ctxEg,
+						kubernetesClient,
+						nP.Name,
+					)
+					if err != nil && err.Error() == errorConstant.NoExistingNode {
+						// Derive node shape from the instance template for pools scaled to zero
+						log.Infof(
+							"[EventCronJob] Event : %s, Node pool %s has no existing node, using instance template",
+							e.Name,
+							nP.Name,
+						)
+						var templateData *UCEntity.GCPNodePoolTemplateData
+						templateData, err = c.gcpClusterUC.GetNodePoolTemplateData(
+							ctxEg,
+							googleClients.instanceGroupManagersClient,
+							googleClients.instanceTemplatesClient,
+							googleClients.machineTypesClient,
+							project,
+							nP,
+						)
+						if err == nil {
+							rD.NodeLabels = templateData.Labels
+							rD.NodeTaints = templateData.Taints
+							allocatable = templateData.Allocatable
+							rD.NodeCPUs = int64(templateData.GuestCPUs)
+						}
+					} else if err == nil {
+						nodes := nodeData.NodeListObject
+						node := nodes.Items[0]
+						rD.NodeLabels = node.Labels
+						rD.NodeTaints = util.FilterNodePoolTaints(node.Spec.Taints)
+						rD.CurrentNodeCount = len(nodes.Items)
+						allocatable = node.Status.Allocatable.DeepCopy()
+						rD.NodeCPUs = node.Status.Capacity.Cpu().Value()
+						delete(allocatable, v1Core.ResourcePods)
+					}
+					if err != nil {
+						if ctxEg.Err() != nil {
+							return nil
+						}
+						log.Errorf(
+							"[EventCronJob] Event : %s, Node pool %s, Error : %s",
+							e.Name,
+							nP.Name,
+							err.Error(),
+						)
+						return err
+					}
+					availablePods := nodePoolMaxPods
+					totalMatchesDaemonSet := int64(0)
+					totalDaemonSetsRequestedResources := v1Core.ResourceList{}
+					var matchesDaemonSet []string
+					for _, daemonSet := range daemonSetsDataList {
+						nodePoolMatch, err := util.CheckPodNodePoolMatch(
+							rD.NodeLabels,
+							rD.NodeTaints,
+							daemonSet.NodeAffinity,
+							daemonSet.NodeSelector,
+							daemonSet.Tolerations,
+						)
+						if err != nil {
+							if ctxEg.Err() != nil {
+								return nil
+							}
+							log.Errorf(
+								"[EventCronJob] Event : %s, Node pool %s, Error : %s",
+								e.Name,
+								nP.Name,
+								err.Error(),
+							)
+							return err
+						}
+						if nodePoolMatch {
+							util.AddResourceList(
+								totalDaemonSetsRequestedResources,
+								daemonSet.RequestedResources,
+							)
+							totalMatchesDaemonSet += 1
+							matchesDaemonSet = append(
+								matchesDaemonSet,
+								fmt.Sprintf(
+									constant.NameNSKeyFormat,
+									daemonSet.Name,
+									daemonSet.Namespace,
+								),
+							)
+						}
+					}
+
+					log.Infof(
+						"[EventCronJob] Event : %s, Node pool %s, %d matches daemonset with requested resources %s\nDaemonset list :\n%s",
+						e.Name,
+						nP.Name,
+						totalMatchesDaemonSet,
+						util.FormatResourceList(totalDaemonSetsRequestedResources),
+						strings.Join(matchesDaemonSet, "\n"),
+					)
+
+					rD.AvailablePods = availablePods - totalMatchesDaemonSet
+					rD.MaxAvailablePods = availablePods * int64(nodePoolMaxNode)
+					util.SubtractResourceList(allocatable, totalDaemonSetsRequestedResources)
+					rD.AvailableResources = allocatable
+					rD.MaxAvailableResources = util.MultiplyResourceList(
+						allocatable,
+						int64(nodePoolMaxNode),
+					)
+
+					log.Infof(
+						"[EventCronJob] Event : %s, Node pool %s has maximum %d available pods and maximum available resources %s",
+						e.Name,
+						nP.Name,
+						rD.MaxAvailablePods,
+						util.FormatResourceList(rD.MaxAvailableResources),
+					)
+
+					return nil
+				}
+			}
+			errGroup.Go(loadFunc(nodePool, resourceData))
+		}
+	}
+
+	if err := errGroup.Wait(); err != nil {
+		c.handleExecEventError(db, e, err.Error())
+		return
+	}
+
+	// Calculate Required Resource
+	var simulatedWorkloadLock sync.Mutex
+	var simulatedWorkloads []*scheduler.Workload
+	var deploymentsMap map[string]v1Apps.Deployment
+	errGroup, ctxEg = errgroup.WithContext(ctx)
+	if e.CalculateNodePool {
+		log.Infof("[EventCronJob] Event : %s, Calculate required resources", e.Name)
+
+		deploymentsMap = map[string]v1Apps.Deployment{}
+		var deploymentNames []string
+
+		log.Infof("[EventCronJob] Event : %s, Fetching deployments", e.Name)
+		deploymentsData, err := c.clusterUC.GetAllDeployments(ctxEg, kubernetesClient, "")
+		if err != nil {
+			c.handleExecEventError(db, e, err.Error())
+			return
+		}
+
+		deployments := deploymentsData.DeploymentListObject
+		for _, deployment := range deployments.Items {
+			key := fmt.Sprintf(constant.NameNSKeyFormat, deployment.Name, deployment.Namespace)
+			deploymentNames = append(deploymentNames, key)
+			deploymentsMap[key] = deployment
+		}
+		log.Infof(
+			"[EventCronJob] Event : %s, Found deployments:\n%s",
+			e.Name,
+			strings.Join(deploymentNames, "\n"),
+		)
+	}
+
+	log.Infof("[EventCronJob] Event : %s, Calculate selected HPA", e.Name)
+	// Calculate Selected HPA
+	for idx, selectedHPA := range selectedK8sHPAs {
+		errGroup.Go(
+			func(i int, hpa interface{}) func() error {
+				return func() error {
+					requestedModification := existingModifiedHPAs[i]
+					var scaleTargetRef interface{}
+					name := requestedModification.Name
+					namespace := requestedModification.Namespace
+					maxReplicas := requestedModification.MaxReplicas
+
+					// Modify HPA, Get Target Ref and Namespace
+					switch h := hpa.(type) {
+					case *v1.HorizontalPodAutoscaler:
+						requestedModification.OriginalMinReplicas = h.Spec.MinReplicas
+						originalMaxReplicas := h.Spec.MaxReplicas
+						requestedModification.OriginalMaxReplicas = &originalMaxReplicas
+						h.Spec.MinReplicas = requestedModification.MinReplicas
+						h.Spec.MaxReplicas = maxReplicas
+						scaleTargetRef = h.Spec.ScaleTargetRef
+					case *v2beta1.HorizontalPodAutoscaler:
+						requestedModification.OriginalMinReplicas = h.Spec.MinReplicas
+						originalMaxReplicas := h.Spec.MaxReplicas
+						requestedModification.OriginalMaxReplicas = &originalMaxReplicas
+						h.Spec.MinReplicas = requestedModification.MinReplicas
+						h.Spec.MaxReplicas = maxReplicas
+						scaleTargetRef = h.Spec.ScaleTargetRef
+					case *v2beta2.HorizontalPodAutoscaler:
+						requestedModification.OriginalMinReplicas = h.Spec.MinReplicas
+						originalMaxReplicas := h.Spec.MaxReplicas
+						requestedModification.OriginalMaxReplicas = &originalMaxReplicas
+						h.Spec.MinReplicas = requestedModification.MinReplicas
+						h.Spec.MaxReplicas = maxReplicas
+						scaleTargetRef = h.Spec.ScaleTargetRef
+					default:
+						return errors.New(errorConstant.HPAVersionUnknown)
+					}
+
+					if e.CalculateNodePool {
+						// Resolve Target Ref to Get Pods
+						resolveRes, err := c.clusterUC.ResolveScaleTargetRefByDeploymentsMap(
+							scaleTargetRef,
+							namespace,
+							deploymentsMap,
+							true,
+						)
+						if err != nil {
+							if ctxEg.Err() != nil {
+								return nil
+							}
+							log.Errorf(
+								"[EventCronJob] Event : %s, Selected HPA %s Namespace %s, Error : %s",
+								e.Name,
+								name,
+								namespace,
+								err.Error(),
+							)
+							return err
+						}
+
+						// Calculate Requested Resource
+						podRequests := c.calculatePodRequests(
+							&resolveRes.Spec.Template,
+							namespace,
+							runtimeClassOverheads,
+						)
+						maxRequestedResources := util.MultiplyResourceList(podRequests, int64(maxReplicas))
+
+						//Resolve node selector, find all node pools and register the workload for simulation
+						workload, err := c.buildSimulatedWorkload(
+							name,
+							namespace,
+							&resolveRes.Spec.Template,
+							podRequests,
+							maxReplicas,
+							nodePoolsMaxResources,
+						)
+						if err != nil {
+							if ctxEg.Err() != nil {
+								return nil
+							}
+							log.Errorf(
+								"[EventCronJob] Event : %s, Selected HPA %s Namespace %s, Error : %s",
+								e.Name,
+								name,
+								namespace,
+								err.Error(),
+							)
+							return err
+						}
+
+						simulatedWorkloadLock.Lock()
+						simulatedWorkloads = append(simulatedWorkloads, workload)
+						simulatedWorkloadLock.Unlock()
+
+						log.Infof(
+							"[EventCronJob] Event : %s, Selected HPA %s namespace %s, maximum %d pods, maximum requested resources %s\nNode pools:\n%s",
+							e.Name,
+							name,
+							namespace,
+							maxReplicas,
+							util.FormatResourceList(maxRequestedResources),
+							strings.Join(workload.NodePools, "\n"),
+						)
+						return nil
+					}
+
+					log.Infof(
+						"[EventCronJob] Event : %s, Selected HPA %s namespace %s, maximum %d pods",
+						e.Name,
+						name,
+						namespace,
+						maxReplicas,
+					)
+
+					return nil
+				}
+			}(idx, selectedHPA),
+		)
+	}
+
+	if e.CalculateNodePool {
+		// Calculate Unselected HPA
+		for idx, unselectedHPA := range unselectedK8sHPAs {
+			errGroup.Go(
+				func(i int, hpa interface{}) func() error {
+					return func() error {
+						var scaleTargetRef interface{}
+						var namespace, name string
+						var maxReplicas int32
+
+						// Modify HPA, Get Target Ref and Namespace
+						switch h := hpa.(type) {
+						case *v1.HorizontalPodAutoscaler:
+							scaleTargetRef = h.Spec.ScaleTargetRef
+							namespace = h.Namespace
+							name = h.Name
+							maxReplicas = h.Spec.MaxReplicas
+						case *v2beta1.HorizontalPodAutoscaler:
+							scaleTargetRef = h.Spec.ScaleTargetRef
+							namespace = h.Namespace
+							name = h.Name
+							maxReplicas = h.Spec.MaxReplicas
+						case *v2beta2.HorizontalPodAutoscaler:
+							scaleTargetRef = h.Spec.ScaleTargetRef
+							namespace = h.Namespace
+							name = h.Name
+							maxReplicas = h.Spec.MaxReplicas
+						default:
+							return errors.New(errorConstant.HPAVersionUnknown)
+						}
+
+						// Resolve Target Ref to Get Pods
+						resolveRes, err := c.clusterUC.ResolveScaleTargetRefByDeploymentsMap(
+							scaleTargetRef,
+							namespace,
+							deploymentsMap,
+							true,
+						)
+						if err != nil {
+							if ctxEg.Err() != nil {
+								return nil
+							}
+							log.Errorf(
+								"[EventCronJob] Event : %s, Selected HPA %s Namespace %s, Error : %s",
+								e.Name,
+								name,
+								namespace,
+								err.Error(),
+							)
+							return err
+						}
+
+						// Calculate Requested Resource
+						podRequests := c.calculatePodRequests(
+							&resolveRes.Spec.Template,
+							namespace,
+							runtimeClassOverheads,
+						)
+						maxRequestedResources := util.MultiplyResourceList(podRequests, int64(maxReplicas))
+
+						//Resolve node selector, find all node pools and register the workload for simulation
+						workload, err := c.buildSimulatedWorkload(
+							name,
+							namespace,
+							&resolveRes.Spec.Template,
+							podRequests,
+							maxReplicas,
+							nodePoolsMaxResources,
+						)
+						if err != nil {
+							if ctxEg.Err() != nil {
+								return nil
+							}
+							log.Errorf(
+								"[EventCronJob] Event : %s, Unselected HPA %s Namespace %s, Error : %s",
+								e.Name,
+								name,
+								namespace,
+								err.Error(),
+							)
+							return err
+						}
+
+						simulatedWorkloadLock.Lock()
+						simulatedWorkloads = append(simulatedWorkloads, workload)
+						simulatedWorkloadLock.Unlock()
+
+						log.Infof(
+							"[EventCronJob] Event : %s, Unselected HPA %s namespace %s, maximum %d pods, maximum requested resources %s\nNode pools:\n%s",
+							e.Name,
+							name,
+							namespace,
+							maxReplicas,
+							util.FormatResourceList(maxRequestedResources),
+							strings.Join(workload.NodePools, "\n"),
+						)
+
+						return nil
+					}
+				}(idx, unselectedHPA),
+			)
+		}
+
+		// Calculate remaining deployment
+		for _, deployment := range deploymentsMap {
+			errGroup.Go(
+				func(d v1Apps.Deployment) func() error {
+					return func() error {
+						name := d.Name
+						namespace := d.Namespace
+						podCounts := d.Spec.Replicas
+						if podCounts == nil {
+							podCounts = &constant.MinimumPod
+						}
+						// Calculate Requested Resource
+						podRequests := c.calculatePodRequests(
+							&d.Spec.Template,
+							namespace,
+							runtimeClassOverheads,
+						)
+						maxRequestedResources := util.MultiplyResourceList(podRequests, int64(*podCounts))
+
+						//Resolve node selector, find all node pools and register the workload for simulation
+						workload, err := c.buildSimulatedWorkload(
+							name,
+							namespace,
+							&d.Spec.Template,
+							podRequests,
+							*podCounts,
+							nodePoolsMaxResources,
+						)
+						if err != nil {
+							if ctxEg.Err() != nil {
+								return nil
+							}
+							log.Errorf(
+								"[EventCronJob] Event : %s, Deployment %s Namespace %s, Error : %s",
+								e.Name,
+								name,
+								namespace,
+								err.Error(),
+							)
+							return err
+						}
+
+						simulatedWorkloadLock.Lock()
+						simulatedWorkloads = append(simulatedWorkloads, workload)
+						simulatedWorkloadLock.Unlock()
+
+						log.Infof(
+							"[EventCronJob] Event : %s, Deployment %s namespace %s, %d pods, maximum requested resources %s\nNode pools:\n%s",
+							e.Name,
+							name,
+							namespace,
+							*podCounts,
+							util.FormatResourceList(maxRequestedResources),
+							strings.Join(workload.NodePools, "\n"),
+						)
+
+						return nil
+					}
+				}(deployment),
+			)
+		}
+	}
+
+	if err := errGroup.Wait(); err != nil {
+		c.handleExecEventError(db, e, err.Error())
+		return
+	}
+
+	if e.CalculateNodePool {
+		// Simulate scheduling all workloads onto the node pools and Update the Node Pool
+		log.Infof(
+			"[EventCronJob] Event : %s, Simulate scheduling to calculate needed node",
+			e.Name,
+		)
+		nodePoolUpdateStart := time.Now()
+		var simulatedNodePools []scheduler.NodePool
+		for _, nodePoolName := range nodePoolsList {
+			maxResourceData := nodePoolsMaxResources[nodePoolName]
+			simulatedNodePools = append(
+				simulatedNodePools, scheduler.NodePool{
+					Name:        nodePoolName,
+					Allocatable: maxResourceData.AvailableResources,
+					MaxPods:     maxResourceData.AvailablePods,
+				},
+			)
+		}
+		sort.Slice(
+			simulatedWorkloads, func(i, j int) bool {
+				return simulatedWorkloads[i].Name < simulatedWorkloads[j].Name
+			},
+		)
+		simulationResult := scheduler.Simulate(simulatedNodePools, simulatedWorkloads)
+		for workloadName, unschedulablePods := range simulationResult.Unschedulable {
+			log.Warnf(
+				"[EventCronJob] Event : %s, Workload %s, %d pods can't be scheduled to any node pool",
+				e.Name,
+				workloadName,
+				unschedulablePods,
+			)
+		}
+
+		// Fetch regional quotas, the plan is not checked against quotas when they can't be fetched
+		region := util.GCPRegionFromLocation(location)
+		quotas, err := c.gcpClusterUC.GetRegionQuotas(
+			ctx,
+			googleClients.regionsClient,
+			project,
+			region,
+		)
+		if err != nil {
+			log.Warnf(
+				"[EventCronJob] Event : %s, Error fetching quotas of region %s : %s",
+				e.Name,
+				region,
+				err.Error(),
+			)
+			planWarnings = append(
+				planWarnings,
+				fmt.Sprintf("quota check skipped, error fetching quotas of region %s", region),
+			)
+		}
+
+		errGroup, ctxEg = errgroup.WithContext(ctx)
+		var updateNodePoolLock sync.Mutex
+		podRangeUsage := map[string]int64{}
+		for idx, nodePoolName := range nodePoolsList {
+			simulatedNodePool := simulationResult.NodePools[nodePoolName]
+			nodePool := nodePoolsMap[nodePoolName]
+			plan := c.planGCPNodePoolMaxNode(
+				googleClusterData.ClusterObject,
+				nodePool,
+				nodePoolsMaxResources[nodePoolName],
+				simulatedNodePool.NodeCount,
+				podRangeUsage,
+				quotas,
+			)
+			for _, warning := range plan.Warnings {
+				log.Warnf(
+					"[EventCronJob] Event : %s, Node pool %s, %s",
+					e.Name,
+					nodePoolName,
+					warning,
+				)
+				planWarnings = append(
+					planWarnings,
+					fmt.Sprintf("node pool %s: %s", nodePoolName, warning),
+				)
+			}
+			plannedNodePools[idx].Message = strings.Join(plan.Warnings, "; ")
+			errGroup.Go(
+				func(
+					simulated *scheduler.NodePoolResult,
+					newMaxNode int32,
+					nodePoolObj *container.NodePool,
+					updatedNodePool *model.UpdatedNodePool,
+				) func() error {
+					return func() error {
+						log.Infof(
+							"[EventCronJob] Event : %s, Node pool %s, requested resources %s, %d requested pods, need %d node",
+							e.Name,
+							nodePoolObj.Name,
+							util.FormatResourceList(simulated.Requested),
+							simulated.PodCount,
+							simulated.NodeCount,
+						)
+
+						autoscalingData := nodePoolObj.Autoscaling
+
+						updatedNodePool.MaxNode = newMaxNode
+
+						updateNodePoolLock.Lock()
+						defer updateNodePoolLock.Unlock()
+						log.Infof(
+							"[EventCronJob] Event : %s, Updating GCP node pool %s with new max node size %d (before : %d)",
+							e.Name,
+							nodePoolObj.Name,
+							newMaxNode,
+							autoscalingData.MaxNodeCount,
+						)
+
+						autoscalingData.MaxNodeCount = newMaxNode
+
+						opData, err := c.gcpClusterUC.SetNodePoolAutoscaling(
+							ctx,
+							googleContainerClient,
+							project,
+							location,
+							name,
+							nodePoolObj.Name,
+							autoscalingData,
+						)
+						if err != nil {
+							if ctxEg.Err() != nil {
+								return nil
+							}
+							metrics.GKEOperationFailures.WithLabelValues(gkeOperationSetNodePoolAutoscaling).Inc()
+							return err
+						}
+						return c.waitGKENodePoolOperation(
+							ctx,
+							googleClients,
+							project,
+							location,
+							nodePoolObj.Name,
+							opData.OperationData,
+						)
+					}
+				}(simulatedNodePool, plan.MaxNode, nodePool, plannedNodePools[idx]),
+			)
+		}
+
+		err = errGroup.Wait()
+		metrics.ObservePhase(metrics.PhaseNodePoolUpdate, nodePoolUpdateStart)
+		if err != nil {
+			c.handleExecEventError(db, e, err.Error())
+			return
+		}
+	}
+
+	if err := db.Create(&updatedNodePools).Error; err != nil {
+		c.handleExecEventError(db, e, err.Error())
+		return
+	}
+	c.cloudEventUC.PublishNodePoolUpdate(db, e, updatedNodePools)
+
+	// Update K8s HPA
+	log.Infof("[EventCronJob] Event : %s, Updating K8s HPA with new configuration", e.Name)
+	err = c.clusterUC.UpdateHPAK8sObjectBatch(ctx, kubernetesClient, clusterID, selectedK8sHPAs)
+	if err != nil {
+		c.handleExecEventError(db, e, err.Error())
+		return
+	}
+
+	for _, existingModifiedHPA := range existingModifiedHPAs {
+		err := c.scheduledHPAConfigUC.UpdateScheduledHPAConfigStatusMessage(
+			db,
+			existingModifiedHPA.ID,
+			model.HPAUpdateSuccess,
+			"",
+		)
+		if err == nil && existingModifiedHPA.OriginalMaxReplicas != nil {
+			err = c.scheduledHPAConfigUC.UpdateScheduledHPAConfigOriginalReplicas(
+				db,
+				existingModifiedHPA.ID,
+				existingModifiedHPA.OriginalMinReplicas,
+				*existingModifiedHPA.OriginalMaxReplicas,
+			)
+		}
+		if err != nil {
+			c.handleExecEventError(
+				db, e, fmt.Sprintf(
+					"Error Update HPA %s Namespace %s : %s", existingModifiedHPA.Name,
+					existingModifiedHPA.Namespace,
+					err.Error(),
+				),
+			)
+			return
+		}
+	}
+
+	e.Status = model.EventPrescaled
+	e.Message = strings.Join(planWarnings, "\n")
+
+	err = c.updateEvent(db, e)
+	if err != nil {
+		log.Errorf("[EventCronJob] Error Update Event : %s", err.Error())
+		return
+	}
+	c.notificationUC.NotifyEventTransition(db, e, model.TransitionPrescaled, e.Message)
+
+	log.Infof("[EventCronJob] Event : %s, Done executing update and calculation", e.Name)
+}
+
+// waitGKENodePoolOperation polls the node pool operation until it is done
+func (c *cron) waitGKENodePoolOperation(
+	ctx context.Context,
+	googleClients *GCPClients,
+	project, location, nodePoolName string,
+	op *container.Operation,
+) error {
+	operationWaitStart := time.Now()
+	defer metrics.ObservePhase(metrics.PhaseGKEOperationWait, operationWaitStart)
+	ctx, cancel := context.WithTimeout(ctx, gkeOperationTimeout)
+	defer cancel()
+	for {
+		opData, err := c.gcpClusterUC.GetOperation(
+			ctx,
+			googleClients.clusterClient,
+			project,
+			location,
+			op.Name,
+		)
+		if err != nil {
+			metrics.GKEOperationFailures.WithLabelValues(gkeOperationSetNodePoolAutoscaling).Inc()
+			return err
+		}
+		op = opData.OperationData
+		if op.Status == container.Operation_DONE {
+			if op.Error != nil {
+				metrics.GKEOperationFailures.WithLabelValues(gkeOperationSetNodePoolAutoscaling).Inc()
+				return fmt.Errorf(
+					"error updating node pool %s : %s",
+					nodePoolName,
+					op.Error.GetMessage(),
+				)
+			}
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			metrics.GKEOperationFailures.WithLabelValues(gkeOperationSetNodePoolAutoscaling).Inc()
+			return fmt.Errorf(errorConstant.GKEOperationTimeout, op.Name, nodePoolName)
+		case <-time.After(gkeOperationPollInterval):
+		}
+	}
+}
+
+// matchPodNodePools finds the node pools the pod can be scheduled on, ordered by the score of the
+// preferred node affinity then by name. The pools with a lower score stay candidates, like the
+// scheduler falls back to them when the preferred pools are full
+func (c *cron) matchPodNodePools(
+	podSpec *v1Core.PodSpec,
+	podRequests v1Core.ResourceList,
+	nodePoolsResources map[string]*NodePoolResourceData,
+) ([]string, error) {
+	nodeSelector := labels.Set(podSpec.NodeSelector).AsSelector()
+	var nodeAffinity *v1Core.NodeAffinity
+	if podSpec.Affinity != nil {
+		nodeAffinity = podSpec.Affinity.NodeAffinity
+	}
+
+	nodePoolScores := map[string]int64{}
+	var res []string
+	for nodePoolName, nodePoolResourceData := range nodePoolsResources {
+		nodePoolMatch, err := util.CheckPodNodePoolMatch(
+			nodePoolResourceData.NodeLabels,
+			nodePoolResourceData.NodeTaints,
+			nodeAffinity,
+			nodeSelector,
+			podSpec.Tolerations,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if !nodePoolMatch {
+			continue
+		}
+		// Pods requesting extended resources (e.g. GPU) only fit pools advertising them
+		missingExtendedResource := false
+		for resourceName, quantity := range podRequests {
+			available := nodePoolResourceData.AvailableResources[resourceName]
+			if util.IsExtendedResourceName(resourceName) &&
+				quantity.Sign() > 0 && available.Sign() <= 0 {
+				missingExtendedResource = true
+				break
+			}
+		}
+		if missingExtendedResource {
+			continue
+		}
+		score, err := util.CalculatePreferredAffinityScore(
+			nodePoolResourceData.NodeLabels,
+			nodeAffinity,
+		)
+		if err != nil {
+			return nil, err
+		}
+		nodePoolScores[nodePoolName] = score
+		res = append(res, nodePoolName)
+	}
+
+	sort.Slice(
+		res, func(i, j int) bool {
+			if nodePoolScores[res[i]] != nodePoolScores[res[j]] {
+				return nodePoolScores[res[i]] > nodePoolScores[res[j]]
+			}
+			return res[i] < res[j]
+		},
+	)
+	return res, nil
+}
+
+// calculatePodRequests returns the effective requests of the pod template, including the sidecar
+// profiles and the runtime class overhead set at admission. An init container named like a profile
+// is the sidecar injected as native sidecar, it runs next to the containers
+func (c *cron) calculatePodRequests(
+	template *v1Core.PodTemplateSpec,
+	namespace string,
+	runtimeClassOverheads map[string]v1Core.ResourceList,
+) v1Core.ResourceList {
+	podSpec := template.Spec
+	if podSpec.Overhead == nil && podSpec.RuntimeClassName != nil {
+		podSpec.Overhead = runtimeClassOverheads[*podSpec.RuntimeClassName]
+	}
+	sidecars := map[string]bool{}
+	for _, profile := range c.capacityConfig.SidecarProfiles {
+		namespaceMatch := false
+		for _, profileNamespace := range profile.Namespaces {
+			if profileNamespace == "*" || profileNamespace == namespace {
+				namespaceMatch = true
+				break
+			}
+		}
+		if !namespaceMatch {
+			continue
+		}
+		if profile.OptOutAnnotation != "" && template.Annotations[profile.OptOutAnnotation] == "false" {
+			continue
+		}
+		alreadyExist := false
+		for _, containerSpec := range podSpec.Containers {
+			if containerSpec.Name == profile.Name {
+				alreadyExist = true
+				break
+			}
+		}
+		for _, containerSpec := range podSpec.InitContainers {
+			if containerSpec.Name == profile.Name {
+				sidecars[profile.Name] = true
+				alreadyExist = true
+				break
+			}
+		}
+		if alreadyExist {
+			continue
+		}
+
+		requests := v1Core.ResourceList{}
+		for resourceName, value := range map[v1Core.ResourceName]string{
+			v1Core.ResourceCPU:    profile.CPU,
+			v1Core.ResourceMemory: profile.Memory,
+		} {
+			if value == "" {
+				continue
+			}
+			quantity, err := resource.ParseQuantity(value)
+			if err != nil {
+				log.Errorf(
+					"[EventCronJob] Sidecar profile %s, invalid %s quantity %s : %s",
+					profile.Name,
+					resourceName,
+					value,
+					err.Error(),
+				)
+				continue
+			}
+			requests[resourceName] = quantity
+		}
+
+		containers := make([]v1Core.Container, 0, len(podSpec.Containers)+1)
+		containers = append(containers, podSpec.Containers...)
+		podSpec.Containers = append(
+			containers, v1Core.Container{
+				Name:      profile.Name,
+				Resources: v1Core.ResourceRequirements{Requests: requests},
+			},
+		)
+	}
+	return util.CalculatePodRequests(&podSpec, sidecars)
+}
+
+// buildSimulatedWorkload matches the pod template to node pools and builds the workload used by
+// the scheduling simulation, node pools are ordered by preference
+func (c *cron) buildSimulatedWorkload(
+	name, namespace string,
+	template *v1Core.PodTemplateSpec,
+	podRequests v1Core.ResourceList,
+	replicas int32,
+	nodePoolsResources map[string]*NodePoolResourceData,
+) (*scheduler.Workload, error) {
+	nodePools, err := c.matchPodNodePools(&template.Spec, podRequests, nodePoolsResources)
+	if err != nil {
+		return nil, err
+	}
+
+	return scheduler.NewWorkload(
+		fmt.Sprintf(constant.NameNSKeyFormat, name, namespace),
+		namespace,
+		template,
+		podRequests,
+		replicas,
+		nodePools,
+	)
+}
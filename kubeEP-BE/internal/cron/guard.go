@@ -0,0 +1,457 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant"
+	errorConstant "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant/errors"
+	UCEntity "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/usecase"
+	k8sInformer "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/pkg/k8s/informer"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/pkg/metrics"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/pkg/util"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository/model"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/genproto/googleapis/container/v1"
+	"gorm.io/gorm"
+	v1 "k8s.io/api/autoscaling/v1"
+	"k8s.io/api/autoscaling/v2beta1"
+	"k8s.io/api/autoscaling/v2beta2"
+	"k8s.io/client-go/kubernetes"
+	"strings"
+	"sync"
+	"time"
+)
+
+const guardStateKeyFormat = "%s/%s"
+
+// eventGuard keeps the breach state of the event guard rules between watch samples
+type eventGuard struct {
+	ctx              context.Context
+	event            *UCEntity.Event
+	clusterData      *UCEntity.ClusterData
+	kubernetesClient kubernetes.Interface
+	clusterCache     *k8sInformer.ClusterCache
+	rules            []*UCEntity.GuardRuleData
+
+	lock          sync.Mutex
+	breachedSince map[string]time.Time
+	lastTriggered map[string]time.Time
+}
+
+func (c *cron) newEventGuard(
+	ctx context.Context,
+	db *gorm.DB,
+	e *UCEntity.Event,
+	clusterData *UCEntity.ClusterData,
+	kubernetesClient kubernetes.Interface,
+	clusterCache *k8sInformer.ClusterCache,
+) (*eventGuard, error) {
+	rules, err := c.guardUC.ListGuardRuleByEventID(db, e.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &eventGuard{
+		ctx:              ctx,
+		event:            e,
+		clusterData:      clusterData,
+		kubernetesClient: kubernetesClient,
+		clusterCache:     clusterCache,
+		rules:            rules,
+		breachedSince:    map[string]time.Time{},
+		lastTriggered:    map[string]time.Time{},
+	}, nil
+}
+
+// guardTrigger is a rule which fired for an hpa, its action runs outside of the guard lock
+type guardTrigger struct {
+	rule               *UCEntity.GuardRuleData
+	scheduledHPAConfig *UCEntity.EventModifiedHPAConfigData
+	breachedSince      time.Time
+}
+
+// evaluateGuard checks the guard rules against the latest hpa samples, a rule fires once it is
+// breached for its duration and keeps quiet during its cooldown. The actions update kubernetes and
+// GKE, they run after the breach state is recorded so the other samplers are not blocked
+func (c *cron) evaluateGuard(
+	db *gorm.DB,
+	guard *eventGuard,
+	scheduledHPAConfigs []*UCEntity.EventModifiedHPAConfigData,
+	deploymentDataMap map[string]*DeploymentPodData,
+	now time.Time,
+) {
+	if guard == nil || len(guard.rules) == 0 {
+		return
+	}
+
+	for _, trigger := range c.triggerGuardRules(guard, scheduledHPAConfigs, deploymentDataMap, now) {
+		c.execGuardAction(db, guard, trigger.rule, trigger.scheduledHPAConfig, trigger.breachedSince)
+	}
+}
+
+func (c *cron) triggerGuardRules(
+	guard *eventGuard,
+	scheduledHPAConfigs []*UCEntity.EventModifiedHPAConfigData,
+	deploymentDataMap map[string]*DeploymentPodData,
+	now time.Time,
+) []guardTrigger {
+	guard.lock.Lock()
+	defer guard.lock.Unlock()
+
+	var triggers []guardTrigger
+	for _, rule := range guard.rules {
+		for _, scheduledHPAConfig := range scheduledHPAConfigs {
+			if !rule.AppliesTo(scheduledHPAConfig.Name, scheduledHPAConfig.Namespace) {
+				continue
+			}
+			hpaKey := fmt.Sprintf(
+				constant.NameAndNamespaceKeyFormat,
+				scheduledHPAConfig.Name,
+				scheduledHPAConfig.Namespace,
+			)
+			data, ok := deploymentDataMap[hpaKey]
+			if !ok {
+				continue
+			}
+
+			breachKey := fmt.Sprintf(guardStateKeyFormat, rule.ID, hpaKey)
+			breached := c.guardUC.IsGuardRuleBreached(
+				rule, &UCEntity.GuardSampleData{
+					Replicas:            data.Replicas,
+					UnavailableReplicas: data.UnavailableReplicas,
+					HPAStatus:           data.HPAStatus,
+					PodStatistic:        data.PodStatistic,
+				},
+			)
+			if !breached {
+				delete(guard.breachedSince, breachKey)
+				continue
+			}
+
+			breachedSince, ok := guard.breachedSince[breachKey]
+			if !ok {
+				breachedSince = now
+				guard.breachedSince[breachKey] = now
+			}
+			if now.Sub(breachedSince) < time.Duration(rule.DurationSeconds)*time.Second {
+				continue
+			}
+
+			// Node pool actions share the target between hpa, the cooldown follows the target
+			target := hpaKey
+			if rule.Action == model.GuardRaiseNodePoolMax {
+				target = rule.NodePoolName
+			}
+			triggerKey := fmt.Sprintf(guardStateKeyFormat, rule.ID, target)
+			lastTriggered := guard.lastTriggered[triggerKey]
+			cooldown := time.Duration(rule.CooldownSeconds) * time.Second
+			if !lastTriggered.IsZero() && (!now.After(lastTriggered) || now.Sub(lastTriggered) < cooldown) {
+				continue
+			}
+			guard.lastTriggered[triggerKey] = now
+			delete(guard.breachedSince, breachKey)
+
+			triggers = append(
+				triggers, guardTrigger{
+					rule:               rule,
+					scheduledHPAConfig: scheduledHPAConfig,
+					breachedSince:      breachedSince,
+				},
+			)
+		}
+	}
+	return triggers
+}
+
+func (c *cron) execGuardAction(
+	db *gorm.DB,
+	guard *eventGuard,
+	rule *UCEntity.GuardRuleData,
+	scheduledHPAConfig *UCEntity.EventModifiedHPAConfigData,
+	breachedSince time.Time,
+) {
+	e := guard.event
+	action := &UCEntity.GuardActionData{
+		EventID:       e.ID,
+		GuardRuleID:   rule.ID,
+		Action:        rule.Action,
+		Status:        model.GuardActionSuccess,
+		Target:        fmt.Sprintf(guardStateKeyFormat, scheduledHPAConfig.Namespace, scheduledHPAConfig.Name),
+		BreachedSince: breachedSince,
+	}
+	message := fmt.Sprintf(
+		"guard rule %s breached by hpa %s namespace %s since %s",
+		rule.Condition,
+		scheduledHPAConfig.Name,
+		scheduledHPAConfig.Namespace,
+		breachedSince.Format(time.RFC3339),
+	)
+
+	var err error
+	var warnings []string
+	switch rule.Action {
+	case model.GuardRaiseMaxReplicas:
+		action.PreviousValue, action.NewValue, err = c.raiseGuardHPAMaxReplicas(
+			db,
+			guard,
+			scheduledHPAConfig,
+			rule.ActionValue,
+		)
+	case model.GuardRaiseNodePoolMax:
+		action.Target = rule.NodePoolName
+		action.PreviousValue, action.NewValue, warnings, err = c.raiseGuardNodePoolMax(
+			db,
+			guard,
+			rule.NodePoolName,
+			rule.ActionValue,
+		)
+	case model.GuardNotify:
+	default:
+		err = errors.New(errorConstant.GuardActionUnknown)
+	}
+
+	if len(warnings) != 0 {
+		message = fmt.Sprintf("%s, %s", message, strings.Join(warnings, "; "))
+	}
+	if err != nil {
+		action.Status = model.GuardActionFailed
+		action.Message = fmt.Sprintf("%s, action %s failed : %s", message, rule.Action, err.Error())
+		log.Errorf("[EventCronJob] Watching event : %s, Guard : %s", e.Name, action.Message)
+	} else {
+		action.Message = message
+		if action.NewValue != action.PreviousValue {
+			action.Message = fmt.Sprintf(
+				"%s, %s raised from %d to %d",
+				message,
+				action.Target,
+				action.PreviousValue,
+				action.NewValue,
+			)
+		}
+		log.Infof("[EventCronJob] Watching event : %s, Guard : %s", e.Name, action.Message)
+	}
+
+	if err := c.guardUC.RecordGuardAction(db, action); err != nil {
+		log.Errorf(
+			"[EventCronJob] Watching event : %s, Error recording guard action : %s",
+			e.Name,
+			err.Error(),
+		)
+	}
+	c.notificationUC.NotifyEventTransition(db, e, model.TransitionGuardBreached, action.Message)
+}
+
+func (c *cron) raiseGuardHPAMaxReplicas(
+	db *gorm.DB,
+	guard *eventGuard,
+	scheduledHPAConfig *UCEntity.EventModifiedHPAConfigData,
+	value int32,
+) (int32, int32, error) {
+	hpaObject, err := guard.clusterCache.GetHPA(scheduledHPAConfig.Name, scheduledHPAConfig.Namespace)
+	if err != nil {
+		return 0, 0, errors.New(errorConstant.GuardHPANotExist)
+	}
+
+	var previousMaxReplicas int32
+	var updatedHPA interface{}
+	switch h := hpaObject.(type) {
+	case *v1.HorizontalPodAutoscaler:
+		deepCopy := h.DeepCopy()
+		previousMaxReplicas = deepCopy.Spec.MaxReplicas
+		deepCopy.Spec.MaxReplicas += value
+		updatedHPA = deepCopy
+	case *v2beta1.HorizontalPodAutoscaler:
+		deepCopy := h.DeepCopy()
+		previousMaxReplicas = deepCopy.Spec.MaxReplicas
+		deepCopy.Spec.MaxReplicas += value
+		updatedHPA = deepCopy
+	case *v2beta2.HorizontalPodAutoscaler:
+		deepCopy := h.DeepCopy()
+		previousMaxReplicas = deepCopy.Spec.MaxReplicas
+		deepCopy.Spec.MaxReplicas += value
+		updatedHPA = deepCopy
+	default:
+		return 0, 0, errors.New(errorConstant.HPAVersionUnknown)
+	}
+	newMaxReplicas := previousMaxReplicas + value
+
+	err = c.clusterUC.UpdateHPAK8sObjectBatch(
+		guard.ctx,
+		guard.kubernetesClient,
+		guard.clusterData.ID,
+		[]interface{}{updatedHPA},
+	)
+	if err != nil {
+		return previousMaxReplicas, previousMaxReplicas, err
+	}
+
+	// The scale down steps keep the event max replicas, they must start from the raised value
+	err = c.scheduledHPAConfigUC.UpdateScheduledHPAConfigMaxReplicas(
+		db,
+		scheduledHPAConfig.ID,
+		newMaxReplicas,
+	)
+	if err != nil {
+		return previousMaxReplicas, newMaxReplicas, err
+	}
+
+	return previousMaxReplicas, newMaxReplicas, nil
+}
+
+// raiseGuardNodePoolMax raises the max node count of the node pool through the node pool planner,
+// the raised value is capped by the same GKE limits as the event execution. The warnings tell why
+// the value was capped
+func (c *cron) raiseGuardNodePoolMax(
+	db *gorm.DB,
+	guard *eventGuard,
+	nodePoolName string,
+	value int32,
+) (int32, int32, []string, error) {
+	clusterData := guard.clusterData
+	if clusterData.Datacenter.Datacenter != model.GCP {
+		return 0, 0, nil, errors.New(errorConstant.GuardNodePoolUnsupported)
+	}
+
+	_, googleClients, err := c.getAllGCPClient(guard.ctx, clusterData)
+	if err != nil {
+		return 0, 0, nil, err
+	}
+	defer googleClients.release()
+
+	// Parse GCP Cluster Name
+	clusterMetadata := strings.Split(clusterData.Name, "_")
+	project := clusterMetadata[1]
+	location := clusterMetadata[3]
+	name := clusterMetadata[2]
+
+	googleClusterData, err := c.gcpClusterUC.GetGCPClusterObject(
+		guard.ctx,
+		googleClients.clusterClient,
+		project,
+		location,
+		name,
+	)
+	if err != nil {
+		return 0, 0, nil, err
+	}
+	cluster := googleClusterData.ClusterObject
+
+	for _, nodePool := range cluster.NodePools {
+		if nodePool.Name != nodePoolName || nodePool.Autoscaling == nil {
+			continue
+		}
+		autoscalingData := nodePool.Autoscaling
+		previousMaxNode := autoscalingData.MaxNodeCount
+
+		var warnings []string
+		resourceData, err := c.loadGuardNodePoolResourceData(guard, googleClients, project, nodePool)
+		if err != nil {
+			warnings = append(
+				warnings,
+				fmt.Sprintf("quota check skipped, error reading the node pool nodes : %s", err.Error()),
+			)
+		}
+		region := util.GCPRegionFromLocation(location)
+		quotas, err := c.gcpClusterUC.GetRegionQuotas(
+			guard.ctx,
+			googleClients.regionsClient,
+			project,
+			region,
+		)
+		if err != nil {
+			warnings = append(
+				warnings,
+				fmt.Sprintf("quota check skipped, error fetching quotas of region %s", region),
+			)
+		}
+		zoneCount := gcpNodePoolZoneCount(cluster, nodePool)
+		plan := c.planGCPNodePoolMaxNode(
+			cluster,
+			nodePool,
+			resourceData,
+			int(previousMaxNode+value)*int(zoneCount),
+			gcpPodRangeUsage(cluster, nodePool.Name),
+			quotas,
+		)
+		warnings = append(warnings, plan.Warnings...)
+		if plan.MaxNode <= previousMaxNode {
+			return previousMaxNode, previousMaxNode, warnings, nil
+		}
+		autoscalingData.MaxNodeCount = plan.MaxNode
+
+		opData, err := c.gcpClusterUC.SetNodePoolAutoscaling(
+			guard.ctx,
+			googleClients.clusterClient,
+			project,
+			location,
+			name,
+			nodePool.Name,
+			autoscalingData,
+		)
+		if err != nil {
+			metrics.GKEOperationFailures.WithLabelValues(gkeOperationSetNodePoolAutoscaling).Inc()
+			return previousMaxNode, previousMaxNode, warnings, err
+		}
+		err = c.waitGKENodePoolOperation(
+			guard.ctx,
+			googleClients,
+			project,
+			location,
+			nodePool.Name,
+			opData.OperationData,
+		)
+		if err != nil {
+			return previousMaxNode, previousMaxNode, warnings, err
+		}
+
+		// The event detail and report read the max node of the updated node pools, like the hpa max
+		// replicas it keeps the raised value
+		err = c.updatedNodePoolUC.UpdateUpdatedNodePoolMaxNode(
+			db,
+			guard.event.ID,
+			nodePool.Name,
+			autoscalingData.MaxNodeCount,
+		)
+		return previousMaxNode, autoscalingData.MaxNodeCount, warnings, err
+	}
+
+	return 0, 0, nil, errors.New(errorConstant.GuardNodePoolNotExist)
+}
+
+// loadGuardNodePoolResourceData reads the node count and the node vCPUs the planner checks the
+// quota with, a node pool without node takes the vCPUs of its instance template
+func (c *cron) loadGuardNodePoolResourceData(
+	guard *eventGuard,
+	googleClients *GCPClients,
+	project string,
+	nodePool *container.NodePool,
+) (*NodePoolResourceData, error) {
+	resourceData := &NodePoolResourceData{}
+	nodeData, err := c.gcpClusterUC.GetNodesFromGCPNodePool(
+		guard.ctx,
+		guard.kubernetesClient,
+		nodePool.Name,
+	)
+	if err == nil {
+		nodes := nodeData.NodeListObject.Items
+		resourceData.CurrentNodeCount = len(nodes)
+		resourceData.NodeCPUs = nodes[0].Status.Capacity.Cpu().Value()
+		return resourceData, nil
+	}
+	if err.Error() != errorConstant.NoExistingNode {
+		return resourceData, err
+	}
+	templateData, err := c.gcpClusterUC.GetNodePoolTemplateData(
+		guard.ctx,
+		googleClients.instanceGroupManagersClient,
+		googleClients.instanceTemplatesClient,
+		googleClients.machineTypesClient,
+		project,
+		nodePool,
+	)
+	if err != nil {
+		return resourceData, err
+	}
+	resourceData.NodeCPUs = int64(templateData.GuestCPUs)
+	return resourceData, nil
+}
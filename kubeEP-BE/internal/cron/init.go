@@ -0,0 +1,35 @@
+package cron
+
+import (
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/config"
+	k8sInformer "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/pkg/k8s/informer"
+	useCase "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/usecase"
+)
+
+func BuildCron(useCases *useCase.UseCases, resources *config.KubeEPResources) Cron {
+	var capacityConfig config.CapacityConfig
+	var cronConfig config.CronConfig
+	if resources.Config != nil {
+		capacityConfig = resources.Config.Capacity
+		cronConfig = resources.Config.Cron
+	}
+	return newCron(
+		useCases.Event,
+		useCases.Cluster,
+		useCases.GcpCluster,
+		useCases.GcpDatacenter,
+		useCases.ScheduledHPAConfig,
+		useCases.UpdatedNodePool,
+		useCases.ScaleDown,
+		capacityConfig,
+		cronConfig,
+		k8sInformer.NewManager(),
+		useCases.ClientManager,
+		useCases.EventReport,
+		useCases.Notification,
+		useCases.CloudEvent,
+		useCases.EventStream,
+		useCases.Guard,
+		resources.DB,
+	)
+}
@@ -0,0 +1,174 @@
+package cron
+
+import (
+	"fmt"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant"
+	UCEntity "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/usecase"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/pkg/util"
+	"google.golang.org/genproto/googleapis/container/v1"
+	"math"
+)
+
+// gcpNodePoolSkipReason tells why the max node count of the node pool can't be planned, it is
+// empty when the node pool can be planned
+func gcpNodePoolSkipReason(nodePool *container.NodePool) string {
+	if nodePool.Autoscaling == nil || !nodePool.Autoscaling.Enabled {
+		return "node pool autoscaling is not enabled"
+	}
+	if nodePool.MaxPodsConstraint == nil {
+		return "node pool has no max pods constraint"
+	}
+	return ""
+}
+
+// planGCPNodePoolMaxNode turns the simulated node count into the per zone max node count of the
+// node pool. The plan is capped by the GKE node pool size limit, the pod ipv4 range shared with
+// previously planned node pools and the regional vCPU quota, the max node count never goes below
+// the current one. podRangeUsage and quotas are updated with the planned nodes.
+func (c *cron) planGCPNodePoolMaxNode(
+	cluster *container.Cluster,
+	nodePool *container.NodePool,
+	resourceData *NodePoolResourceData,
+	simulatedNodeCount int,
+	podRangeUsage map[string]int64,
+	quotas map[string]*UCEntity.GCPQuotaData,
+) *NodePoolPlan {
+	plan := &NodePoolPlan{}
+	var currentMaxNode int32
+	if nodePool.Autoscaling != nil {
+		currentMaxNode = nodePool.Autoscaling.MaxNodeCount
+	}
+
+	zoneCount := gcpNodePoolZoneCount(cluster, nodePool)
+	maxNode := int32(math.Ceil(float64(simulatedNodeCount) / float64(zoneCount)))
+	if maxNode > constant.GKEMaxNodePerZone {
+		plan.Warnings = append(
+			plan.Warnings,
+			fmt.Sprintf(
+				"needs %d node per zone, capped to the GKE limit of %d node per zone",
+				maxNode,
+				constant.GKEMaxNodePerZone,
+			),
+		)
+		maxNode = constant.GKEMaxNodePerZone
+	}
+
+	// Each node takes a range with PodIpv4CidrSize prefix from the pod ipv4 range
+	podRange := gcpNodePoolPodRange(cluster, nodePool)
+	if podRange != "" && nodePool.PodIpv4CidrSize > 0 {
+		capacity, err := util.CalculatePodCIDRNodeCapacity(podRange, nodePool.PodIpv4CidrSize)
+		if err != nil {
+			plan.Warnings = append(
+				plan.Warnings,
+				fmt.Sprintf("pod range check skipped, invalid pod range %s", podRange),
+			)
+		} else {
+			availableNode := capacity - podRangeUsage[podRange]
+			if int64(maxNode)*int64(zoneCount) > availableNode {
+				cappedMaxNode := int32(availableNode / int64(zoneCount))
+				plan.Warnings = append(
+					plan.Warnings,
+					fmt.Sprintf(
+						"needs %d node per zone, pod range %s only has room for %d node per zone (/%d per node)",
+						maxNode,
+						podRange,
+						cappedMaxNode,
+						nodePool.PodIpv4CidrSize,
+					),
+				)
+				maxNode = cappedMaxNode
+			}
+		}
+	}
+
+	// Only nodes above the current node count consume new quota
+	if quotas != nil && nodePool.Config != nil && resourceData.NodeCPUs > 0 {
+		metric := util.GCPCPUsQuotaMetric(nodePool.Config.MachineType)
+		quota, ok := quotas[metric]
+		if !ok {
+			quota = quotas[constant.GCPCPUsQuotaMetric]
+		}
+		if quota != nil {
+			currentNode := int64(resourceData.CurrentNodeCount)
+			extraNode := int64(maxNode)*int64(zoneCount) - currentNode
+			availableCPUs := int64(quota.Limit - quota.Usage)
+			if extraNode > 0 && extraNode*resourceData.NodeCPUs > availableCPUs {
+				allowedExtraNode := availableCPUs / resourceData.NodeCPUs
+				if allowedExtraNode < 0 {
+					allowedExtraNode = 0
+				}
+				cappedMaxNode := int32((currentNode + allowedExtraNode) / int64(zoneCount))
+				plan.Warnings = append(
+					plan.Warnings,
+					fmt.Sprintf(
+						"needs %d node per zone, %s quota (%.0f of %.0f used) only allows %d node per zone",
+						maxNode,
+						quota.Metric,
+						quota.Usage,
+						quota.Limit,
+						cappedMaxNode,
+					),
+				)
+				maxNode = cappedMaxNode
+				extraNode = int64(maxNode)*int64(zoneCount) - currentNode
+			}
+			if extraNode > 0 {
+				quota.Usage += float64(extraNode * resourceData.NodeCPUs)
+			}
+		}
+	}
+
+	if maxNode < currentMaxNode {
+		maxNode = currentMaxNode
+	}
+	if podRange != "" {
+		podRangeUsage[podRange] += int64(maxNode) * int64(zoneCount)
+	}
+	plan.MaxNode = maxNode
+	return plan
+}
+
+// gcpNodePoolZoneCount returns the zones of the node pool, MaxNodeCount applies to each zone
+func gcpNodePoolZoneCount(cluster *container.Cluster, nodePool *container.NodePool) int32 {
+	zoneCount := int32(len(nodePool.Locations))
+	if zoneCount == 0 {
+		zoneCount = int32(len(cluster.Locations))
+	}
+	if zoneCount == 0 {
+		zoneCount = 1
+	}
+	return zoneCount
+}
+
+// gcpNodePoolPodRange returns the pod ipv4 range the nodes of the node pool take their range from
+func gcpNodePoolPodRange(cluster *container.Cluster, nodePool *container.NodePool) string {
+	podRange := cluster.ClusterIpv4Cidr
+	if cluster.IpAllocationPolicy != nil && cluster.IpAllocationPolicy.ClusterIpv4CidrBlock != "" {
+		podRange = cluster.IpAllocationPolicy.ClusterIpv4CidrBlock
+	}
+	if nodePool.NetworkConfig != nil && nodePool.NetworkConfig.PodIpv4CidrBlock != "" {
+		podRange = nodePool.NetworkConfig.PodIpv4CidrBlock
+	}
+	return podRange
+}
+
+// gcpPodRangeUsage returns the nodes each pod ipv4 range holds for the node pools other than the
+// excluded one, the autoscaled node pools count at their max node count
+func gcpPodRangeUsage(cluster *container.Cluster, excludedNodePool string) map[string]int64 {
+	podRangeUsage := map[string]int64{}
+	for _, nodePool := range cluster.NodePools {
+		if nodePool.Name == excludedNodePool {
+			continue
+		}
+		podRange := gcpNodePoolPodRange(cluster, nodePool)
+		if podRange == "" {
+			continue
+		}
+		nodeCount := nodePool.InitialNodeCount
+		if nodePool.Autoscaling != nil && nodePool.Autoscaling.Enabled {
+			nodeCount = nodePool.Autoscaling.MaxNodeCount
+		}
+		podRangeUsage[podRange] += int64(nodeCount) * int64(gcpNodePoolZoneCount(cluster, nodePool))
+	}
+	return podRangeUsage
+}
@@ -0,0 +1,304 @@
+package cron
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant"
+	UCEntity "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/usecase"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository/model"
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"k8s.io/api/autoscaling/v1"
+	"k8s.io/api/autoscaling/v2beta1"
+	"k8s.io/api/autoscaling/v2beta2"
+	"k8s.io/client-go/kubernetes"
+	"time"
+)
+
+func (c *cron) startScaleDownEvent(e *UCEntity.Event, db *gorm.DB) {
+	log.Infof("[EventCronJob] Planning scale down for event %s", e.Name)
+
+	scheduledHPAConfigs, err := c.scheduledHPAConfigUC.ListScheduledHPAConfigByEventID(db, e.ID)
+	if err != nil {
+		c.handleWatchEvent(db, e, err.Error())
+		return
+	}
+
+	steps := c.scaleDownUC.PlanScaleDownSteps(e, scheduledHPAConfigs)
+	if len(steps) == 0 {
+		e.Status = model.EventSuccess
+	} else {
+		e.Status = model.EventScalingDown
+	}
+
+	var transition *UCEntity.EventStatusTransition
+	err = db.Transaction(
+		func(tx *gorm.DB) error {
+			if err := c.scaleDownUC.RegisterScaleDownSteps(tx, steps); err != nil {
+				return err
+			}
+			transition, err = c.eventUC.UpdateEvent(tx, e)
+			return err
+		},
+	)
+	if err != nil {
+		e.Status = model.EventWatching
+		c.handleWatchEvent(db, e, err.Error())
+		return
+	}
+	c.eventUC.PublishEventStatusTransition(db, transition)
+
+	log.Infof("[EventCronJob] Event : %s, Planned %d scale down steps", e.Name, len(steps))
+	if e.Status == model.EventSuccess {
+		c.notificationUC.NotifyEventTransition(db, e, model.TransitionFinished, e.Message)
+		c.generateEventReport(db, e.ID)
+	}
+}
+
+func (c *cron) execScaleDownEvent(
+	e *UCEntity.Event,
+	db *gorm.DB,
+	ctx context.Context,
+	now time.Time,
+) {
+	dueSteps, err := c.scaleDownUC.ListDueScaleDownStepByEventID(db, e.ID, now)
+	if err != nil {
+		log.Errorf("[EventCronJob] Scaling down event : %s, Error : %s", e.Name, err.Error())
+		return
+	}
+
+	if len(dueSteps) != 0 {
+		c.applyScaleDownSteps(e, db, ctx, now, dueSteps)
+	}
+
+	pendingCount, err := c.scaleDownUC.CountPendingScaleDownStepByEventID(db, e.ID)
+	if err != nil {
+		log.Errorf("[EventCronJob] Scaling down event : %s, Error : %s", e.Name, err.Error())
+		return
+	}
+	if pendingCount != 0 {
+		return
+	}
+
+	e.Status = model.EventSuccess
+	if err := c.updateEvent(db, e); err != nil {
+		log.Errorf("[EventCronJob] Error Update Event : %s", err.Error())
+		return
+	}
+	log.Infof("[EventCronJob] Event : %s, Done scaling down", e.Name)
+	c.notificationUC.NotifyEventTransition(db, e, model.TransitionRolledBack, e.Message)
+	c.notificationUC.NotifyEventTransition(db, e, model.TransitionFinished, e.Message)
+	c.generateEventReport(db, e.ID)
+}
+
+func (c *cron) applyScaleDownSteps(
+	e *UCEntity.Event,
+	db *gorm.DB,
+	ctx context.Context,
+	now time.Time,
+	dueSteps []*UCEntity.ScaleDownStepData,
+) {
+	clusterID := e.Cluster.ID
+	clusterData, err := c.clusterUC.GetClusterAndDatacenterDataByClusterID(db, clusterID)
+	if err != nil {
+		c.handleWatchEvent(db, e, err.Error())
+		return
+	}
+
+	var kubernetesClient kubernetes.Interface
+	switch clusterData.Datacenter.Datacenter {
+	case model.GCP:
+		kubernetesClient, err = c.clientManager.GetKubernetesClient(ctx, clusterData)
+		if err != nil {
+			c.handleWatchEvent(db, e, err.Error())
+			return
+		}
+	}
+
+	scheduledHPAConfigs, err := c.scheduledHPAConfigUC.ListScheduledHPAConfigByEventID(db, e.ID)
+	if err != nil {
+		c.handleWatchEvent(db, e, err.Error())
+		return
+	}
+	scheduledHPAConfigMap := map[uuid.UUID]*UCEntity.EventModifiedHPAConfigData{}
+	for _, scheduledHPAConfig := range scheduledHPAConfigs {
+		scheduledHPAConfigMap[scheduledHPAConfig.ID] = scheduledHPAConfig
+	}
+
+	existingK8sHPA, err := c.clusterUC.GetAllK8sHPAObjectInCluster(
+		ctx,
+		kubernetesClient,
+		clusterID,
+		clusterData.LatestHPAAPIVersion,
+	)
+	if err != nil {
+		c.handleWatchEvent(db, e, err.Error())
+		return
+	}
+	hpaMap := map[string]interface{}{}
+	for _, data := range existingK8sHPA {
+		switch h := data.HPAObject.(type) {
+		case v1.HorizontalPodAutoscaler:
+			hpaMap[fmt.Sprintf(constant.NameNSKeyFormat, h.Name, h.Namespace)] = h.DeepCopy()
+		case v2beta1.HorizontalPodAutoscaler:
+			hpaMap[fmt.Sprintf(constant.NameNSKeyFormat, h.Name, h.Namespace)] = h.DeepCopy()
+		case v2beta2.HorizontalPodAutoscaler:
+			hpaMap[fmt.Sprintf(constant.NameNSKeyFormat, h.Name, h.Namespace)] = h.DeepCopy()
+		}
+	}
+
+	// Only the latest due step of each HPA is applied, older ones are skipped
+	latestSteps := map[uuid.UUID]*UCEntity.ScaleDownStepData{}
+	var finishedSteps []*UCEntity.ScaleDownStepData
+	for _, step := range dueSteps {
+		latestStep, ok := latestSteps[step.ScheduledHPAConfigID]
+		if ok && latestStep.StepNumber > step.StepNumber {
+			step.Status = model.ScaleDownStepSkipped
+			step.Message = fmt.Sprintf("superseded by step %d", latestStep.StepNumber)
+			finishedSteps = append(finishedSteps, step)
+			continue
+		}
+		if ok {
+			latestStep.Status = model.ScaleDownStepSkipped
+			latestStep.Message = fmt.Sprintf("superseded by step %d", step.StepNumber)
+			finishedSteps = append(finishedSteps, latestStep)
+		}
+		latestSteps[step.ScheduledHPAConfigID] = step
+	}
+
+	var updatedHPAs []interface{}
+	var appliedSteps []*UCEntity.ScaleDownStepData
+	var waitingSteps []*UCEntity.ScaleDownStepData
+	for scheduledHPAConfigID, step := range latestSteps {
+		scheduledHPAConfig, ok := scheduledHPAConfigMap[scheduledHPAConfigID]
+		if !ok {
+			step.Status = model.ScaleDownStepFailed
+			step.Message = "scheduled hpa config not found"
+			finishedSteps = append(finishedSteps, step)
+			continue
+		}
+		key := fmt.Sprintf(
+			constant.NameNSKeyFormat,
+			scheduledHPAConfig.Name,
+			scheduledHPAConfig.Namespace,
+		)
+		hpa, ok := hpaMap[key]
+		if !ok {
+			step.Status = model.ScaleDownStepFailed
+			step.Message = "hpa not found"
+			finishedSteps = append(finishedSteps, step)
+			continue
+		}
+
+		// A gated step waits at most one window after its due time, then it is applied anyway so
+		// the hpa does not keep the event replicas forever
+		if passed, msg := c.checkScaleDownGate(e.ScaleDown.Gate, hpa); !passed {
+			gateDeadline := step.ScheduledAt.Add(time.Duration(e.ScaleDown.WindowMinutes) * time.Minute)
+			if now.Before(gateDeadline) {
+				step.Message = fmt.Sprintf("waiting, %s", msg)
+				waitingSteps = append(waitingSteps, step)
+				continue
+			}
+			step.Message = fmt.Sprintf(
+				"applied after the gate deadline %s, %s",
+				gateDeadline.Format(time.RFC3339),
+				msg,
+			)
+		} else {
+			step.Message = ""
+		}
+
+		minReplicas := step.MinReplicas
+		switch h := hpa.(type) {
+		case *v1.HorizontalPodAutoscaler:
+			h.Spec.MinReplicas = &minReplicas
+			h.Spec.MaxReplicas = step.MaxReplicas
+		case *v2beta1.HorizontalPodAutoscaler:
+			h.Spec.MinReplicas = &minReplicas
+			h.Spec.MaxReplicas = step.MaxReplicas
+		case *v2beta2.HorizontalPodAutoscaler:
+			h.Spec.MinReplicas = &minReplicas
+			h.Spec.MaxReplicas = step.MaxReplicas
+		}
+		updatedHPAs = append(updatedHPAs, hpa)
+		appliedSteps = append(appliedSteps, step)
+	}
+
+	if len(updatedHPAs) != 0 {
+		log.Infof(
+			"[EventCronJob] Scaling down event : %s, Updating %d HPA",
+			e.Name,
+			len(updatedHPAs),
+		)
+		err = c.clusterUC.UpdateHPAK8sObjectBatch(ctx, kubernetesClient, clusterID, updatedHPAs)
+		for _, step := range appliedSteps {
+			if err != nil {
+				step.Status = model.ScaleDownStepFailed
+				step.Message = err.Error()
+			} else {
+				step.Status = model.ScaleDownStepSuccess
+			}
+		}
+		finishedSteps = append(finishedSteps, appliedSteps...)
+	}
+
+	for _, step := range finishedSteps {
+		executedAt := now
+		step.ExecutedAt = &executedAt
+	}
+
+	for _, step := range append(finishedSteps, waitingSteps...) {
+		if err := c.scaleDownUC.UpdateScaleDownStep(db, step); err != nil {
+			log.Errorf(
+				"[EventCronJob] Scaling down event : %s, Error update step : %s",
+				e.Name,
+				err.Error(),
+			)
+		}
+	}
+}
+
+func (c *cron) checkScaleDownGate(gate model.ScaleDownGate, hpa interface{}) (bool, string) {
+	var minReplicas, currentReplicas, desiredReplicas int32
+	switch h := hpa.(type) {
+	case *v1.HorizontalPodAutoscaler:
+		if h.Spec.MinReplicas != nil {
+			minReplicas = *h.Spec.MinReplicas
+		}
+		currentReplicas = h.Status.CurrentReplicas
+		desiredReplicas = h.Status.DesiredReplicas
+	case *v2beta1.HorizontalPodAutoscaler:
+		if h.Spec.MinReplicas != nil {
+			minReplicas = *h.Spec.MinReplicas
+		}
+		currentReplicas = h.Status.CurrentReplicas
+		desiredReplicas = h.Status.DesiredReplicas
+	case *v2beta2.HorizontalPodAutoscaler:
+		if h.Spec.MinReplicas != nil {
+			minReplicas = *h.Spec.MinReplicas
+		}
+		currentReplicas = h.Status.CurrentReplicas
+		desiredReplicas = h.Status.DesiredReplicas
+	}
+
+	switch gate {
+	case model.ScaleDownGateCurrentReplicas:
+		if currentReplicas > minReplicas {
+			return false, fmt.Sprintf(
+				"current replicas %d is above min replicas %d",
+				currentReplicas,
+				minReplicas,
+			)
+		}
+	case model.ScaleDownGateHPARecommendation:
+		if desiredReplicas > minReplicas {
+			return false, fmt.Sprintf(
+				"hpa desired replicas %d is above min replicas %d",
+				desiredReplicas,
+				minReplicas,
+			)
+		}
+	}
+	return true, ""
+}
@@ -0,0 +1,54 @@
+package cron
+
+import (
+	compute "cloud.google.com/go/compute/apiv1"
+	container "cloud.google.com/go/container/apiv1"
+	UCEntity "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/usecase"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+type NodePoolResourceData struct {
+	MaxAvailablePods      int64
+	MaxAvailableResources v1.ResourceList
+	AvailableResources    v1.ResourceList
+	AvailablePods         int64
+	CurrentNodeCount      int
+	NodeCPUs              int64
+	NodeLabels            labels.Set
+	NodeTaints            []v1.Taint
+}
+
+type DeploymentPodData struct {
+	Name, Namespace     string
+	Replicas            int32
+	AvailableReplicas   int32
+	ReadyReplicas       int32
+	UnavailableReplicas int32
+	PodStatistic        *UCEntity.PodStatisticData
+	HPAStatus           *UCEntity.HPAStatusSnapshotData
+}
+
+type DaemonSetData struct {
+	NodeSelector       labels.Selector
+	NodeAffinity       *v1.NodeAffinity
+	Tolerations        []v1.Toleration
+	RequestedResources v1.ResourceList
+	Name, Namespace    string
+}
+
+// GCPClients are shared with the other callers of the client manager, release must be called once
+// the caller is done with them
+type GCPClients struct {
+	clusterClient               *container.ClusterManagerClient
+	instanceGroupManagersClient *compute.InstanceGroupManagersClient
+	instanceTemplatesClient     *compute.InstanceTemplatesClient
+	machineTypesClient          *compute.MachineTypesClient
+	regionsClient               *compute.RegionsClient
+	release                     func()
+}
+
+type NodePoolPlan struct {
+	MaxNode  int32
+	Warnings []string
+}
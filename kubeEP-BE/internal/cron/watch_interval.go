@@ -0,0 +1,60 @@
+package cron
+
+import (
+	UCEntity "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/usecase"
+	"time"
+)
+
+const (
+	defaultSchedulerInterval = 1 * time.Minute
+	defaultWatchInterval     = 30 * time.Second
+	defaultWatchDenseWindow  = 30 * time.Minute
+	defaultMinChangeInterval = 5 * time.Second
+	maxWatchIntervalDoubling = 16
+)
+
+// watchInterval returns the sampling interval of the event at now. Adaptive sampling keeps the base
+// interval until one dense window after the event start time, then doubles the interval every
+// dense window until it reaches the max interval of the event.
+func (c *cron) watchInterval(e *UCEntity.Event, now time.Time) time.Duration {
+	interval := time.Duration(e.Watch.IntervalSeconds) * time.Second
+	if interval <= 0 {
+		interval = time.Duration(c.cronConfig.Watcher.DefaultIntervalSeconds) * time.Second
+	}
+	if interval <= 0 {
+		interval = defaultWatchInterval
+	}
+
+	maxInterval := time.Duration(e.Watch.MaxIntervalSeconds) * time.Second
+	if maxInterval <= interval {
+		return interval
+	}
+
+	denseWindow := time.Duration(c.cronConfig.Watcher.DenseWindowMinutes) * time.Minute
+	if denseWindow <= 0 {
+		denseWindow = defaultWatchDenseWindow
+	}
+	elapsed := now.Sub(e.StartTime)
+	if elapsed < denseWindow {
+		return interval
+	}
+
+	doubling := int(elapsed / denseWindow)
+	if doubling > maxWatchIntervalDoubling {
+		doubling = maxWatchIntervalDoubling
+	}
+	interval = interval << doubling
+	if interval > maxInterval {
+		return maxInterval
+	}
+	return interval
+}
+
+// minChangeSampleInterval is the minimum gap between a change driven sample and the previous sample
+func (c *cron) minChangeSampleInterval() time.Duration {
+	interval := time.Duration(c.cronConfig.Watcher.MinChangeIntervalSeconds) * time.Second
+	if interval <= 0 {
+		return defaultMinChangeInterval
+	}
+	return interval
+}
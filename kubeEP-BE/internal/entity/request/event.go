@@ -14,6 +14,24 @@ type EventDataRequest struct {
 	ExecuteConfigAt    *time.Time                   `json:"execute_config_at" validate:"required"`
 	WatchingAt         *time.Time                   `json:"watching_at" validate:"required,gtefield=ExecuteConfigAt,ltefield=StartTime"`
 	ModifiedHPAConfigs []EventModifiedHPAConfigData `json:"modified_hpa_configs" validate:"required,min=1,dive"`
+	ScaleDown          *EventScaleDownConfig        `json:"scale_down" validate:"omitempty"`
+	Watch              *EventWatchConfig            `json:"watch" validate:"omitempty"`
+	GuardRules         []EventGuardRuleData         `json:"guard_rules" validate:"omitempty,dive"`
+	// Owner marks an event managed by another system, such as the kubeEP controller
+	Owner *string `json:"owner,omitempty"`
+}
+
+type EventScaleDownConfig struct {
+	WindowMinutes *int64  `json:"window_minutes" validate:"required,min=0"`
+	Steps         *int32  `json:"steps" validate:"required,min=0"`
+	Gate          *string `json:"gate" validate:"omitempty,oneof=NONE CURRENT_REPLICAS HPA_RECOMMENDATION"`
+}
+
+// EventWatchConfig sets the watcher sampling interval, sampling becomes adaptive when
+// MaxIntervalSeconds is above IntervalSeconds
+type EventWatchConfig struct {
+	IntervalSeconds    *int64 `json:"interval_seconds" validate:"required,min=1"`
+	MaxIntervalSeconds *int64 `json:"max_interval_seconds" validate:"omitempty,min=0"`
 }
 
 type EventListRequest struct {
@@ -29,8 +47,40 @@ type UpdateEventDataRequest struct {
 	ExecuteConfigAt    *time.Time                   `json:"execute_config_at" validate:"required,gtefield=ExecuteConfigAt"`
 	WatchingAt         *time.Time                   `json:"watching_at" validate:"required,gtefield=ExecuteConfigAt,ltefield=StartTime"`
 	EventID            *uuid.UUID                   `json:"event_id" validator:"required"`
+	ScaleDown          *EventScaleDownConfig        `json:"scale_down" validate:"omitempty"`
+	Watch              *EventWatchConfig            `json:"watch" validate:"omitempty"`
+	GuardRules         []EventGuardRuleData         `json:"guard_rules" validate:"omitempty,dive"`
 }
 
 type EventDetailRequest struct {
 	EventID *uuid.UUID `json:"event_id" query:"event_id" validator:"required"`
 }
+
+type EventValidationRequest struct {
+	ClusterID          *uuid.UUID                   `json:"cluster_id" validate:"required"`
+	CalculateNodePool  *bool                        `json:"calculate_node_pool"`
+	ModifiedHPAConfigs []EventModifiedHPAConfigData `json:"modified_hpa_configs" validate:"required,min=1,dive"`
+}
+
+// EventRecommendationRequest selects the hpa to recommend with "namespace/name" values, the start
+// and end time are RFC3339 strings
+type EventRecommendationRequest struct {
+	ClusterID *uuid.UUID `query:"cluster_id" validate:"required"`
+	HPAs      []string   `query:"hpa"`
+	StartTime *string    `query:"start_time"`
+	EndTime   *string    `query:"end_time"`
+}
+
+// EventGuardRuleData reacts to a breach lasting DurationSeconds during watching, the rule applies to
+// every hpa of the event when HPAName is empty
+type EventGuardRuleData struct {
+	HPAName         *string  `json:"hpa_name" validate:"required_with=HPANamespace"`
+	HPANamespace    *string  `json:"hpa_namespace" validate:"required_with=HPAName"`
+	Condition       *string  `json:"condition" validate:"required,oneof=UNAVAILABLE_REPLICAS HPA_AT_MAX_REPLICAS UNSCHEDULABLE_PODS"`
+	Threshold       *float64 `json:"threshold" validate:"omitempty,min=0"`
+	DurationSeconds *int64   `json:"duration_seconds" validate:"required,min=0"`
+	Action          *string  `json:"action" validate:"required,oneof=RAISE_MAX_REPLICAS RAISE_NODE_POOL_MAX NOTIFY"`
+	ActionValue     *int32   `json:"action_value" validate:"omitempty,min=1"`
+	NodePoolName    *string  `json:"node_pool_name"`
+	CooldownSeconds *int64   `json:"cooldown_seconds" validate:"omitempty,min=0"`
+}
@@ -0,0 +1,45 @@
+package request
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// EventDocument is the versioned document of the event import, it holds the same events as the
+// export and references the cluster by name so it can move between kubeEP instances
+type EventDocument struct {
+	Version *string              `json:"version" validate:"required,eq=v1"`
+	Events  []EventDocumentEvent `json:"events" validate:"required,min=1,dive"`
+}
+
+// EventDocumentEvent mirrors EventDataRequest, UpdatedNodePools is written by the export and
+// ignored by the import because the node pools are computed again when the event is executed
+type EventDocumentEvent struct {
+	Name               *string                      `json:"name" validate:"required"`
+	Cluster            *EventDocumentCluster        `json:"cluster" validate:"required"`
+	StartTime          *time.Time                   `json:"start_time" validate:"required,gtefield=ExecuteConfigAt"`
+	EndTime            *time.Time                   `json:"end_time" validate:"required,gtefield=StartTime"`
+	CalculateNodePool  *bool                        `json:"calculate_node_pool"`
+	ExecuteConfigAt    *time.Time                   `json:"execute_config_at" validate:"required"`
+	WatchingAt         *time.Time                   `json:"watching_at" validate:"required,gtefield=ExecuteConfigAt,ltefield=StartTime"`
+	ModifiedHPAConfigs []EventModifiedHPAConfigData `json:"modified_hpa_configs" validate:"required,min=1,dive"`
+	ScaleDown          *EventScaleDownConfig        `json:"scale_down" validate:"omitempty"`
+	Watch              *EventWatchConfig            `json:"watch" validate:"omitempty"`
+	GuardRules         []EventGuardRuleData         `json:"guard_rules" validate:"omitempty,dive"`
+	UpdatedNodePools   []EventDocumentNodePool      `json:"updated_node_pools"`
+}
+
+// EventDocumentCluster needs the datacenter only when the cluster name exists in several datacenters
+type EventDocumentCluster struct {
+	Name       *string `json:"name" validate:"required"`
+	Datacenter *string `json:"datacenter"`
+}
+
+type EventDocumentNodePool struct {
+	NodePoolName *string `json:"node_pool_name"`
+	MaxNode      *int32  `json:"max_node"`
+}
+
+type EventExportRequest struct {
+	ClusterID *uuid.UUID `query:"cluster_id" validate:"required"`
+}
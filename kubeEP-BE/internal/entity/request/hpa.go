@@ -5,4 +5,7 @@ type EventModifiedHPAConfigData struct {
 	Namespace   *string `json:"namespace" validate:"required"`
 	MinReplicas *int32  `json:"min_replicas" validate:"required"`
 	MaxReplicas *int32  `json:"max_replicas" validate:"required"`
+
+	PostEventMinReplicas *int32 `json:"post_event_min_replicas" validate:"omitempty,min=1"`
+	PostEventMaxReplicas *int32 `json:"post_event_max_replicas" validate:"omitempty,min=1"`
 }
@@ -0,0 +1,26 @@
+package request
+
+import "github.com/google/uuid"
+
+type NotificationChannelRequest struct {
+	Name       *string  `json:"name" validate:"required"`
+	Type       *string  `json:"type" validate:"required,oneof=WEBHOOK SLACK EMAIL"`
+	URL        *string  `json:"url" validate:"omitempty,url"`
+	Secret     *string  `json:"secret"`
+	Recipients []string `json:"recipients" validate:"omitempty,dive,email"`
+}
+
+type NotificationSubscriptionRequest struct {
+	ChannelID   *uuid.UUID `json:"channel_id" validate:"required"`
+	ClusterID   *uuid.UUID `json:"cluster_id"`
+	EventID     *uuid.UUID `json:"event_id"`
+	Transitions []string   `json:"transitions" validate:"required,min=1,dive,oneof=PRESCALED WATCHING FAILED FINISHED ROLLED_BACK GUARD_BREACHED"`
+}
+
+type NotificationSubscriptionListRequest struct {
+	ClusterID *uuid.UUID `query:"cluster_id"`
+}
+
+type NotificationDeliveryListRequest struct {
+	EventID *uuid.UUID `query:"event_id" validate:"required"`
+}
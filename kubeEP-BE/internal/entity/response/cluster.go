@@ -16,6 +16,7 @@ type UpdatedNodePool struct {
 	ID           uuid.UUID `json:"id"`
 	NodePoolName string    `json:"node_pool_name"`
 	MaxNode      int32     `json:"max_node"`
+	Message      string    `json:"message,omitempty"`
 }
 
 type ClusterDetailResponse struct {
@@ -16,16 +16,45 @@ type EventSimpleResponse struct {
 	StartTime time.Time         `json:"start_time"`
 	EndTime   time.Time         `json:"end_time"`
 	Status    model.EventStatus `json:"status"`
+	Owner     string            `json:"owner,omitempty"`
 }
 
 type EventDetailedResponse struct {
 	EventSimpleResponse
-	CreatedAt          time.Time           `json:"created_at"`
-	UpdatedAt          time.Time           `json:"updated_at"`
-	CalculateNodePool  bool                `json:"calculate_node_pool"`
-	ExecuteConfigAt    time.Time           `json:"execute_config_at"`
-	WatchingAt         time.Time           `json:"watching_at"`
-	Cluster            Cluster             `json:"cluster"`
-	ModifiedHPAConfigs []ModifiedHPAConfig `json:"modified_hpa_configs"`
-	UpdatedNodePools   []UpdatedNodePool   `json:"updated_node_pools"`
+	CreatedAt          time.Time            `json:"created_at"`
+	UpdatedAt          time.Time            `json:"updated_at"`
+	CalculateNodePool  bool                 `json:"calculate_node_pool"`
+	ExecuteConfigAt    time.Time            `json:"execute_config_at"`
+	WatchingAt         time.Time            `json:"watching_at"`
+	Cluster            Cluster              `json:"cluster"`
+	ModifiedHPAConfigs []ModifiedHPAConfig  `json:"modified_hpa_configs"`
+	UpdatedNodePools   []UpdatedNodePool    `json:"updated_node_pools"`
+	ScaleDown          EventScaleDownConfig `json:"scale_down"`
+	Watch              EventWatchConfig     `json:"watch"`
+	ScaleDownSteps     []ScaleDownStep      `json:"scale_down_steps"`
+	GuardRules         []GuardRule          `json:"guard_rules"`
+	GuardActions       []GuardAction        `json:"guard_actions"`
+}
+
+type EventScaleDownConfig struct {
+	WindowMinutes int64               `json:"window_minutes"`
+	Steps         int32               `json:"steps"`
+	Gate          model.ScaleDownGate `json:"gate"`
+}
+
+type EventWatchConfig struct {
+	IntervalSeconds    int64 `json:"interval_seconds,omitempty"`
+	MaxIntervalSeconds int64 `json:"max_interval_seconds,omitempty"`
+}
+
+type ScaleDownStep struct {
+	ID                   uuid.UUID                 `json:"id"`
+	ScheduledHPAConfigID uuid.UUID                 `json:"scheduled_hpa_config_id"`
+	StepNumber           int32                     `json:"step_number"`
+	ScheduledAt          time.Time                 `json:"scheduled_at"`
+	ExecutedAt           *time.Time                `json:"executed_at,omitempty"`
+	MinReplicas          int32                     `json:"min_replicas"`
+	MaxReplicas          int32                     `json:"max_replicas"`
+	Status               model.ScaleDownStepStatus `json:"status"`
+	Message              string                    `json:"message"`
 }
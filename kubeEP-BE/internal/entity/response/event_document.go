@@ -0,0 +1,74 @@
+package response
+
+import (
+	"github.com/google/uuid"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository/model"
+	"time"
+)
+
+type EventDocument struct {
+	Version string               `json:"version"`
+	Events  []EventDocumentEvent `json:"events"`
+}
+
+type EventDocumentEvent struct {
+	Name               string                   `json:"name"`
+	Cluster            EventDocumentCluster     `json:"cluster"`
+	StartTime          time.Time                `json:"start_time"`
+	EndTime            time.Time                `json:"end_time"`
+	CalculateNodePool  bool                     `json:"calculate_node_pool"`
+	ExecuteConfigAt    time.Time                `json:"execute_config_at"`
+	WatchingAt         time.Time                `json:"watching_at"`
+	ModifiedHPAConfigs []EventDocumentHPAConfig `json:"modified_hpa_configs"`
+	ScaleDown          *EventScaleDownConfig    `json:"scale_down,omitempty"`
+	Watch              *EventWatchConfig        `json:"watch,omitempty"`
+	GuardRules         []EventDocumentGuardRule `json:"guard_rules,omitempty"`
+	UpdatedNodePools   []EventDocumentNodePool  `json:"updated_node_pools,omitempty"`
+}
+
+type EventDocumentCluster struct {
+	Name       string `json:"name"`
+	Datacenter string `json:"datacenter"`
+}
+
+type EventDocumentHPAConfig struct {
+	Name                 string `json:"name"`
+	Namespace            string `json:"namespace"`
+	MinReplicas          *int32 `json:"min_replicas"`
+	MaxReplicas          int32  `json:"max_replicas"`
+	PostEventMinReplicas *int32 `json:"post_event_min_replicas,omitempty"`
+	PostEventMaxReplicas *int32 `json:"post_event_max_replicas,omitempty"`
+}
+
+type EventDocumentGuardRule struct {
+	HPAName         string                `json:"hpa_name,omitempty"`
+	HPANamespace    string                `json:"hpa_namespace,omitempty"`
+	Condition       model.GuardCondition  `json:"condition"`
+	Threshold       float64               `json:"threshold,omitempty"`
+	DurationSeconds int64                 `json:"duration_seconds"`
+	Action          model.GuardActionType `json:"action"`
+	ActionValue     int32                 `json:"action_value,omitempty"`
+	NodePoolName    string                `json:"node_pool_name,omitempty"`
+	CooldownSeconds int64                 `json:"cooldown_seconds,omitempty"`
+}
+
+type EventDocumentNodePool struct {
+	NodePoolName string `json:"node_pool_name"`
+	MaxNode      int32  `json:"max_node"`
+}
+
+type EventImportResponse struct {
+	DryRun bool                `json:"dry_run"`
+	Valid  bool                `json:"valid"`
+	Events []EventImportResult `json:"events"`
+}
+
+// EventImportResult holds the validation of an imported event, EventID is set once it is registered
+type EventImportResult struct {
+	Name       string                   `json:"name"`
+	Cluster    string                   `json:"cluster"`
+	Valid      bool                     `json:"valid"`
+	EventID    *uuid.UUID               `json:"event_id,omitempty"`
+	Errors     []string                 `json:"errors"`
+	Validation *EventValidationResponse `json:"validation,omitempty"`
+}
@@ -0,0 +1,32 @@
+package response
+
+import (
+	"github.com/google/uuid"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/request"
+)
+
+type EventRecommendation struct {
+	Event           request.EventDataRequest `json:"event"`
+	BasedOnEventIDs []uuid.UUID              `json:"based_on_event_ids"`
+	HPAs            []HPARecommendation      `json:"hpas"`
+	NodePools       []NodePoolRecommendation `json:"node_pools"`
+}
+
+type HPARecommendation struct {
+	Name         string  `json:"name"`
+	Namespace    string  `json:"namespace"`
+	MinReplicas  int32   `json:"min_replicas"`
+	MaxReplicas  int32   `json:"max_replicas"`
+	SampleCount  int     `json:"sample_count"`
+	P50Replicas  float64 `json:"p50_replicas"`
+	P95Replicas  float64 `json:"p95_replicas"`
+	PeakReplicas int32   `json:"peak_replicas"`
+}
+
+type NodePoolRecommendation struct {
+	Name              string  `json:"name"`
+	ExpectedNodeCount int32   `json:"expected_node_count"`
+	SampleCount       int     `json:"sample_count"`
+	P95NodeCount      float64 `json:"p95_node_count"`
+	PeakNodeCount     int32   `json:"peak_node_count"`
+}
@@ -0,0 +1,46 @@
+package response
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type EventReport struct {
+	EventID     uuid.UUID             `json:"event_id"`
+	EventName   string                `json:"event_name"`
+	StartTime   time.Time             `json:"start_time"`
+	EndTime     time.Time             `json:"end_time"`
+	GeneratedAt time.Time             `json:"generated_at"`
+	HPAs        []EventReportHPA      `json:"hpas"`
+	NodePools   []EventReportNodePool `json:"node_pools"`
+}
+
+type EventReportHPA struct {
+	Name                   string                `json:"name"`
+	Namespace              string                `json:"namespace"`
+	MinReplicas            *int32                `json:"min_replicas"`
+	MaxReplicas            int32                 `json:"max_replicas"`
+	PeakReplicas           int32                 `json:"peak_replicas"`
+	PeakReplicasAt         *time.Time            `json:"peak_replicas_at"`
+	PeakDesiredReplicas    int32                 `json:"peak_desired_replicas"`
+	TimeToReadySeconds     *float64              `json:"time_to_ready_seconds"`
+	HitMaxIntervals        []EventReportInterval `json:"hit_max_intervals"`
+	UnavailableIntervals   []EventReportInterval `json:"unavailable_intervals"`
+	RecommendedMinReplicas int32                 `json:"recommended_min_replicas"`
+	RecommendedMaxReplicas int32                 `json:"recommended_max_replicas"`
+}
+
+type EventReportNodePool struct {
+	Name                     string     `json:"name"`
+	MaxNode                  int32      `json:"max_node"`
+	PeakNodeCount            int32      `json:"peak_node_count"`
+	PeakNodeCountAt          *time.Time `json:"peak_node_count_at"`
+	PeakRequestedCPUMillis   int64      `json:"peak_requested_cpu_millis"`
+	PeakRequestedMemoryBytes int64      `json:"peak_requested_memory_bytes"`
+	RecommendedNodeCount     int32      `json:"recommended_node_count"`
+}
+
+type EventReportInterval struct {
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+}
@@ -0,0 +1,22 @@
+package response
+
+import "github.com/google/uuid"
+
+type EventStreamHPAStatus struct {
+	ScheduledHPAConfigID uuid.UUID `json:"scheduled_hpa_config_id"`
+	Name                 string    `json:"name"`
+	Namespace            string    `json:"namespace"`
+	HPAStatus
+}
+
+type EventStreamNodePoolStatus struct {
+	UpdatedNodePoolID uuid.UUID `json:"updated_node_pool_id"`
+	NodePoolName      string    `json:"node_pool_name"`
+	NodePoolStatus
+}
+
+type EventStreamEventStatus struct {
+	Status         string `json:"status"`
+	PreviousStatus string `json:"previous_status,omitempty"`
+	Message        string `json:"message,omitempty"`
+}
@@ -0,0 +1,26 @@
+package response
+
+import "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant"
+
+type ValidationIssue struct {
+	Level   constant.ValidationLevel `json:"level"`
+	Code    string                   `json:"code"`
+	Message string                   `json:"message"`
+}
+
+type HPAValidation struct {
+	Name      string            `json:"name"`
+	Namespace string            `json:"namespace"`
+	Issues    []ValidationIssue `json:"issues"`
+}
+
+type NodePoolValidation struct {
+	Name   string            `json:"name"`
+	Issues []ValidationIssue `json:"issues"`
+}
+
+type EventValidationResponse struct {
+	Valid     bool                 `json:"valid"`
+	HPAs      []HPAValidation      `json:"hpas"`
+	NodePools []NodePoolValidation `json:"node_pools"`
+}
@@ -0,0 +1,33 @@
+package response
+
+import (
+	"github.com/google/uuid"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository/model"
+	"time"
+)
+
+type GuardRule struct {
+	ID              uuid.UUID             `json:"id"`
+	HPAName         string                `json:"hpa_name,omitempty"`
+	HPANamespace    string                `json:"hpa_namespace,omitempty"`
+	Condition       model.GuardCondition  `json:"condition"`
+	Threshold       float64               `json:"threshold"`
+	DurationSeconds int64                 `json:"duration_seconds"`
+	Action          model.GuardActionType `json:"action"`
+	ActionValue     int32                 `json:"action_value,omitempty"`
+	NodePoolName    string                `json:"node_pool_name,omitempty"`
+	CooldownSeconds int64                 `json:"cooldown_seconds"`
+}
+
+type GuardAction struct {
+	ID            uuid.UUID               `json:"id"`
+	CreatedAt     time.Time               `json:"created_at"`
+	GuardRuleID   uuid.UUID               `json:"guard_rule_id"`
+	Action        model.GuardActionType   `json:"action"`
+	Status        model.GuardActionStatus `json:"status"`
+	Target        string                  `json:"target"`
+	PreviousValue int32                   `json:"previous_value"`
+	NewValue      int32                   `json:"new_value"`
+	Message       string                  `json:"message"`
+	BreachedSince time.Time               `json:"breached_since"`
+}
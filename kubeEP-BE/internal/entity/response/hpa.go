@@ -1,6 +1,9 @@
 package response
 
-import "github.com/google/uuid"
+import (
+	"github.com/google/uuid"
+	"time"
+)
 
 type SimpleHPA struct {
 	Name            string `json:"name"`
@@ -16,4 +19,16 @@ type ModifiedHPAConfig struct {
 	Namespace   string    `json:"namespace"`
 	MinReplicas *int32    `json:"min_replicas,omitempty"`
 	MaxReplicas int32     `json:"max_replicas"`
+
+	OriginalMinReplicas  *int32 `json:"original_min_replicas,omitempty"`
+	OriginalMaxReplicas  *int32 `json:"original_max_replicas,omitempty"`
+	PostEventMinReplicas *int32 `json:"post_event_min_replicas,omitempty"`
+	PostEventMaxReplicas *int32 `json:"post_event_max_replicas,omitempty"`
+
+	HitMaxIntervals []HPAHitMaxInterval `json:"hit_max_intervals"`
+}
+
+type HPAHitMaxInterval struct {
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
 }
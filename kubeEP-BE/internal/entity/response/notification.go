@@ -0,0 +1,40 @@
+package response
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type NotificationChannel struct {
+	ID         uuid.UUID `json:"id"`
+	Name       string    `json:"name"`
+	Type       string    `json:"type"`
+	URL        string    `json:"url,omitempty"`
+	HasSecret  bool      `json:"has_secret"`
+	Recipients []string  `json:"recipients,omitempty"`
+}
+
+type NotificationSubscription struct {
+	ID          uuid.UUID           `json:"id"`
+	Channel     NotificationChannel `json:"channel"`
+	ClusterID   *uuid.UUID          `json:"cluster_id"`
+	EventID     *uuid.UUID          `json:"event_id"`
+	Transitions []string            `json:"transitions"`
+}
+
+type NotificationDelivery struct {
+	ID          uuid.UUID  `json:"id"`
+	CreatedAt   time.Time  `json:"created_at"`
+	ChannelID   uuid.UUID  `json:"channel_id"`
+	ChannelName string     `json:"channel_name"`
+	EventID     uuid.UUID  `json:"event_id"`
+	Transition  string     `json:"transition"`
+	Status      string     `json:"status"`
+	Attempts    int32      `json:"attempts"`
+	LastError   string     `json:"last_error,omitempty"`
+	DeliveredAt *time.Time `json:"delivered_at"`
+}
+
+type NotificationCreationResponse struct {
+	ID uuid.UUID `json:"id"`
+}
@@ -3,8 +3,14 @@ package response
 import "time"
 
 type NodePoolStatus struct {
-	CreatedAt time.Time `json:"created_at"`
-	Count     int32     `json:"count"`
+	CreatedAt              time.Time `json:"created_at"`
+	Count                  int32     `json:"count"`
+	AllocatableCPUMillis   int64     `json:"allocatable_cpu_millis"`
+	AllocatableMemoryBytes int64     `json:"allocatable_memory_bytes"`
+	RequestedCPUMillis     int64     `json:"requested_cpu_millis"`
+	RequestedMemoryBytes   int64     `json:"requested_memory_bytes"`
+	UsageCPUMillis         *int64    `json:"usage_cpu_millis,omitempty"`
+	UsageMemoryBytes       *int64    `json:"usage_memory_bytes,omitempty"`
 }
 
 type HPAStatus struct {
@@ -13,4 +19,30 @@ type HPAStatus struct {
 	AvailableReplicas   int32     `json:"available_replicas"`
 	ReadyReplicas       int32     `json:"ready_replicas"`
 	UnavailableReplicas int32     `json:"unavailable_replicas"`
+
+	HPAMinReplicas       int32            `json:"hpa_min_replicas"`
+	HPAMaxReplicas       int32            `json:"hpa_max_replicas"`
+	HPACurrentReplicas   int32            `json:"hpa_current_replicas"`
+	HPADesiredReplicas   int32            `json:"hpa_desired_replicas"`
+	ScalingLimited       bool             `json:"scaling_limited"`
+	ScalingLimitedReason string           `json:"scaling_limited_reason,omitempty"`
+	Metrics              []HPAMetricValue `json:"metrics"`
+}
+
+type HPAMetricValue struct {
+	Name    string `json:"name"`
+	Current string `json:"current,omitempty"`
+	Target  string `json:"target,omitempty"`
+}
+
+type PodStatus struct {
+	CreatedAt                time.Time `json:"created_at"`
+	PendingPods              int32     `json:"pending_pods"`
+	UnschedulablePods        int32     `json:"unschedulable_pods"`
+	FailedSchedulingEvents   int32     `json:"failed_scheduling_events"`
+	RestartCount             int32     `json:"restart_count"`
+	OOMKilledContainers      int32     `json:"oom_killed_containers"`
+	StartedPods              int32     `json:"started_pods"`
+	AvgStartupLatencySeconds float64   `json:"avg_startup_latency_seconds"`
+	MaxStartupLatencySeconds float64   `json:"max_startup_latency_seconds"`
 }
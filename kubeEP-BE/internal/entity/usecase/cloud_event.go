@@ -0,0 +1,34 @@
+package UCEntity
+
+import (
+	"github.com/google/uuid"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository/model"
+)
+
+type EventStatusChangedData struct {
+	Event          EventSummary      `json:"event"`
+	PreviousStatus model.EventStatus `json:"previous_status"`
+	Message        string            `json:"message,omitempty"`
+}
+
+type HPAStatusChangedData struct {
+	EventID              uuid.UUID             `json:"event_id"`
+	ScheduledHPAConfigID uuid.UUID             `json:"scheduled_hpa_config_id"`
+	Name                 string                `json:"name"`
+	Namespace            string                `json:"namespace"`
+	PreviousStatus       model.HPAUpdateStatus `json:"previous_status"`
+	Status               model.HPAUpdateStatus `json:"status"`
+	Message              string                `json:"message,omitempty"`
+}
+
+type NodePoolUpdatedData struct {
+	Event     EventSummary             `json:"event"`
+	NodePools []UpdatedNodePoolSummary `json:"node_pools"`
+}
+
+type UpdatedNodePoolSummary struct {
+	ID      uuid.UUID `json:"id"`
+	Name    string    `json:"name"`
+	MaxNode int32     `json:"max_node"`
+	Message string    `json:"message,omitempty"`
+}
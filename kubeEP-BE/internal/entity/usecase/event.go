@@ -19,9 +19,43 @@ type Event struct {
 	ExecuteConfigAt   time.Time
 	WatchingAt        time.Time
 	Cluster           ClusterData
+	ScaleDown         EventScaleDownConfig
+	Watch             EventWatchConfig
+	Owner             string
+}
+
+type EventScaleDownConfig struct {
+	WindowMinutes int64
+	Steps         int32
+	Gate          model.ScaleDownGate
+}
+
+type EventWatchConfig struct {
+	IntervalSeconds    int64
+	MaxIntervalSeconds int64
 }
 
 type DetailedEvent struct {
 	Event
 	EventModifiedHPAConfigData []EventModifiedHPAConfigData
 }
+
+// EventStatusTransition is a status change saved by UpdateEvent, it is published once the update
+// is committed
+type EventStatusTransition struct {
+	Event          Event
+	PreviousStatus model.EventStatus
+}
+
+// EventSummary is the event data sent to the external systems
+type EventSummary struct {
+	ID              uuid.UUID         `json:"id"`
+	Name            string            `json:"name"`
+	ClusterID       uuid.UUID         `json:"cluster_id"`
+	ClusterName     string            `json:"cluster_name"`
+	Status          model.EventStatus `json:"status"`
+	StartTime       time.Time         `json:"start_time"`
+	EndTime         time.Time         `json:"end_time"`
+	ExecuteConfigAt time.Time         `json:"execute_config_at"`
+	WatchingAt      time.Time         `json:"watching_at"`
+}
@@ -0,0 +1,33 @@
+package UCEntity
+
+import "github.com/google/uuid"
+
+type EventRecommendationData struct {
+	// Most recent event the recommendation is based on, the config of the new event is copied from it
+	LatestEvent   *Event
+	BasedOnEvents []uuid.UUID
+	HPAs          []HPARecommendationData
+	NodePools     []NodePoolRecommendationData
+}
+
+type HPARecommendationData struct {
+	Name        string
+	Namespace   string
+	MinReplicas int32
+	MaxReplicas int32
+	// Post event replicas of the latest event with the HPA
+	PostEventMinReplicas *int32
+	PostEventMaxReplicas *int32
+	SampleCount          int
+	P50Replicas          float64
+	P95Replicas          float64
+	PeakReplicas         int32
+}
+
+type NodePoolRecommendationData struct {
+	NodePoolName      string
+	ExpectedNodeCount int32
+	SampleCount       int
+	P95NodeCount      float64
+	PeakNodeCount     int32
+}
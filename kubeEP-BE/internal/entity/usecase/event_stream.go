@@ -0,0 +1,41 @@
+package UCEntity
+
+import (
+	"github.com/google/uuid"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository/model"
+)
+
+type EventStreamMessageType string
+
+const (
+	EventStreamHPAStatus      EventStreamMessageType = "hpa_status"
+	EventStreamNodePoolStatus EventStreamMessageType = "node_pool_status"
+	EventStreamEventStatus    EventStreamMessageType = "event_status"
+)
+
+// EventStreamMessage is a live update of an event, only the field of its type is set
+type EventStreamMessage struct {
+	Type           EventStreamMessageType
+	HPAStatus      *EventStreamHPAStatusData      `json:",omitempty"`
+	NodePoolStatus *EventStreamNodePoolStatusData `json:",omitempty"`
+	EventStatus    *EventStreamEventStatusData    `json:",omitempty"`
+}
+
+type EventStreamHPAStatusData struct {
+	ScheduledHPAConfigID uuid.UUID
+	Name                 string
+	Namespace            string
+	HPAStatusData
+}
+
+type EventStreamNodePoolStatusData struct {
+	UpdatedNodePoolID uuid.UUID
+	NodePoolName      string
+	NodePoolStatusData
+}
+
+type EventStreamEventStatusData struct {
+	Status         model.EventStatus
+	PreviousStatus model.EventStatus
+	Message        string
+}
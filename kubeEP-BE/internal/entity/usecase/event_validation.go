@@ -0,0 +1,48 @@
+package UCEntity
+
+import (
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant"
+	"google.golang.org/genproto/googleapis/container/v1"
+	v1Apps "k8s.io/api/apps/v1"
+	v1Core "k8s.io/api/core/v1"
+)
+
+type EventValidationInput struct {
+	CalculateNodePool  bool
+	ModifiedHPAConfigs []EventModifiedHPAConfigData
+	ExistingHPAs       []SimpleHPAData
+	Deployments        []v1Apps.Deployment
+	NodePools          []*container.NodePool
+	NodePoolNodeCounts map[string]int
+	// NodePoolNodes holds the labels and taints of an existing node of each node pool, or the ones
+	// of the node pool config when it has no node
+	NodePoolNodes map[string]NodePoolNodeData
+}
+
+type NodePoolNodeData struct {
+	Labels map[string]string
+	Taints []v1Core.Taint
+}
+
+type ValidationIssue struct {
+	Level   constant.ValidationLevel
+	Code    string
+	Message string
+}
+
+type HPAValidationResult struct {
+	Name      string
+	Namespace string
+	Issues    []ValidationIssue
+}
+
+type NodePoolValidationResult struct {
+	Name   string
+	Issues []ValidationIssue
+}
+
+type EventValidationResult struct {
+	Valid     bool
+	HPAs      []HPAValidationResult
+	NodePools []NodePoolValidationResult
+}
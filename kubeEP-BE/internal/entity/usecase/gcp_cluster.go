@@ -1,7 +1,10 @@
 package UCEntity
 
 import (
+	compute "cloud.google.com/go/compute/apiv1"
+	containerClient "cloud.google.com/go/container/apiv1"
 	"google.golang.org/genproto/googleapis/container/v1"
+	v1Core "k8s.io/api/core/v1"
 )
 
 type GCPClusterData struct {
@@ -20,3 +23,28 @@ type GCPClusterObjectData struct {
 type GCPClusterOperationData struct {
 	OperationData *container.Operation
 }
+
+type GCPNodePoolTemplateData struct {
+	MachineType string
+	GuestCPUs   int32
+	Zone        string
+	Labels      map[string]string
+	Taints      []v1Core.Taint
+	Allocatable v1Core.ResourceList
+}
+
+type GCPQuotaData struct {
+	Metric string
+	Limit  float64
+	Usage  float64
+}
+
+// GCPClientsData holds the google api clients of a datacenter, the clients are owned by the client
+// manager and must not be closed by the caller
+type GCPClientsData struct {
+	ClusterClient               *containerClient.ClusterManagerClient
+	InstanceGroupManagersClient *compute.InstanceGroupManagersClient
+	InstanceTemplatesClient     *compute.InstanceTemplatesClient
+	MachineTypesClient          *compute.MachineTypesClient
+	RegionsClient               *compute.RegionsClient
+}
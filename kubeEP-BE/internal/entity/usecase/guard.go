@@ -0,0 +1,50 @@
+package UCEntity
+
+import (
+	"github.com/google/uuid"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository/model"
+	"time"
+)
+
+type GuardRuleData struct {
+	ID              uuid.UUID
+	HPAName         string
+	HPANamespace    string
+	Condition       model.GuardCondition
+	Threshold       float64
+	DurationSeconds int64
+	Action          model.GuardActionType
+	ActionValue     int32
+	NodePoolName    string
+	CooldownSeconds int64
+}
+
+// AppliesTo tells whether the rule watches the hpa, rules without hpa watch every hpa of the event
+func (r GuardRuleData) AppliesTo(name, namespace string) bool {
+	if r.HPAName == "" {
+		return true
+	}
+	return r.HPAName == name && r.HPANamespace == namespace
+}
+
+// GuardSampleData is the state of an hpa used to evaluate the guard rules
+type GuardSampleData struct {
+	Replicas            int32
+	UnavailableReplicas int32
+	HPAStatus           *HPAStatusSnapshotData
+	PodStatistic        *PodStatisticData
+}
+
+type GuardActionData struct {
+	ID            uuid.UUID
+	CreatedAt     time.Time
+	EventID       uuid.UUID
+	GuardRuleID   uuid.UUID
+	Action        model.GuardActionType
+	Status        model.GuardActionStatus
+	Target        string
+	PreviousValue int32
+	NewValue      int32
+	Message       string
+	BreachedSince time.Time
+}
@@ -6,8 +6,9 @@ import (
 )
 
 type HPAScaleTargetRef struct {
-	Name string
-	Kind string
+	Name       string
+	Kind       string
+	APIVersion string
 }
 
 type SimpleHPAData struct {
@@ -20,11 +21,31 @@ type SimpleHPAData struct {
 }
 
 type EventModifiedHPAConfigData struct {
-	ID          uuid.UUID
-	Name        string
-	Namespace   string
-	Status      model.HPAUpdateStatus
-	Message     string
-	MinReplicas *int32
-	MaxReplicas int32
+	ID                   uuid.UUID
+	Name                 string
+	Namespace            string
+	Status               model.HPAUpdateStatus
+	Message              string
+	MinReplicas          *int32
+	MaxReplicas          int32
+	OriginalMinReplicas  *int32
+	OriginalMaxReplicas  *int32
+	PostEventMinReplicas *int32
+	PostEventMaxReplicas *int32
+}
+
+type HPAMetricValueData struct {
+	Name    string `json:"name"`
+	Current string `json:"current,omitempty"`
+	Target  string `json:"target,omitempty"`
+}
+
+type HPAStatusSnapshotData struct {
+	MinReplicas          int32
+	MaxReplicas          int32
+	CurrentReplicas      int32
+	DesiredReplicas      int32
+	ScalingLimited       bool
+	ScalingLimitedReason string
+	Metrics              []HPAMetricValueData
 }
@@ -0,0 +1,60 @@
+package UCEntity
+
+import (
+	"github.com/google/uuid"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository/model"
+	"time"
+)
+
+// NotificationChannelConfig is stored as the channel config, webhook uses URL and Secret, slack
+// uses URL and email uses Recipients
+type NotificationChannelConfig struct {
+	URL        string   `json:"url,omitempty"`
+	Secret     string   `json:"secret,omitempty"`
+	Recipients []string `json:"recipients,omitempty"`
+}
+
+type NotificationChannelData struct {
+	ID     uuid.UUID
+	Name   string
+	Type   model.NotificationChannelType
+	Config NotificationChannelConfig
+}
+
+type NotificationSubscriptionData struct {
+	ID          uuid.UUID
+	Channel     NotificationChannelData
+	ClusterID   *uuid.UUID
+	EventID     *uuid.UUID
+	Transitions []model.NotificationTransition
+}
+
+type NotificationDeliveryData struct {
+	ID          uuid.UUID
+	CreatedAt   time.Time
+	ChannelID   uuid.UUID
+	ChannelName string
+	EventID     uuid.UUID
+	Transition  model.NotificationTransition
+	Status      model.NotificationDeliveryStatus
+	Attempts    int32
+	LastError   string
+	DeliveredAt *time.Time
+}
+
+// NotificationPayload is the body of the webhook deliveries
+type NotificationPayload struct {
+	Transition model.NotificationTransition `json:"transition"`
+	Message    string                       `json:"message,omitempty"`
+	Event      EventSummary                 `json:"event"`
+	CreatedAt  time.Time                    `json:"created_at"`
+}
+
+func (s NotificationSubscriptionData) HasTransition(transition model.NotificationTransition) bool {
+	for _, subscribed := range s.Transitions {
+		if subscribed == transition {
+			return true
+		}
+	}
+	return false
+}
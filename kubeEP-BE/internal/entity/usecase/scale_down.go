@@ -0,0 +1,20 @@
+package UCEntity
+
+import (
+	"github.com/google/uuid"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository/model"
+	"time"
+)
+
+type ScaleDownStepData struct {
+	ID                   uuid.UUID
+	EventID              uuid.UUID
+	ScheduledHPAConfigID uuid.UUID
+	StepNumber           int32
+	ScheduledAt          time.Time
+	ExecutedAt           *time.Time
+	MinReplicas          int32
+	MaxReplicas          int32
+	Status               model.ScaleDownStepStatus
+	Message              string
+}
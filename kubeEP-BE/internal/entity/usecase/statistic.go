@@ -9,11 +9,18 @@ type UpdatedNodePoolData struct {
 	ID           uuid.UUID
 	NodePoolName string
 	MaxNode      int32
+	Message      string
 }
 
 type NodePoolStatusData struct {
-	CreatedAt time.Time
-	Count     int32
+	CreatedAt         time.Time
+	Count             int32
+	AllocatableCPU    int64
+	AllocatableMemory int64
+	RequestedCPU      int64
+	RequestedMemory   int64
+	UsageCPU          *int64
+	UsageMemory       *int64
 }
 
 type HPAStatusData struct {
@@ -22,4 +29,26 @@ type HPAStatusData struct {
 	AvailableReplicas   int32
 	ReadyReplicas       int32
 	UnavailableReplicas int32
+	HPAStatusSnapshotData
+}
+
+type HPAHitMaxIntervalData struct {
+	StartTime time.Time
+	EndTime   time.Time
+}
+
+type PodStatisticData struct {
+	PendingPods              int32
+	UnschedulablePods        int32
+	FailedSchedulingEvents   int32
+	RestartCount             int32
+	OOMKilledContainers      int32
+	StartedPods              int32
+	AvgStartupLatencySeconds float64
+	MaxStartupLatencySeconds float64
+}
+
+type PodStatusData struct {
+	CreatedAt time.Time
+	PodStatisticData
 }
@@ -3,6 +3,7 @@ package handler
 import (
 	"context"
 	"errors"
+	"fmt"
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant"
@@ -11,9 +12,11 @@ import (
 	UCEntity "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/usecase"
 	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository/model"
 	useCase "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/usecase"
+	"google.golang.org/genproto/googleapis/container/v1"
 	"gorm.io/gorm"
 	"k8s.io/client-go/kubernetes"
 	"net/http"
+	"strings"
 )
 
 type baseHandler struct {
@@ -61,6 +64,7 @@ type kubernetesBaseHandler struct {
 	generalClusterUC useCase.Cluster
 	gcpClusterUC     useCase.GCPCluster
 	gcpDatacenterUC  useCase.GCPDatacenter
+	clientManager    useCase.ClientManager
 }
 
 func (h kubernetesBaseHandler) getClusterKubernetesClient(
@@ -75,31 +79,40 @@ func (h kubernetesBaseHandler) getClusterKubernetesClient(
 	if err != nil {
 		return nil, nil, err
 	}
-	var kubernetesClient kubernetes.Interface
-	switch clusterData.Datacenter.Datacenter {
-	case model.GCP:
-		datacenterName := clusterData.Datacenter.Name
-		datacenterData := UCEntity.DatacenterData{
-			Credentials: clusterData.Datacenter.Credentials,
-			Name:        datacenterName,
-		}
-		googleCredential, err := h.gcpDatacenterUC.GetGoogleCredentials(
-			ctx,
-			datacenterData,
-		)
-		if err != nil {
-			return nil, nil, err
-		}
-		h.gcpClusterUC.RegisterGoogleCredentials(datacenterName, googleCredential)
-		kubernetesClient, err = h.gcpClusterUC.GetKubernetesClusterClient(
-			datacenterName,
-			clusterData,
-		)
-		if err != nil {
-			return nil, nil, err
-		}
-	default:
-		return nil, nil, errors.New(errorConstant.DatacenterTypeNotFound)
+	kubernetesClient, err := h.clientManager.GetKubernetesClient(ctx, clusterData)
+	if err != nil {
+		return nil, nil, err
 	}
 	return kubernetesClient, clusterData, nil
 }
+
+func (h kubernetesBaseHandler) getGCPClusterNodePools(
+	ctx context.Context,
+	clusterData *UCEntity.ClusterData,
+) ([]*container.NodePool, error) {
+	if clusterData.Datacenter.Datacenter != model.GCP {
+		return nil, errors.New(errorConstant.DatacenterMismatch)
+	}
+	googleClients, release, err := h.clientManager.GetGCPClients(ctx, clusterData.Datacenter)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	// Parse GCP Cluster Name
+	clusterMetadata := strings.Split(clusterData.Name, "_")
+	if len(clusterMetadata) < 4 {
+		return nil, errors.New(fmt.Sprintf(errorConstant.ClusterNotFound, clusterData.Name))
+	}
+	googleClusterData, err := h.gcpClusterUC.GetGCPClusterObject(
+		ctx,
+		googleClients.ClusterClient,
+		clusterMetadata[1],
+		clusterMetadata[3],
+		clusterMetadata[2],
+	)
+	if err != nil {
+		return nil, err
+	}
+	return googleClusterData.ClusterObject.NodePools, nil
+}
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"context"
 	"fmt"
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
@@ -10,6 +11,9 @@ import (
 	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/request"
 	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/response"
 	UCEntity "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/usecase"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/pkg/cloudevent"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/pkg/util"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository/model"
 	useCase "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/usecase"
 	"gorm.io/gorm"
 	"time"
@@ -20,18 +24,33 @@ type Event interface {
 	ListEventByCluster(c *fiber.Ctx) error
 	UpdateEvent(c *fiber.Ctx) error
 	GetDetailedEvent(c *fiber.Ctx) error
+	ValidateEvent(c *fiber.Ctx) error
 	DeleteEvent(c *fiber.Ctx) error
 	ListNodePoolStatusByUpdatedNodePool(c *fiber.Ctx) error
 	ListHPAStatusByScheduledHPAConfig(c *fiber.Ctx) error
+	ListPodStatusByScheduledHPAConfig(c *fiber.Ctx) error
+	GetEventReport(c *fiber.Ctx) error
+	GetEventRecommendation(c *fiber.Ctx) error
+	StreamEvent(c *fiber.Ctx) error
+	ExportEvent(c *fiber.Ctx) error
+	ExportClusterEvents(c *fiber.Ctx) error
+	ImportEvents(c *fiber.Ctx) error
 }
 
 type event struct {
 	kubernetesBaseHandler
-	validatorInst        *validator.Validate
-	db                   *gorm.DB
-	eventUC              useCase.Event
-	scheduledHPAConfigUC useCase.ScheduledHPAConfig
-	statisticUC          useCase.Statistic
+	validatorInst         *validator.Validate
+	db                    *gorm.DB
+	eventUC               useCase.Event
+	scheduledHPAConfigUC  useCase.ScheduledHPAConfig
+	statisticUC           useCase.Statistic
+	scaleDownUC           useCase.ScaleDown
+	eventValidationUC     useCase.EventValidation
+	eventReportUC         useCase.EventReport
+	eventRecommendationUC useCase.EventRecommendation
+	cloudEventUC          useCase.CloudEvent
+	eventStreamUC         useCase.EventStream
+	guardUC               useCase.Guard
 }
 
 func newEventHandler(
@@ -39,6 +58,13 @@ func newEventHandler(
 	eventUC useCase.Event,
 	scheduledHPAConfigUC useCase.ScheduledHPAConfig,
 	updatedNodePoolUC useCase.Statistic,
+	scaleDownUC useCase.ScaleDown,
+	eventValidationUC useCase.EventValidation,
+	eventReportUC useCase.EventReport,
+	eventRecommendationUC useCase.EventRecommendation,
+	cloudEventUC useCase.CloudEvent,
+	eventStreamUC useCase.EventStream,
+	guardUC useCase.Guard,
 	db *gorm.DB,
 	kubeHandler kubernetesBaseHandler,
 ) Event {
@@ -48,6 +74,13 @@ func newEventHandler(
 		eventUC:               eventUC,
 		scheduledHPAConfigUC:  scheduledHPAConfigUC,
 		statisticUC:           updatedNodePoolUC,
+		scaleDownUC:           scaleDownUC,
+		eventValidationUC:     eventValidationUC,
+		eventReportUC:         eventReportUC,
+		eventRecommendationUC: eventRecommendationUC,
+		cloudEventUC:          cloudEventUC,
+		eventStreamUC:         eventStreamUC,
+		guardUC:               guardUC,
 		db:                    db,
 	}
 }
@@ -76,27 +109,30 @@ func (e *event) RegisterEvents(c *fiber.Ctx) error {
 
 	ctx := c.Context()
 	db := e.db.WithContext(ctx)
-	tx := db.Begin()
 
-	kubernetesClient, clusterData, err := e.getClusterKubernetesClient(
+	HPAConfigs := e.buildModifiedHPAConfigs(reqData.ModifiedHPAConfigs)
+	validationInput, err := e.collectEventValidationInput(
 		ctx,
 		db,
 		*reqData.ClusterID,
+		*reqData.CalculateNodePool,
+		HPAConfigs,
 	)
 	if err != nil {
 		return e.errorResponse(c, err.Error())
 	}
+	validationResult := e.eventValidationUC.ValidateEventConfig(validationInput)
+	if !validationResult.Valid {
+		return e.errorResponse(c, e.buildEventValidationResponse(validationResult))
+	}
 
-	HPAs, err := e.generalClusterUC.GetAllHPAInCluster(
-		ctx,
-		kubernetesClient,
-		*reqData.ClusterID,
-		clusterData.LatestHPAAPIVersion,
-	)
-	if err != nil {
+	guardRules := e.buildGuardRules(reqData.GuardRules)
+	if err := e.guardUC.ValidateGuardRules(guardRules, HPAConfigs); err != nil {
 		return e.errorResponse(c, err.Error())
 	}
 
+	tx := db.Begin()
+
 	eventData := &UCEntity.Event{
 		Name:              *reqData.Name,
 		ExecuteConfigAt:   *reqData.ExecuteConfigAt,
@@ -104,44 +140,50 @@ func (e *event) RegisterEvents(c *fiber.Ctx) error {
 		StartTime:         *reqData.StartTime,
 		EndTime:           *reqData.EndTime,
 		CalculateNodePool: *reqData.CalculateNodePool,
+		ScaleDown:         e.buildEventScaleDownConfig(reqData.ScaleDown),
+		Watch:             e.buildEventWatchConfig(reqData.Watch),
+	}
+	if reqData.Owner != nil {
+		eventData.Owner = *reqData.Owner
 	}
 	eventData.Cluster.ID = *reqData.ClusterID
 
-	eventID, err := e.eventUC.RegisterEvents(tx, eventData)
+	eventID, err := e.registerEvent(tx, eventData, HPAConfigs, guardRules)
 	if err != nil {
 		return e.errorResponse(c, err.Error())
 	}
 
-	var HPAConfigs []UCEntity.EventModifiedHPAConfigData
-	for _, hpaConfig := range reqData.ModifiedHPAConfigs {
-		found := false
-		for _, HPA := range HPAs {
-			if *hpaConfig.Name == HPA.Name && *hpaConfig.Namespace == HPA.Namespace {
-				found = true
-				break
-			}
-		}
-		if found {
-			HPAConfigs = append(
-				HPAConfigs, UCEntity.EventModifiedHPAConfigData{
-					Name:        *hpaConfig.Name,
-					Namespace:   *hpaConfig.Namespace,
-					MinReplicas: hpaConfig.MinReplicas,
-					MaxReplicas: *hpaConfig.MaxReplicas,
-				},
-			)
-		}
-	}
+	tx.Commit()
 
-	_, err = e.scheduledHPAConfigUC.RegisterModifiedHPAConfigs(tx, HPAConfigs, eventID)
+	eventData.ID = eventID
+	eventData.Status = model.EventPending
+	e.cloudEventUC.PublishEventChange(db, cloudevent.TypeEventCreated, eventData)
+
+	return e.successResponse(c, response.EventCreationResponse{EventID: eventID})
+
+}
+
+// registerEvent stores the event with its hpa configs and guard rules
+func (e *event) registerEvent(
+	tx *gorm.DB,
+	eventData *UCEntity.Event,
+	hpaConfigs []UCEntity.EventModifiedHPAConfigData,
+	guardRules []UCEntity.GuardRuleData,
+) (uuid.UUID, error) {
+	eventID, err := e.eventUC.RegisterEvents(tx, eventData)
 	if err != nil {
-		return e.errorResponse(c, err.Error())
+		return uuid.UUID{}, err
 	}
 
-	tx.Commit()
-
-	return e.successResponse(c, response.EventCreationResponse{EventID: eventID})
+	_, err = e.scheduledHPAConfigUC.RegisterModifiedHPAConfigs(tx, hpaConfigs, eventID)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
 
+	if err := e.guardUC.RegisterGuardRules(tx, guardRules, eventID); err != nil {
+		return uuid.UUID{}, err
+	}
+	return eventID, nil
 }
 
 func (e *event) ListEventByCluster(c *fiber.Ctx) error {
@@ -173,6 +215,7 @@ func (e *event) ListEventByCluster(c *fiber.Ctx) error {
 				StartTime: event.StartTime,
 				EndTime:   event.EndTime,
 				Status:    event.Status,
+				Owner:     event.Owner,
 			},
 		)
 	}
@@ -193,7 +236,6 @@ func (e *event) UpdateEvent(c *fiber.Ctx) error {
 
 	ctx := c.Context()
 	db := e.db.WithContext(ctx)
-	tx := db.Begin()
 
 	eventData, err := e.eventUC.GetEventByID(db, *req.EventID)
 	if err != nil {
@@ -212,25 +254,43 @@ func (e *event) UpdateEvent(c *fiber.Ctx) error {
 	eventData.EndTime = *req.EndTime
 	eventData.ExecuteConfigAt = *req.ExecuteConfigAt
 	eventData.WatchingAt = *req.WatchingAt
+	if req.ScaleDown != nil {
+		eventData.ScaleDown = e.buildEventScaleDownConfig(req.ScaleDown)
+	}
+	if req.Watch != nil {
+		eventData.Watch = e.buildEventWatchConfig(req.Watch)
+	}
 
-	if err := e.eventUC.UpdateEvent(tx, eventData); err != nil {
+	newModifiedHPAConfigs := e.buildModifiedHPAConfigs(req.ModifiedHPAConfigs)
+	validationInput, err := e.collectEventValidationInput(
+		ctx,
+		db,
+		eventData.Cluster.ID,
+		eventData.CalculateNodePool,
+		newModifiedHPAConfigs,
+	)
+	if err != nil {
 		return e.errorResponse(c, err.Error())
 	}
+	validationResult := e.eventValidationUC.ValidateEventConfig(validationInput)
+	if !validationResult.Valid {
+		return e.errorResponse(c, e.buildEventValidationResponse(validationResult))
+	}
 
-	if err := e.scheduledHPAConfigUC.DeleteEventModifiedHPAConfigs(tx, eventData.ID); err != nil {
+	guardRules := e.buildGuardRules(req.GuardRules)
+	if err := e.guardUC.ValidateGuardRules(guardRules, newModifiedHPAConfigs); err != nil {
 		return e.errorResponse(c, err.Error())
 	}
 
-	var newModifiedHPAConfigs []UCEntity.EventModifiedHPAConfigData
-	for _, hpaConfig := range req.ModifiedHPAConfigs {
-		newModifiedHPAConfigs = append(
-			newModifiedHPAConfigs, UCEntity.EventModifiedHPAConfigData{
-				Name:        *hpaConfig.Name,
-				Namespace:   *hpaConfig.Namespace,
-				MinReplicas: hpaConfig.MinReplicas,
-				MaxReplicas: *hpaConfig.MaxReplicas,
-			},
-		)
+	tx := db.Begin()
+
+	transition, err := e.eventUC.UpdateEvent(tx, eventData)
+	if err != nil {
+		return e.errorResponse(c, err.Error())
+	}
+
+	if err := e.scheduledHPAConfigUC.DeleteEventModifiedHPAConfigs(tx, eventData.ID); err != nil {
+		return e.errorResponse(c, err.Error())
 	}
 
 	_, err = e.scheduledHPAConfigUC.RegisterModifiedHPAConfigs(
@@ -242,8 +302,20 @@ func (e *event) UpdateEvent(c *fiber.Ctx) error {
 		return e.errorResponse(c, err.Error())
 	}
 
+	if req.GuardRules != nil {
+		if err := e.guardUC.DeleteEventGuardRules(tx, eventData.ID); err != nil {
+			return e.errorResponse(c, err.Error())
+		}
+		if err := e.guardUC.RegisterGuardRules(tx, guardRules, eventData.ID); err != nil {
+			return e.errorResponse(c, err.Error())
+		}
+	}
+
 	tx.Commit()
 
+	e.eventUC.PublishEventStatusTransition(db, transition)
+	e.cloudEventUC.PublishEventChange(db, cloudevent.TypeEventUpdated, eventData)
+
 	res := &response.EventCreationResponse{EventID: eventData.ID}
 	return e.successResponse(c, res)
 }
@@ -265,6 +337,19 @@ func (e *event) GetDetailedEvent(c *fiber.Ctx) error {
 
 	var modifiedHPAConfigRes []response.ModifiedHPAConfig
 	for _, hpa := range eventData.EventModifiedHPAConfigData {
+		hitMaxIntervals, err := e.statisticUC.GetHPAHitMaxIntervals(db, hpa.ID)
+		if err != nil {
+			return e.errorResponse(c, err.Error())
+		}
+		hitMaxIntervalRes := make([]response.HPAHitMaxInterval, 0)
+		for _, interval := range hitMaxIntervals {
+			hitMaxIntervalRes = append(
+				hitMaxIntervalRes, response.HPAHitMaxInterval{
+					StartTime: interval.StartTime,
+					EndTime:   interval.EndTime,
+				},
+			)
+		}
 		modifiedHPAConfigRes = append(
 			modifiedHPAConfigRes, response.ModifiedHPAConfig{
 				ID:          hpa.ID,
@@ -272,6 +357,13 @@ func (e *event) GetDetailedEvent(c *fiber.Ctx) error {
 				Namespace:   hpa.Namespace,
 				MinReplicas: hpa.MinReplicas,
 				MaxReplicas: hpa.MaxReplicas,
+
+				OriginalMinReplicas:  hpa.OriginalMinReplicas,
+				OriginalMaxReplicas:  hpa.OriginalMaxReplicas,
+				PostEventMinReplicas: hpa.PostEventMinReplicas,
+				PostEventMaxReplicas: hpa.PostEventMaxReplicas,
+
+				HitMaxIntervals: hitMaxIntervalRes,
 			},
 		)
 	}
@@ -288,6 +380,75 @@ func (e *event) GetDetailedEvent(c *fiber.Ctx) error {
 				ID:           updatedNodePool.ID,
 				NodePoolName: updatedNodePool.NodePoolName,
 				MaxNode:      updatedNodePool.MaxNode,
+				Message:      updatedNodePool.Message,
+			},
+		)
+	}
+
+	scaleDownSteps, err := e.scaleDownUC.ListScaleDownStepByEventID(db, eventID)
+	if err != nil {
+		return e.errorResponse(c, err.Error())
+	}
+
+	scaleDownStepRes := make([]response.ScaleDownStep, 0)
+	for _, step := range scaleDownSteps {
+		scaleDownStepRes = append(
+			scaleDownStepRes, response.ScaleDownStep{
+				ID:                   step.ID,
+				ScheduledHPAConfigID: step.ScheduledHPAConfigID,
+				StepNumber:           step.StepNumber,
+				ScheduledAt:          step.ScheduledAt,
+				ExecutedAt:           step.ExecutedAt,
+				MinReplicas:          step.MinReplicas,
+				MaxReplicas:          step.MaxReplicas,
+				Status:               step.Status,
+				Message:              step.Message,
+			},
+		)
+	}
+
+	guardRules, err := e.guardUC.ListGuardRuleByEventID(db, eventID)
+	if err != nil {
+		return e.errorResponse(c, err.Error())
+	}
+
+	guardRuleRes := make([]response.GuardRule, 0)
+	for _, rule := range guardRules {
+		guardRuleRes = append(
+			guardRuleRes, response.GuardRule{
+				ID:              rule.ID,
+				HPAName:         rule.HPAName,
+				HPANamespace:    rule.HPANamespace,
+				Condition:       rule.Condition,
+				Threshold:       rule.Threshold,
+				DurationSeconds: rule.DurationSeconds,
+				Action:          rule.Action,
+				ActionValue:     rule.ActionValue,
+				NodePoolName:    rule.NodePoolName,
+				CooldownSeconds: rule.CooldownSeconds,
+			},
+		)
+	}
+
+	guardActions, err := e.guardUC.ListGuardActionByEventID(db, eventID)
+	if err != nil {
+		return e.errorResponse(c, err.Error())
+	}
+
+	guardActionRes := make([]response.GuardAction, 0)
+	for _, action := range guardActions {
+		guardActionRes = append(
+			guardActionRes, response.GuardAction{
+				ID:            action.ID,
+				CreatedAt:     action.CreatedAt,
+				GuardRuleID:   action.GuardRuleID,
+				Action:        action.Action,
+				Status:        action.Status,
+				Target:        action.Target,
+				PreviousValue: action.PreviousValue,
+				NewValue:      action.NewValue,
+				Message:       action.Message,
+				BreachedSince: action.BreachedSince,
 			},
 		)
 	}
@@ -299,6 +460,7 @@ func (e *event) GetDetailedEvent(c *fiber.Ctx) error {
 			StartTime: eventData.StartTime,
 			EndTime:   eventData.EndTime,
 			Status:    eventData.Status,
+			Owner:     eventData.Owner,
 		},
 		CreatedAt: eventData.CreatedAt,
 		UpdatedAt: eventData.UpdatedAt,
@@ -313,6 +475,18 @@ func (e *event) GetDetailedEvent(c *fiber.Ctx) error {
 		CalculateNodePool:  eventData.CalculateNodePool,
 		ExecuteConfigAt:    eventData.ExecuteConfigAt,
 		WatchingAt:         eventData.WatchingAt,
+		ScaleDown: response.EventScaleDownConfig{
+			WindowMinutes: eventData.ScaleDown.WindowMinutes,
+			Steps:         eventData.ScaleDown.Steps,
+			Gate:          eventData.ScaleDown.Gate,
+		},
+		Watch: response.EventWatchConfig{
+			IntervalSeconds:    eventData.Watch.IntervalSeconds,
+			MaxIntervalSeconds: eventData.Watch.MaxIntervalSeconds,
+		},
+		ScaleDownSteps: scaleDownStepRes,
+		GuardRules:     guardRuleRes,
+		GuardActions:   guardActionRes,
 	}
 
 	return e.successResponse(c, res)
@@ -329,7 +503,7 @@ func (e *event) DeleteEvent(c *fiber.Ctx) error {
 	db := e.db.WithContext(ctx)
 	tx := db.Begin()
 
-	_, err = e.eventUC.GetEventByID(db, eventID)
+	eventData, err := e.eventUC.GetEventByID(db, eventID)
 	if err != nil {
 		return e.errorResponse(c, errorConstant.EventNotExist)
 	}
@@ -346,6 +520,8 @@ func (e *event) DeleteEvent(c *fiber.Ctx) error {
 
 	tx.Commit()
 
+	e.cloudEventUC.PublishEventChange(db, cloudevent.TypeEventDeleted, eventData)
+
 	return e.successResponse(c, constant.ActionDone)
 }
 
@@ -369,12 +545,7 @@ func (e *event) ListNodePoolStatusByUpdatedNodePool(c *fiber.Ctx) error {
 
 	var resp []response.NodePoolStatus
 	for _, nodePoolStatus := range nodePoolStatuses {
-		resp = append(
-			resp, response.NodePoolStatus{
-				CreatedAt: nodePoolStatus.CreatedAt,
-				Count:     nodePoolStatus.Count,
-			},
-		)
+		resp = append(resp, e.buildNodePoolStatusResponse(nodePoolStatus))
 	}
 
 	return e.successResponse(c, resp)
@@ -402,17 +573,358 @@ func (e *event) ListHPAStatusByScheduledHPAConfig(c *fiber.Ctx) error {
 	}
 
 	var resp []response.HPAStatus
-	for _, nodePoolStatus := range hpaStatuses {
+	for _, hpaStatus := range hpaStatuses {
+		resp = append(resp, e.buildHPAStatusResponse(hpaStatus))
+	}
+
+	return e.successResponse(c, resp)
+}
+
+func (e *event) ListPodStatusByScheduledHPAConfig(c *fiber.Ctx) error {
+	scheduledHPAConfigIDStr := c.Params("scheduled_hpa_config_id")
+	scheduledHPAConfigID, err := uuid.Parse(scheduledHPAConfigIDStr)
+	if err != nil {
+		return e.errorResponse(
+			c,
+			fmt.Sprintf(errorConstant.ParamInvalid, "scheduled_hpa_config_id"),
+		)
+	}
+
+	ctx := c.Context()
+	db := e.db.WithContext(ctx)
+
+	podStatuses, err := e.statisticUC.GetAllPodStatusByScheduledHPAConfigID(
+		db,
+		scheduledHPAConfigID,
+	)
+	if err != nil {
+		return e.errorResponse(c, err.Error())
+	}
+
+	var resp []response.PodStatus
+	for _, podStatus := range podStatuses {
 		resp = append(
-			resp, response.HPAStatus{
-				CreatedAt:           nodePoolStatus.CreatedAt,
-				Replicas:            nodePoolStatus.Replicas,
-				ReadyReplicas:       nodePoolStatus.ReadyReplicas,
-				UnavailableReplicas: nodePoolStatus.UnavailableReplicas,
-				AvailableReplicas:   nodePoolStatus.AvailableReplicas,
+			resp, response.PodStatus{
+				CreatedAt:                podStatus.CreatedAt,
+				PendingPods:              podStatus.PendingPods,
+				UnschedulablePods:        podStatus.UnschedulablePods,
+				FailedSchedulingEvents:   podStatus.FailedSchedulingEvents,
+				RestartCount:             podStatus.RestartCount,
+				OOMKilledContainers:      podStatus.OOMKilledContainers,
+				StartedPods:              podStatus.StartedPods,
+				AvgStartupLatencySeconds: podStatus.AvgStartupLatencySeconds,
+				MaxStartupLatencySeconds: podStatus.MaxStartupLatencySeconds,
 			},
 		)
 	}
 
 	return e.successResponse(c, resp)
 }
+
+func (e *event) buildEventScaleDownConfig(
+	req *request.EventScaleDownConfig,
+) UCEntity.EventScaleDownConfig {
+	config := UCEntity.EventScaleDownConfig{Gate: model.ScaleDownGateNone}
+	if req == nil {
+		return config
+	}
+	config.WindowMinutes = *req.WindowMinutes
+	config.Steps = *req.Steps
+	if req.Gate != nil {
+		config.Gate = model.ScaleDownGate(*req.Gate)
+	}
+	return config
+}
+
+func (e *event) buildEventWatchConfig(req *request.EventWatchConfig) UCEntity.EventWatchConfig {
+	config := UCEntity.EventWatchConfig{}
+	if req == nil {
+		return config
+	}
+	config.IntervalSeconds = *req.IntervalSeconds
+	if req.MaxIntervalSeconds != nil {
+		config.MaxIntervalSeconds = *req.MaxIntervalSeconds
+	}
+	return config
+}
+
+func (e *event) ValidateEvent(c *fiber.Ctx) error {
+	reqData := &request.EventValidationRequest{}
+	if err := c.BodyParser(reqData); err != nil {
+		return e.errorResponse(c, err.Error())
+	}
+	if err := e.validatorInst.Struct(reqData); err != nil {
+		return e.errorResponse(c, errorConstant.InvalidRequestBody)
+	}
+
+	calculateNodePool := true
+	if reqData.CalculateNodePool != nil {
+		calculateNodePool = *reqData.CalculateNodePool
+	}
+
+	ctx := c.Context()
+	db := e.db.WithContext(ctx)
+
+	validationInput, err := e.collectEventValidationInput(
+		ctx,
+		db,
+		*reqData.ClusterID,
+		calculateNodePool,
+		e.buildModifiedHPAConfigs(reqData.ModifiedHPAConfigs),
+	)
+	if err != nil {
+		return e.errorResponse(c, err.Error())
+	}
+
+	validationResult := e.eventValidationUC.ValidateEventConfig(validationInput)
+	return e.successResponse(c, e.buildEventValidationResponse(validationResult))
+}
+
+func (e *event) collectEventValidationInput(
+	ctx context.Context,
+	db *gorm.DB,
+	clusterID uuid.UUID,
+	calculateNodePool bool,
+	hpaConfigs []UCEntity.EventModifiedHPAConfigData,
+) (*UCEntity.EventValidationInput, error) {
+	kubernetesClient, clusterData, err := e.getClusterKubernetesClient(ctx, db, clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	HPAs, err := e.generalClusterUC.GetAllHPAInCluster(
+		ctx,
+		kubernetesClient,
+		clusterID,
+		clusterData.LatestHPAAPIVersion,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	deploymentsData, err := e.generalClusterUC.GetAllDeployments(ctx, kubernetesClient, "")
+	if err != nil {
+		return nil, err
+	}
+
+	input := &UCEntity.EventValidationInput{
+		CalculateNodePool:  calculateNodePool,
+		ModifiedHPAConfigs: hpaConfigs,
+		ExistingHPAs:       HPAs,
+		Deployments:        deploymentsData.DeploymentListObject.Items,
+		NodePoolNodeCounts: map[string]int{},
+		NodePoolNodes:      map[string]UCEntity.NodePoolNodeData{},
+	}
+
+	if !calculateNodePool {
+		return input, nil
+	}
+
+	switch clusterData.Datacenter.Datacenter {
+	case model.GCP:
+		nodePools, err := e.getGCPClusterNodePools(ctx, clusterData)
+		if err != nil {
+			return nil, err
+		}
+		input.NodePools = nodePools
+		for _, nodePool := range nodePools {
+			nodeData, err := e.gcpClusterUC.GetNodesFromGCPNodePool(
+				ctx,
+				kubernetesClient,
+				nodePool.Name,
+			)
+			if err != nil {
+				if err.Error() == errorConstant.NoExistingNode {
+					nodeLabels, nodeTaints := util.GKENodePoolConfigNode(nodePool)
+					input.NodePoolNodes[nodePool.Name] = UCEntity.NodePoolNodeData{
+						Labels: nodeLabels,
+						Taints: nodeTaints,
+					}
+					continue
+				}
+				return nil, err
+			}
+			nodes := nodeData.NodeListObject.Items
+			input.NodePoolNodeCounts[nodePool.Name] = len(nodes)
+			input.NodePoolNodes[nodePool.Name] = UCEntity.NodePoolNodeData{
+				Labels: nodes[0].Labels,
+				Taints: util.FilterNodePoolTaints(nodes[0].Spec.Taints),
+			}
+		}
+	}
+
+	return input, nil
+}
+
+func (e *event) buildModifiedHPAConfigs(
+	reqs []request.EventModifiedHPAConfigData,
+) []UCEntity.EventModifiedHPAConfigData {
+	var HPAConfigs []UCEntity.EventModifiedHPAConfigData
+	for _, hpaConfig := range reqs {
+		HPAConfigs = append(
+			HPAConfigs, UCEntity.EventModifiedHPAConfigData{
+				Name:        *hpaConfig.Name,
+				Namespace:   *hpaConfig.Namespace,
+				MinReplicas: hpaConfig.MinReplicas,
+				MaxReplicas: *hpaConfig.MaxReplicas,
+
+				PostEventMinReplicas: hpaConfig.PostEventMinReplicas,
+				PostEventMaxReplicas: hpaConfig.PostEventMaxReplicas,
+			},
+		)
+	}
+	return HPAConfigs
+}
+
+func (e *event) buildGuardRules(reqs []request.EventGuardRuleData) []UCEntity.GuardRuleData {
+	var rules []UCEntity.GuardRuleData
+	for _, req := range reqs {
+		rule := UCEntity.GuardRuleData{
+			Condition:       model.GuardCondition(*req.Condition),
+			DurationSeconds: *req.DurationSeconds,
+			Action:          model.GuardActionType(*req.Action),
+		}
+		if req.HPAName != nil && req.HPANamespace != nil {
+			rule.HPAName = *req.HPAName
+			rule.HPANamespace = *req.HPANamespace
+		}
+		if req.Threshold != nil {
+			rule.Threshold = *req.Threshold
+		}
+		if req.ActionValue != nil {
+			rule.ActionValue = *req.ActionValue
+		}
+		if req.NodePoolName != nil {
+			rule.NodePoolName = *req.NodePoolName
+		}
+		if req.CooldownSeconds != nil {
+			rule.CooldownSeconds = *req.CooldownSeconds
+		}
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+func (e *event) buildEventValidationResponse(
+	result *UCEntity.EventValidationResult,
+) *response.EventValidationResponse {
+	buildIssues := func(issues []UCEntity.ValidationIssue) []response.ValidationIssue {
+		res := make([]response.ValidationIssue, 0)
+		for _, issue := range issues {
+			res = append(
+				res, response.ValidationIssue{
+					Level:   issue.Level,
+					Code:    issue.Code,
+					Message: issue.Message,
+				},
+			)
+		}
+		return res
+	}
+
+	res := &response.EventValidationResponse{
+		Valid:     result.Valid,
+		HPAs:      make([]response.HPAValidation, 0),
+		NodePools: make([]response.NodePoolValidation, 0),
+	}
+	for _, hpa := range result.HPAs {
+		res.HPAs = append(
+			res.HPAs, response.HPAValidation{
+				Name:      hpa.Name,
+				Namespace: hpa.Namespace,
+				Issues:    buildIssues(hpa.Issues),
+			},
+		)
+	}
+	for _, nodePool := range result.NodePools {
+		res.NodePools = append(
+			res.NodePools, response.NodePoolValidation{
+				Name:   nodePool.Name,
+				Issues: buildIssues(nodePool.Issues),
+			},
+		)
+	}
+	return res
+}
+
+func (e *event) GetEventReport(c *fiber.Ctx) error {
+	eventIDStr := c.Params("event_id")
+	eventID, err := uuid.Parse(eventIDStr)
+	if err != nil {
+		return e.errorResponse(c, fmt.Sprintf(errorConstant.ParamInvalid, "event_id"))
+	}
+	format := c.Query("format", eventReportFormatJSON)
+
+	ctx := c.Context()
+	db := e.db.WithContext(ctx)
+
+	report, err := e.eventReportUC.GetEventReport(db, eventID)
+	if err != nil {
+		return e.errorResponse(c, err.Error())
+	}
+	reportRes := buildEventReportResponse(report)
+
+	switch format {
+	case eventReportFormatJSON:
+		return e.successResponse(c, reportRes)
+	case eventReportFormatMarkdown:
+		output, err := renderEventReportMarkdown(reportRes)
+		if err != nil {
+			return e.errorResponse(c, err.Error())
+		}
+		c.Set(fiber.HeaderContentType, "text/markdown; charset=utf-8")
+		return c.SendString(output)
+	case eventReportFormatHTML:
+		output, err := renderEventReportHTML(reportRes)
+		if err != nil {
+			return e.errorResponse(c, err.Error())
+		}
+		c.Set(fiber.HeaderContentType, fiber.MIMETextHTMLCharsetUTF8)
+		return c.SendString(output)
+	default:
+		return e.errorResponse(c, fmt.Sprintf(errorConstant.ParamInvalid, "format"))
+	}
+}
+
+func (e *event) buildNodePoolStatusResponse(
+	nodePoolStatus *UCEntity.NodePoolStatusData,
+) response.NodePoolStatus {
+	return response.NodePoolStatus{
+		CreatedAt:              nodePoolStatus.CreatedAt,
+		Count:                  nodePoolStatus.Count,
+		AllocatableCPUMillis:   nodePoolStatus.AllocatableCPU,
+		AllocatableMemoryBytes: nodePoolStatus.AllocatableMemory,
+		RequestedCPUMillis:     nodePoolStatus.RequestedCPU,
+		RequestedMemoryBytes:   nodePoolStatus.RequestedMemory,
+		UsageCPUMillis:         nodePoolStatus.UsageCPU,
+		UsageMemoryBytes:       nodePoolStatus.UsageMemory,
+	}
+}
+
+func (e *event) buildHPAStatusResponse(hpaStatus *UCEntity.HPAStatusData) response.HPAStatus {
+	metrics := make([]response.HPAMetricValue, 0)
+	for _, metric := range hpaStatus.Metrics {
+		metrics = append(
+			metrics, response.HPAMetricValue{
+				Name:    metric.Name,
+				Current: metric.Current,
+				Target:  metric.Target,
+			},
+		)
+	}
+	return response.HPAStatus{
+		CreatedAt:           hpaStatus.CreatedAt,
+		Replicas:            hpaStatus.Replicas,
+		ReadyReplicas:       hpaStatus.ReadyReplicas,
+		UnavailableReplicas: hpaStatus.UnavailableReplicas,
+		AvailableReplicas:   hpaStatus.AvailableReplicas,
+
+		HPAMinReplicas:       hpaStatus.MinReplicas,
+		HPAMaxReplicas:       hpaStatus.MaxReplicas,
+		HPACurrentReplicas:   hpaStatus.CurrentReplicas,
+		HPADesiredReplicas:   hpaStatus.DesiredReplicas,
+		ScalingLimited:       hpaStatus.ScalingLimited,
+		ScalingLimitedReason: hpaStatus.ScalingLimitedReason,
+		Metrics:              metrics,
+	}
+}
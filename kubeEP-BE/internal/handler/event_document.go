@@ -0,0 +1,353 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	errorConstant "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant/errors"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/request"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/response"
+	UCEntity "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/usecase"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/pkg/cloudevent"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository/model"
+	"gorm.io/gorm"
+	"sigs.k8s.io/yaml"
+	"time"
+)
+
+const (
+	eventDocumentVersion    = "v1"
+	eventDocumentFormatYAML = "yaml"
+	eventDocumentFormatJSON = "json"
+)
+
+// importedEvent is an event of the imported document ready to be registered
+type importedEvent struct {
+	event      *UCEntity.Event
+	hpaConfigs []UCEntity.EventModifiedHPAConfigData
+	guardRules []UCEntity.GuardRuleData
+}
+
+func (e *event) ExportEvent(c *fiber.Ctx) error {
+	eventIDStr := c.Params("event_id")
+	eventID, err := uuid.Parse(eventIDStr)
+	if err != nil {
+		return e.errorResponse(c, fmt.Sprintf(errorConstant.ParamInvalid, "event_id"))
+	}
+
+	ctx := c.Context()
+	db := e.db.WithContext(ctx)
+
+	eventDocumentEvent, err := e.buildEventDocumentEvent(db, eventID)
+	if err != nil {
+		return e.errorResponse(c, err.Error())
+	}
+
+	return e.sendEventDocument(
+		c,
+		eventDocumentEvent.Name,
+		&response.EventDocument{
+			Version: eventDocumentVersion,
+			Events:  []response.EventDocumentEvent{*eventDocumentEvent},
+		},
+	)
+}
+
+func (e *event) ExportClusterEvents(c *fiber.Ctx) error {
+	req := &request.EventExportRequest{}
+	if err := c.QueryParser(req); err != nil {
+		return e.errorResponse(c, errorConstant.InvalidQueryParam)
+	}
+	if err := e.validatorInst.Struct(req); err != nil {
+		return e.errorResponse(c, errorConstant.InvalidQueryParam)
+	}
+
+	ctx := c.Context()
+	db := e.db.WithContext(ctx)
+
+	clusterData, err := e.generalClusterUC.GetClusterAndDatacenterDataByClusterID(db, *req.ClusterID)
+	if err != nil {
+		return e.errorResponse(c, fmt.Sprintf(errorConstant.ClusterNotFound, req.ClusterID.String()))
+	}
+	events, err := e.eventUC.ListEventByClusterID(db, *req.ClusterID)
+	if err != nil {
+		return e.errorResponse(c, err.Error())
+	}
+
+	document := &response.EventDocument{
+		Version: eventDocumentVersion,
+		Events:  make([]response.EventDocumentEvent, 0),
+	}
+	for _, eventData := range events {
+		eventDocumentEvent, err := e.buildEventDocumentEvent(db, eventData.ID)
+		if err != nil {
+			return e.errorResponse(c, err.Error())
+		}
+		document.Events = append(document.Events, *eventDocumentEvent)
+	}
+
+	return e.sendEventDocument(c, clusterData.Name, document)
+}
+
+// ImportEvents registers every event of the document or none of them, with dry_run the events are
+// only validated
+func (e *event) ImportEvents(c *fiber.Ctx) error {
+	dryRun := c.Query("dry_run") == "true"
+
+	// JSON is valid YAML, both formats are read the same way
+	body, err := yaml.YAMLToJSON(c.Body())
+	if err != nil {
+		return e.errorResponse(c, errorConstant.InvalidRequestBody)
+	}
+	document := &request.EventDocument{}
+	if err := json.Unmarshal(body, document); err != nil {
+		return e.errorResponse(c, errorConstant.InvalidRequestBody)
+	}
+	if err := e.validatorInst.Struct(document); err != nil {
+		return e.errorResponse(c, errorConstant.InvalidRequestBody)
+	}
+
+	ctx := c.Context()
+	db := e.db.WithContext(ctx)
+
+	res := &response.EventImportResponse{
+		DryRun: dryRun,
+		Valid:  true,
+		Events: make([]response.EventImportResult, 0),
+	}
+	var importedEvents []*importedEvent
+	eventNames := map[string]bool{}
+	for i := range document.Events {
+		result := response.EventImportResult{
+			Name:    *document.Events[i].Name,
+			Cluster: *document.Events[i].Cluster.Name,
+			Errors:  make([]string, 0),
+		}
+		if eventNames[result.Name] {
+			result.Errors = append(result.Errors, errorConstant.EventExist)
+		}
+		eventNames[result.Name] = true
+
+		imported := e.validateEventDocumentEvent(ctx, db, &document.Events[i], &result)
+		result.Valid = len(result.Errors) == 0 &&
+			(result.Validation == nil || result.Validation.Valid)
+		if !result.Valid {
+			res.Valid = false
+		}
+		res.Events = append(res.Events, result)
+		importedEvents = append(importedEvents, imported)
+	}
+
+	if !res.Valid {
+		return e.errorResponse(c, res)
+	}
+	if dryRun {
+		return e.successResponse(c, res)
+	}
+
+	tx := db.Begin()
+	for i, imported := range importedEvents {
+		eventID, err := e.registerEvent(tx, imported.event, imported.hpaConfigs, imported.guardRules)
+		if err != nil {
+			tx.Rollback()
+			return e.errorResponse(c, err.Error())
+		}
+		imported.event.ID = eventID
+		res.Events[i].EventID = &eventID
+	}
+	tx.Commit()
+
+	for _, imported := range importedEvents {
+		imported.event.Status = model.EventPending
+		e.cloudEventUC.PublishEventChange(db, cloudevent.TypeEventCreated, imported.event)
+	}
+
+	return e.successResponse(c, res)
+}
+
+// validateEventDocumentEvent runs the checks of the event registration and collects the failures
+// in the result
+func (e *event) validateEventDocumentEvent(
+	ctx context.Context,
+	db *gorm.DB,
+	req *request.EventDocumentEvent,
+	result *response.EventImportResult,
+) *importedEvent {
+	var datacenterName string
+	if req.Cluster.Datacenter != nil {
+		datacenterName = *req.Cluster.Datacenter
+	}
+	clusterData, err := e.generalClusterUC.GetClusterByName(db, *req.Cluster.Name, datacenterName)
+	if err != nil {
+		result.Errors = append(result.Errors, err.Error())
+		return nil
+	}
+
+	calculateNodePool := true
+	if req.CalculateNodePool != nil {
+		calculateNodePool = *req.CalculateNodePool
+	}
+
+	utcNow := time.Now().UTC()
+	if utcNow.After(*req.StartTime) || utcNow.After(*req.EndTime) {
+		result.Errors = append(result.Errors, errorConstant.EventTimePassed)
+	}
+	if _, err := e.eventUC.GetEventByName(db, *req.Name); err == nil {
+		result.Errors = append(result.Errors, errorConstant.EventExist)
+	}
+
+	hpaConfigs := e.buildModifiedHPAConfigs(req.ModifiedHPAConfigs)
+	guardRules := e.buildGuardRules(req.GuardRules)
+	if err := e.guardUC.ValidateGuardRules(guardRules, hpaConfigs); err != nil {
+		result.Errors = append(result.Errors, err.Error())
+	}
+
+	validationInput, err := e.collectEventValidationInput(
+		ctx,
+		db,
+		clusterData.ID,
+		calculateNodePool,
+		hpaConfigs,
+	)
+	if err != nil {
+		result.Errors = append(result.Errors, err.Error())
+		return nil
+	}
+	result.Validation = e.buildEventValidationResponse(
+		e.eventValidationUC.ValidateEventConfig(validationInput),
+	)
+
+	eventData := &UCEntity.Event{
+		Name:              *req.Name,
+		ExecuteConfigAt:   *req.ExecuteConfigAt,
+		WatchingAt:        *req.WatchingAt,
+		StartTime:         *req.StartTime,
+		EndTime:           *req.EndTime,
+		CalculateNodePool: calculateNodePool,
+		ScaleDown:         e.buildEventScaleDownConfig(req.ScaleDown),
+		Watch:             e.buildEventWatchConfig(req.Watch),
+		Cluster:           *clusterData,
+	}
+	return &importedEvent{
+		event:      eventData,
+		hpaConfigs: hpaConfigs,
+		guardRules: guardRules,
+	}
+}
+
+func (e *event) buildEventDocumentEvent(
+	db *gorm.DB,
+	eventID uuid.UUID,
+) (*response.EventDocumentEvent, error) {
+	eventData, err := e.eventUC.GetDetailedEventData(db, eventID)
+	if err != nil {
+		return nil, errors.New(errorConstant.EventNotExist)
+	}
+
+	output := &response.EventDocumentEvent{
+		Name: eventData.Name,
+		Cluster: response.EventDocumentCluster{
+			Name:       eventData.Cluster.Name,
+			Datacenter: eventData.Cluster.Datacenter.Name,
+		},
+		StartTime:          eventData.StartTime,
+		EndTime:            eventData.EndTime,
+		CalculateNodePool:  eventData.CalculateNodePool,
+		ExecuteConfigAt:    eventData.ExecuteConfigAt,
+		WatchingAt:         eventData.WatchingAt,
+		ModifiedHPAConfigs: make([]response.EventDocumentHPAConfig, 0),
+		ScaleDown: &response.EventScaleDownConfig{
+			WindowMinutes: eventData.ScaleDown.WindowMinutes,
+			Steps:         eventData.ScaleDown.Steps,
+			Gate:          eventData.ScaleDown.Gate,
+		},
+	}
+	// Events without their own watch config follow the cron defaults
+	if eventData.Watch.IntervalSeconds > 0 {
+		output.Watch = &response.EventWatchConfig{
+			IntervalSeconds:    eventData.Watch.IntervalSeconds,
+			MaxIntervalSeconds: eventData.Watch.MaxIntervalSeconds,
+		}
+	}
+	for _, hpa := range eventData.EventModifiedHPAConfigData {
+		output.ModifiedHPAConfigs = append(
+			output.ModifiedHPAConfigs, response.EventDocumentHPAConfig{
+				Name:                 hpa.Name,
+				Namespace:            hpa.Namespace,
+				MinReplicas:          hpa.MinReplicas,
+				MaxReplicas:          hpa.MaxReplicas,
+				PostEventMinReplicas: hpa.PostEventMinReplicas,
+				PostEventMaxReplicas: hpa.PostEventMaxReplicas,
+			},
+		)
+	}
+
+	guardRules, err := e.guardUC.ListGuardRuleByEventID(db, eventID)
+	if err != nil {
+		return nil, err
+	}
+	for _, rule := range guardRules {
+		output.GuardRules = append(
+			output.GuardRules, response.EventDocumentGuardRule{
+				HPAName:         rule.HPAName,
+				HPANamespace:    rule.HPANamespace,
+				Condition:       rule.Condition,
+				Threshold:       rule.Threshold,
+				DurationSeconds: rule.DurationSeconds,
+				Action:          rule.Action,
+				ActionValue:     rule.ActionValue,
+				NodePoolName:    rule.NodePoolName,
+				CooldownSeconds: rule.CooldownSeconds,
+			},
+		)
+	}
+
+	updatedNodePools, err := e.statisticUC.GetAllUpdatedNodePoolByEvent(db, eventID)
+	if err != nil {
+		return nil, err
+	}
+	for _, updatedNodePool := range updatedNodePools {
+		output.UpdatedNodePools = append(
+			output.UpdatedNodePools, response.EventDocumentNodePool{
+				NodePoolName: updatedNodePool.NodePoolName,
+				MaxNode:      updatedNodePool.MaxNode,
+			},
+		)
+	}
+
+	return output, nil
+}
+
+// sendEventDocument writes the raw document so it can be imported again as is
+func (e *event) sendEventDocument(
+	c *fiber.Ctx,
+	fileName string,
+	document *response.EventDocument,
+) error {
+	data, err := json.Marshal(document)
+	if err != nil {
+		return e.errorResponse(c, err.Error())
+	}
+
+	format := c.Query("format", eventDocumentFormatYAML)
+	switch format {
+	case eventDocumentFormatJSON:
+		c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	case eventDocumentFormatYAML:
+		data, err = yaml.JSONToYAML(data)
+		if err != nil {
+			return e.errorResponse(c, err.Error())
+		}
+		c.Set(fiber.HeaderContentType, "application/yaml")
+	default:
+		return e.errorResponse(c, fmt.Sprintf(errorConstant.ParamInvalid, "format"))
+	}
+	c.Set(
+		fiber.HeaderContentDisposition,
+		fmt.Sprintf(`attachment; filename="%s.%s"`, fileName, format),
+	)
+	return c.Send(data)
+}
@@ -0,0 +1,151 @@
+package handler
+
+import (
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	errorConstant "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant/errors"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/request"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/response"
+	UCEntity "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/usecase"
+	"strings"
+	"time"
+)
+
+func (e *event) GetEventRecommendation(c *fiber.Ctx) error {
+	reqData := &request.EventRecommendationRequest{}
+	err := c.QueryParser(reqData)
+	if err != nil {
+		return e.errorResponse(c, err.Error())
+	}
+
+	err = e.validatorInst.Struct(reqData)
+	if err != nil {
+		return e.errorResponse(c, errorConstant.InvalidQueryParam)
+	}
+
+	var hpas []UCEntity.SimpleHPAData
+	for _, hpa := range reqData.HPAs {
+		parts := strings.SplitN(hpa, "/", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return e.errorResponse(c, fmt.Sprintf(errorConstant.ParamInvalid, "hpa"))
+		}
+		hpas = append(hpas, UCEntity.SimpleHPAData{Namespace: parts[0], Name: parts[1]})
+	}
+
+	var startTime, endTime *time.Time
+	if reqData.StartTime != nil {
+		parsed, err := time.Parse(time.RFC3339, *reqData.StartTime)
+		if err != nil {
+			return e.errorResponse(c, fmt.Sprintf(errorConstant.ParamInvalid, "start_time"))
+		}
+		startTime = &parsed
+	}
+	if reqData.EndTime != nil {
+		parsed, err := time.Parse(time.RFC3339, *reqData.EndTime)
+		if err != nil {
+			return e.errorResponse(c, fmt.Sprintf(errorConstant.ParamInvalid, "end_time"))
+		}
+		endTime = &parsed
+	}
+
+	ctx := c.Context()
+	db := e.db.WithContext(ctx)
+
+	recommendation, err := e.eventRecommendationUC.RecommendEventConfig(db, *reqData.ClusterID, hpas)
+	if err != nil {
+		return e.errorResponse(c, err.Error())
+	}
+
+	return e.successResponse(
+		c,
+		buildEventRecommendationResponse(reqData, recommendation, startTime, endTime),
+	)
+}
+
+// buildEventRecommendationResponse prefills the event request with the recommended hpa config and
+// the config of the latest event, the schedule keeps the offsets of the latest event when the start
+// time is given
+func buildEventRecommendationResponse(
+	reqData *request.EventRecommendationRequest,
+	recommendation *UCEntity.EventRecommendationData,
+	startTime, endTime *time.Time,
+) response.EventRecommendation {
+	latestEvent := recommendation.LatestEvent
+	clusterID := *reqData.ClusterID
+	calculateNodePool := latestEvent.CalculateNodePool
+	output := response.EventRecommendation{
+		Event: request.EventDataRequest{
+			ClusterID:          &clusterID,
+			CalculateNodePool:  &calculateNodePool,
+			ModifiedHPAConfigs: make([]request.EventModifiedHPAConfigData, 0),
+			ScaleDown: &request.EventScaleDownConfig{
+				WindowMinutes: &latestEvent.ScaleDown.WindowMinutes,
+				Steps:         &latestEvent.ScaleDown.Steps,
+			},
+		},
+		BasedOnEventIDs: recommendation.BasedOnEvents,
+		HPAs:            make([]response.HPARecommendation, 0),
+		NodePools:       make([]response.NodePoolRecommendation, 0),
+	}
+	gate := string(latestEvent.ScaleDown.Gate)
+	if gate != "" {
+		output.Event.ScaleDown.Gate = &gate
+	}
+	if latestEvent.Watch.IntervalSeconds > 0 {
+		output.Event.Watch = &request.EventWatchConfig{
+			IntervalSeconds:    &latestEvent.Watch.IntervalSeconds,
+			MaxIntervalSeconds: &latestEvent.Watch.MaxIntervalSeconds,
+		}
+	}
+
+	if startTime != nil {
+		if endTime == nil {
+			latestEndTime := startTime.Add(latestEvent.EndTime.Sub(latestEvent.StartTime))
+			endTime = &latestEndTime
+		}
+		executeConfigAt := startTime.Add(-latestEvent.StartTime.Sub(latestEvent.ExecuteConfigAt))
+		watchingAt := startTime.Add(-latestEvent.StartTime.Sub(latestEvent.WatchingAt))
+		output.Event.StartTime = startTime
+		output.Event.EndTime = endTime
+		output.Event.ExecuteConfigAt = &executeConfigAt
+		output.Event.WatchingAt = &watchingAt
+	}
+
+	for _, hpa := range recommendation.HPAs {
+		hpa := hpa
+		output.Event.ModifiedHPAConfigs = append(
+			output.Event.ModifiedHPAConfigs, request.EventModifiedHPAConfigData{
+				Name:                 &hpa.Name,
+				Namespace:            &hpa.Namespace,
+				MinReplicas:          &hpa.MinReplicas,
+				MaxReplicas:          &hpa.MaxReplicas,
+				PostEventMinReplicas: hpa.PostEventMinReplicas,
+				PostEventMaxReplicas: hpa.PostEventMaxReplicas,
+			},
+		)
+		output.HPAs = append(
+			output.HPAs, response.HPARecommendation{
+				Name:         hpa.Name,
+				Namespace:    hpa.Namespace,
+				MinReplicas:  hpa.MinReplicas,
+				MaxReplicas:  hpa.MaxReplicas,
+				SampleCount:  hpa.SampleCount,
+				P50Replicas:  hpa.P50Replicas,
+				P95Replicas:  hpa.P95Replicas,
+				PeakReplicas: hpa.PeakReplicas,
+			},
+		)
+	}
+	for _, nodePool := range recommendation.NodePools {
+		output.NodePools = append(
+			output.NodePools, response.NodePoolRecommendation{
+				Name:              nodePool.NodePoolName,
+				ExpectedNodeCount: nodePool.ExpectedNodeCount,
+				SampleCount:       nodePool.SampleCount,
+				P95NodeCount:      nodePool.P95NodeCount,
+				PeakNodeCount:     nodePool.PeakNodeCount,
+			},
+		)
+	}
+	return output
+}
@@ -0,0 +1,208 @@
+package handler
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/response"
+	UCEntity "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/usecase"
+	htmlTemplate "html/template"
+	"text/template"
+	"time"
+)
+
+const (
+	eventReportFormatJSON     = "json"
+	eventReportFormatMarkdown = "markdown"
+	eventReportFormatHTML     = "html"
+)
+
+var eventReportTemplateFuncs = map[string]interface{}{
+	"formatTime": func(t *time.Time) string {
+		if t == nil {
+			return "-"
+		}
+		return t.UTC().Format(time.RFC3339)
+	},
+	"formatSeconds": func(seconds *float64) string {
+		if seconds == nil {
+			return "not reached"
+		}
+		return (time.Duration(*seconds) * time.Second).String()
+	},
+	"formatReplicas": func(replicas *int32) string {
+		if replicas == nil {
+			return "-"
+		}
+		return fmt.Sprintf("%d", *replicas)
+	},
+	"timePtr": func(t time.Time) *time.Time {
+		return &t
+	},
+}
+
+const eventReportMarkdownTemplate = `# Event report : {{ .EventName }}
+
+- Start time : {{ formatTime (timePtr .StartTime) }}
+- End time : {{ formatTime (timePtr .EndTime) }}
+- Generated at : {{ formatTime (timePtr .GeneratedAt) }}
+
+## HPA
+
+| Namespace | Name | Min | Max | Peak replicas | Peak at | Time to ready | Recommended min | Recommended max |
+|---|---|---|---|---|---|---|---|---|
+{{- range .HPAs }}
+| {{ .Namespace }} | {{ .Name }} | {{ formatReplicas .MinReplicas }} | {{ .MaxReplicas }} | {{ .PeakReplicas }} | {{ formatTime .PeakReplicasAt }} | {{ formatSeconds .TimeToReadySeconds }} | {{ .RecommendedMinReplicas }} | {{ .RecommendedMaxReplicas }} |
+{{- end }}
+{{ range .HPAs }}{{ if or .HitMaxIntervals .UnavailableIntervals }}
+### {{ .Namespace }}/{{ .Name }}
+{{ range .HitMaxIntervals }}
+- Pinned at max replicas from {{ formatTime (timePtr .StartTime) }} to {{ formatTime (timePtr .EndTime) }}
+{{- end }}
+{{- range .UnavailableIntervals }}
+- Unavailable replicas from {{ formatTime (timePtr .StartTime) }} to {{ formatTime (timePtr .EndTime) }}
+{{- end }}
+{{ end }}{{ end }}
+## Node pool
+
+Peak node count is the node count across all zones, max node is the per zone limit.
+
+| Name | Max node | Peak node count | Peak at | Recommended node count |
+|---|---|---|---|---|
+{{- range .NodePools }}
+| {{ .Name }} | {{ .MaxNode }} | {{ .PeakNodeCount }} | {{ formatTime .PeakNodeCountAt }} | {{ .RecommendedNodeCount }} |
+{{- end }}
+`
+
+const eventReportHTMLTemplate = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Event report : {{ .EventName }}</title>
+<style>
+body { font-family: sans-serif; }
+table { border-collapse: collapse; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; }
+</style>
+</head>
+<body>
+<h1>Event report : {{ .EventName }}</h1>
+<ul>
+<li>Start time : {{ formatTime (timePtr .StartTime) }}</li>
+<li>End time : {{ formatTime (timePtr .EndTime) }}</li>
+<li>Generated at : {{ formatTime (timePtr .GeneratedAt) }}</li>
+</ul>
+<h2>HPA</h2>
+<table>
+<tr><th>Namespace</th><th>Name</th><th>Min</th><th>Max</th><th>Peak replicas</th><th>Peak at</th><th>Time to ready</th><th>Recommended min</th><th>Recommended max</th></tr>
+{{- range .HPAs }}
+<tr><td>{{ .Namespace }}</td><td>{{ .Name }}</td><td>{{ formatReplicas .MinReplicas }}</td><td>{{ .MaxReplicas }}</td><td>{{ .PeakReplicas }}</td><td>{{ formatTime .PeakReplicasAt }}</td><td>{{ formatSeconds .TimeToReadySeconds }}</td><td>{{ .RecommendedMinReplicas }}</td><td>{{ .RecommendedMaxReplicas }}</td></tr>
+{{- end }}
+</table>
+{{- range .HPAs }}{{ if or .HitMaxIntervals .UnavailableIntervals }}
+<h3>{{ .Namespace }}/{{ .Name }}</h3>
+<ul>
+{{- range .HitMaxIntervals }}
+<li>Pinned at max replicas from {{ formatTime (timePtr .StartTime) }} to {{ formatTime (timePtr .EndTime) }}</li>
+{{- end }}
+{{- range .UnavailableIntervals }}
+<li>Unavailable replicas from {{ formatTime (timePtr .StartTime) }} to {{ formatTime (timePtr .EndTime) }}</li>
+{{- end }}
+</ul>
+{{- end }}{{ end }}
+<h2>Node pool</h2>
+<p>Peak node count is the node count across all zones, max node is the per zone limit.</p>
+<table>
+<tr><th>Name</th><th>Max node</th><th>Peak node count</th><th>Peak at</th><th>Recommended node count</th></tr>
+{{- range .NodePools }}
+<tr><td>{{ .Name }}</td><td>{{ .MaxNode }}</td><td>{{ .PeakNodeCount }}</td><td>{{ formatTime .PeakNodeCountAt }}</td><td>{{ .RecommendedNodeCount }}</td></tr>
+{{- end }}
+</table>
+</body>
+</html>
+`
+
+var (
+	eventReportMarkdown = template.Must(
+		template.New("event_report_markdown").
+			Funcs(eventReportTemplateFuncs).
+			Parse(eventReportMarkdownTemplate),
+	)
+	eventReportHTML = htmlTemplate.Must(
+		htmlTemplate.New("event_report_html").
+			Funcs(eventReportTemplateFuncs).
+			Parse(eventReportHTMLTemplate),
+	)
+)
+
+func renderEventReportMarkdown(report response.EventReport) (string, error) {
+	buffer := &bytes.Buffer{}
+	if err := eventReportMarkdown.Execute(buffer, report); err != nil {
+		return "", err
+	}
+	return buffer.String(), nil
+}
+
+func renderEventReportHTML(report response.EventReport) (string, error) {
+	buffer := &bytes.Buffer{}
+	if err := eventReportHTML.Execute(buffer, report); err != nil {
+		return "", err
+	}
+	return buffer.String(), nil
+}
+
+func buildEventReportIntervals(intervals []UCEntity.EventReportIntervalData) []response.EventReportInterval {
+	output := make([]response.EventReportInterval, 0)
+	for _, interval := range intervals {
+		output = append(
+			output, response.EventReportInterval{
+				StartTime: interval.StartTime,
+				EndTime:   interval.EndTime,
+			},
+		)
+	}
+	return output
+}
+
+func buildEventReportResponse(report *UCEntity.EventReportData) response.EventReport {
+	output := response.EventReport{
+		EventID:     report.EventID,
+		EventName:   report.EventName,
+		StartTime:   report.StartTime,
+		EndTime:     report.EndTime,
+		GeneratedAt: report.GeneratedAt,
+		HPAs:        make([]response.EventReportHPA, 0),
+		NodePools:   make([]response.EventReportNodePool, 0),
+	}
+	for _, hpa := range report.HPAs {
+		output.HPAs = append(
+			output.HPAs, response.EventReportHPA{
+				Name:                   hpa.Name,
+				Namespace:              hpa.Namespace,
+				MinReplicas:            hpa.MinReplicas,
+				MaxReplicas:            hpa.MaxReplicas,
+				PeakReplicas:           hpa.PeakReplicas,
+				PeakReplicasAt:         hpa.PeakReplicasAt,
+				PeakDesiredReplicas:    hpa.PeakDesiredReplicas,
+				TimeToReadySeconds:     hpa.TimeToReadySeconds,
+				HitMaxIntervals:        buildEventReportIntervals(hpa.HitMaxIntervals),
+				UnavailableIntervals:   buildEventReportIntervals(hpa.UnavailableIntervals),
+				RecommendedMinReplicas: hpa.RecommendedMinReplicas,
+				RecommendedMaxReplicas: hpa.RecommendedMaxReplicas,
+			},
+		)
+	}
+	for _, nodePool := range report.NodePools {
+		output.NodePools = append(
+			output.NodePools, response.EventReportNodePool{
+				Name:                     nodePool.Name,
+				MaxNode:                  nodePool.MaxNode,
+				PeakNodeCount:            nodePool.PeakNodeCount,
+				PeakNodeCountAt:          nodePool.PeakNodeCountAt,
+				PeakRequestedCPUMillis:   nodePool.PeakRequestedCPU,
+				PeakRequestedMemoryBytes: nodePool.PeakRequestedMemory,
+				RecommendedNodeCount:     nodePool.RecommendedNodeCount,
+			},
+		)
+	}
+	return output
+}
@@ -0,0 +1,132 @@
+package handler
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	errorConstant "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant/errors"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/response"
+	UCEntity "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/usecase"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository/model"
+	"time"
+)
+
+// The heartbeat keeps proxies from closing an idle stream and detects disconnected clients
+const eventStreamHeartbeatInterval = 15 * time.Second
+
+// StreamEvent pushes the new samples and status transitions of the event as server-sent events,
+// the stream starts with the current status and ends when the event succeeds or fails
+func (e *event) StreamEvent(c *fiber.Ctx) error {
+	eventID, err := uuid.Parse(c.Params("event_id"))
+	if err != nil {
+		return e.errorResponse(c, fmt.Sprintf(errorConstant.ParamInvalid, "event_id"))
+	}
+
+	// The request context is done once the handler returns, the subscription lives as long as
+	// the stream writer. It starts before the current status is read so a transition published in
+	// between is not lost
+	ctx, cancel := context.WithCancel(context.Background())
+	messages, err := e.eventStreamUC.Subscribe(ctx, eventID)
+	if err != nil {
+		cancel()
+		return e.errorResponse(c, err.Error())
+	}
+
+	db := e.db.WithContext(c.Context())
+	eventData, err := e.eventUC.GetEventByID(db, eventID)
+	if err != nil {
+		cancel()
+		return e.errorResponse(c, errorConstant.EventNotExist)
+	}
+
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+
+	currentStatus := &UCEntity.EventStreamMessage{
+		Type: UCEntity.EventStreamEventStatus,
+		EventStatus: &UCEntity.EventStreamEventStatusData{
+			Status:  eventData.Status,
+			Message: eventData.Message,
+		},
+	}
+	c.Context().SetBodyStreamWriter(
+		func(w *bufio.Writer) {
+			defer cancel()
+			heartbeat := time.NewTicker(eventStreamHeartbeatInterval)
+			defer heartbeat.Stop()
+
+			message := currentStatus
+			for {
+				if message != nil {
+					if err := e.writeEventStreamMessage(w, message); err != nil {
+						return
+					}
+					if message.EventStatus != nil && isEventStreamFinished(message.EventStatus.Status) {
+						return
+					}
+				}
+				select {
+				case newMessage, ok := <-messages:
+					if !ok {
+						return
+					}
+					message = newMessage
+				case <-heartbeat.C:
+					message = nil
+					if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+						return
+					}
+					if err := w.Flush(); err != nil {
+						return
+					}
+				}
+			}
+		},
+	)
+	return nil
+}
+
+func (e *event) writeEventStreamMessage(w *bufio.Writer, message *UCEntity.EventStreamMessage) error {
+	var data interface{}
+	switch {
+	case message.HPAStatus != nil:
+		data = response.EventStreamHPAStatus{
+			ScheduledHPAConfigID: message.HPAStatus.ScheduledHPAConfigID,
+			Name:                 message.HPAStatus.Name,
+			Namespace:            message.HPAStatus.Namespace,
+			HPAStatus:            e.buildHPAStatusResponse(&message.HPAStatus.HPAStatusData),
+		}
+	case message.NodePoolStatus != nil:
+		data = response.EventStreamNodePoolStatus{
+			UpdatedNodePoolID: message.NodePoolStatus.UpdatedNodePoolID,
+			NodePoolName:      message.NodePoolStatus.NodePoolName,
+			NodePoolStatus:    e.buildNodePoolStatusResponse(&message.NodePoolStatus.NodePoolStatusData),
+		}
+	case message.EventStatus != nil:
+		data = response.EventStreamEventStatus{
+			Status:         string(message.EventStatus.Status),
+			PreviousStatus: string(message.EventStatus.PreviousStatus),
+			Message:        message.EventStatus.Message,
+		}
+	default:
+		return nil
+	}
+
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", message.Type, payload); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+func isEventStreamFinished(status model.EventStatus) bool {
+	return status == model.EventSuccess || status == model.EventFailed
+}
@@ -115,6 +115,7 @@ func (g *gcp) GetClustersByDatacenterID(c *fiber.Ctx) error {
 	if err != nil {
 		return g.errorResponse(c, err.Error())
 	}
+	defer clusterClient.Close()
 	clusters, err := g.clusterUC.GetAllClustersInGCPProject(
 		ctx,
 		googleCredentials.ProjectID,
@@ -181,6 +182,7 @@ func (g *gcp) RegisterClusterWithDatacenter(c *fiber.Ctx) error {
 	if err != nil {
 		return g.errorResponse(c, err.Error())
 	}
+	defer clusterClient.Close()
 	clusters, err := g.clusterUC.GetAllClustersInGCPProject(
 		ctx,
 		googleCredentials.ProjectID,
@@ -6,9 +6,10 @@ import (
 )
 
 type Handlers struct {
-	GcpHandler     Gcp
-	ClusterHandler Cluster
-	EventHandler   Event
+	GcpHandler          Gcp
+	ClusterHandler      Cluster
+	EventHandler        Event
+	NotificationHandler Notification
 }
 
 func BuildHandlers(useCases *useCase.UseCases, resources *config.KubeEPResources) *Handlers {
@@ -16,6 +17,7 @@ func BuildHandlers(useCases *useCase.UseCases, resources *config.KubeEPResources
 		generalClusterUC: useCases.Cluster,
 		gcpClusterUC:     useCases.GcpCluster,
 		gcpDatacenterUC:  useCases.GcpDatacenter,
+		clientManager:    useCases.ClientManager,
 	}
 	return &Handlers{
 		GcpHandler: newGCPHandler(
@@ -36,9 +38,21 @@ func BuildHandlers(useCases *useCase.UseCases, resources *config.KubeEPResources
 			useCases.Event,
 			useCases.ScheduledHPAConfig,
 			useCases.UpdatedNodePool,
+			useCases.ScaleDown,
+			useCases.EventValidation,
+			useCases.EventReport,
+			useCases.EventRecommendation,
+			useCases.CloudEvent,
+			useCases.EventStream,
+			useCases.Guard,
 			resources.DB,
 			kubernetesBaseHandler,
 		),
+		NotificationHandler: newNotificationHandler(
+			resources.ValidatorInst,
+			useCases.Notification,
+			resources.DB,
+		),
 	}
 
 }
@@ -0,0 +1,246 @@
+package handler
+
+import (
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant"
+	errorConstant "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant/errors"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/request"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/response"
+	UCEntity "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/usecase"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository/model"
+	useCase "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/usecase"
+	"gorm.io/gorm"
+)
+
+type Notification interface {
+	RegisterChannel(c *fiber.Ctx) error
+	ListChannel(c *fiber.Ctx) error
+	DeleteChannel(c *fiber.Ctx) error
+	RegisterSubscription(c *fiber.Ctx) error
+	ListSubscription(c *fiber.Ctx) error
+	DeleteSubscription(c *fiber.Ctx) error
+	ListDelivery(c *fiber.Ctx) error
+}
+
+type notification struct {
+	baseHandler
+	validatorInst  *validator.Validate
+	db             *gorm.DB
+	notificationUC useCase.Notification
+}
+
+func newNotificationHandler(
+	validatorInst *validator.Validate,
+	notificationUC useCase.Notification,
+	db *gorm.DB,
+) Notification {
+	return &notification{
+		validatorInst:  validatorInst,
+		notificationUC: notificationUC,
+		db:             db,
+	}
+}
+
+func (n *notification) RegisterChannel(c *fiber.Ctx) error {
+	reqData := &request.NotificationChannelRequest{}
+	err := c.BodyParser(reqData)
+	if err != nil {
+		return n.errorResponse(c, err.Error())
+	}
+	err = n.validatorInst.Struct(reqData)
+	if err != nil {
+		return n.errorResponse(c, errorConstant.InvalidRequestBody)
+	}
+
+	channelData := &UCEntity.NotificationChannelData{
+		Name: *reqData.Name,
+		Type: model.NotificationChannelType(*reqData.Type),
+		Config: UCEntity.NotificationChannelConfig{
+			Recipients: reqData.Recipients,
+		},
+	}
+	if reqData.URL != nil {
+		channelData.Config.URL = *reqData.URL
+	}
+	if reqData.Secret != nil {
+		channelData.Config.Secret = *reqData.Secret
+	}
+
+	db := n.db.WithContext(c.Context())
+	channelID, err := n.notificationUC.RegisterChannel(db, channelData)
+	if err != nil {
+		return n.errorResponse(c, err.Error())
+	}
+
+	return n.successResponse(c, response.NotificationCreationResponse{ID: channelID})
+}
+
+func (n *notification) ListChannel(c *fiber.Ctx) error {
+	db := n.db.WithContext(c.Context())
+	channels, err := n.notificationUC.ListChannel(db)
+	if err != nil {
+		return n.errorResponse(c, err.Error())
+	}
+
+	resp := []response.NotificationChannel{}
+	for _, channel := range channels {
+		resp = append(resp, n.buildNotificationChannelResponse(channel))
+	}
+
+	return n.successResponse(c, resp)
+}
+
+func (n *notification) DeleteChannel(c *fiber.Ctx) error {
+	channelID, err := uuid.Parse(c.Params("channel_id"))
+	if err != nil {
+		return n.errorResponse(c, fmt.Sprintf(errorConstant.ParamInvalid, "channel_id"))
+	}
+
+	db := n.db.WithContext(c.Context())
+	tx := db.Begin()
+
+	err = n.notificationUC.DeleteChannel(tx, channelID)
+	if err != nil {
+		tx.Rollback()
+		return n.errorResponse(c, err.Error())
+	}
+
+	tx.Commit()
+
+	return n.successResponse(c, constant.ActionDone)
+}
+
+func (n *notification) RegisterSubscription(c *fiber.Ctx) error {
+	reqData := &request.NotificationSubscriptionRequest{}
+	err := c.BodyParser(reqData)
+	if err != nil {
+		return n.errorResponse(c, err.Error())
+	}
+	err = n.validatorInst.Struct(reqData)
+	if err != nil {
+		return n.errorResponse(c, errorConstant.InvalidRequestBody)
+	}
+
+	subscriptionData := &UCEntity.NotificationSubscriptionData{
+		ClusterID: reqData.ClusterID,
+		EventID:   reqData.EventID,
+	}
+	subscriptionData.Channel.ID = *reqData.ChannelID
+	for _, transition := range reqData.Transitions {
+		subscriptionData.Transitions = append(
+			subscriptionData.Transitions,
+			model.NotificationTransition(transition),
+		)
+	}
+
+	db := n.db.WithContext(c.Context())
+	subscriptionID, err := n.notificationUC.RegisterSubscription(db, subscriptionData)
+	if err != nil {
+		return n.errorResponse(c, err.Error())
+	}
+
+	return n.successResponse(c, response.NotificationCreationResponse{ID: subscriptionID})
+}
+
+func (n *notification) ListSubscription(c *fiber.Ctx) error {
+	reqData := &request.NotificationSubscriptionListRequest{}
+	err := c.QueryParser(reqData)
+	if err != nil {
+		return n.errorResponse(c, errorConstant.InvalidQueryParam)
+	}
+
+	db := n.db.WithContext(c.Context())
+	subscriptions, err := n.notificationUC.ListSubscription(db, reqData.ClusterID)
+	if err != nil {
+		return n.errorResponse(c, err.Error())
+	}
+
+	resp := []response.NotificationSubscription{}
+	for _, subscription := range subscriptions {
+		transitions := []string{}
+		for _, transition := range subscription.Transitions {
+			transitions = append(transitions, string(transition))
+		}
+		resp = append(
+			resp, response.NotificationSubscription{
+				ID:          subscription.ID,
+				Channel:     n.buildNotificationChannelResponse(subscription.Channel),
+				ClusterID:   subscription.ClusterID,
+				EventID:     subscription.EventID,
+				Transitions: transitions,
+			},
+		)
+	}
+
+	return n.successResponse(c, resp)
+}
+
+func (n *notification) DeleteSubscription(c *fiber.Ctx) error {
+	subscriptionID, err := uuid.Parse(c.Params("subscription_id"))
+	if err != nil {
+		return n.errorResponse(c, fmt.Sprintf(errorConstant.ParamInvalid, "subscription_id"))
+	}
+
+	db := n.db.WithContext(c.Context())
+	err = n.notificationUC.DeleteSubscription(db, subscriptionID)
+	if err != nil {
+		return n.errorResponse(c, err.Error())
+	}
+
+	return n.successResponse(c, constant.ActionDone)
+}
+
+func (n *notification) ListDelivery(c *fiber.Ctx) error {
+	reqData := &request.NotificationDeliveryListRequest{}
+	err := c.QueryParser(reqData)
+	if err != nil {
+		return n.errorResponse(c, errorConstant.InvalidQueryParam)
+	}
+	err = n.validatorInst.Struct(reqData)
+	if err != nil {
+		return n.errorResponse(c, errorConstant.InvalidQueryParam)
+	}
+
+	db := n.db.WithContext(c.Context())
+	deliveries, err := n.notificationUC.ListDeliveryByEventID(db, *reqData.EventID)
+	if err != nil {
+		return n.errorResponse(c, err.Error())
+	}
+
+	resp := []response.NotificationDelivery{}
+	for _, delivery := range deliveries {
+		resp = append(
+			resp, response.NotificationDelivery{
+				ID:          delivery.ID,
+				CreatedAt:   delivery.CreatedAt,
+				ChannelID:   delivery.ChannelID,
+				ChannelName: delivery.ChannelName,
+				EventID:     delivery.EventID,
+				Transition:  string(delivery.Transition),
+				Status:      string(delivery.Status),
+				Attempts:    delivery.Attempts,
+				LastError:   delivery.LastError,
+				DeliveredAt: delivery.DeliveredAt,
+			},
+		)
+	}
+
+	return n.successResponse(c, resp)
+}
+
+// buildNotificationChannelResponse leaves out the webhook secret, only its presence is returned
+func (n *notification) buildNotificationChannelResponse(
+	channel UCEntity.NotificationChannelData,
+) response.NotificationChannel {
+	return response.NotificationChannel{
+		ID:         channel.ID,
+		Name:       channel.Name,
+		Type:       string(channel.Type),
+		URL:        channel.Config.URL,
+		HasSecret:  channel.Config.Secret != "",
+		Recipients: channel.Config.Recipients,
+	}
+}
@@ -0,0 +1,97 @@
+package auth
+
+import (
+	"crypto/subtle"
+	"github.com/gofiber/fiber/v2"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant"
+	errorConstant "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant/errors"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/response"
+	"strings"
+)
+
+const (
+	bearerPrefix = "Bearer "
+	// QueryToken carries the token of the server-sent event streams, the browser EventSource cannot
+	// set the Authorization header
+	QueryToken          = "access_token"
+	eventStreamMIMEType = "text/event-stream"
+)
+
+// FiberMiddleware accepts the requests carrying one of the tokens as bearer token, every request
+// is accepted when no token is configured. The event streams, the GET requests of
+// queryTokenRoutes asking for text/event-stream, also accept the token in the access_token query,
+// the other routes only read the header so the token does not end in the access logs of their
+// urls. The routes are fiber paths like /event/:event_id/stream since the middleware runs before
+// the routing.
+//
+// The auth is meant for the api clients (kubeepctl, the controller), the web UI sends no token and
+// needs the tokens to be empty or a proxy authenticating its users in front of the api. The routes
+// registered before the middleware, /metrics and the OpenAPI document, stay unauthenticated
+func FiberMiddleware(tokens []string, queryTokenRoutes ...string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if len(tokens) == 0 || c.Method() == fiber.MethodOptions {
+			return c.Next()
+		}
+		header := c.Get(fiber.HeaderAuthorization)
+		token := strings.TrimPrefix(header, bearerPrefix)
+		if strings.HasPrefix(header, bearerPrefix) && isValidToken(tokens, token) {
+			return c.Next()
+		}
+		if isEventStreamRequest(c, queryTokenRoutes) && isValidToken(tokens, c.Query(QueryToken)) {
+			return c.Next()
+		}
+		return c.Status(fiber.StatusUnauthorized).JSON(
+			&response.Base{
+				Status: constant.Error,
+				Data:   errorConstant.Unauthorized,
+			},
+		)
+	}
+}
+
+func isEventStreamRequest(c *fiber.Ctx, streamRoutes []string) bool {
+	if c.Method() != fiber.MethodGet || !strings.Contains(c.Get(fiber.HeaderAccept), eventStreamMIMEType) {
+		return false
+	}
+	for _, route := range streamRoutes {
+		if matchRoute(route, c.Path()) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchRoute matches the path against a fiber route, a :param segment matches any non empty
+// segment. Like the router the match ignores the case and a trailing slash
+func matchRoute(route, path string) bool {
+	routeSegments := strings.Split(strings.Trim(route, "/"), "/")
+	pathSegments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(routeSegments) != len(pathSegments) {
+		return false
+	}
+	for i, segment := range routeSegments {
+		if strings.HasPrefix(segment, ":") {
+			if pathSegments[i] == "" {
+				return false
+			}
+			continue
+		}
+		if !strings.EqualFold(segment, pathSegments[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func isValidToken(tokens []string, token string) bool {
+	if token == "" {
+		return false
+	}
+	valid := false
+	for _, t := range tokens {
+		if subtle.ConstantTimeCompare([]byte(t), []byte(token)) == 1 {
+			valid = true
+		}
+	}
+	return valid
+}
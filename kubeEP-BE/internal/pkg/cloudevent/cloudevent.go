@@ -0,0 +1,68 @@
+package cloudevent
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/google/uuid"
+	"time"
+)
+
+const (
+	SpecVersion     = "1.0"
+	DataContentType = "application/json"
+	DefaultSource   = "kubeep"
+)
+
+const (
+	TypeEventCreated       = "io.kubeep.event.created"
+	TypeEventUpdated       = "io.kubeep.event.updated"
+	TypeEventDeleted       = "io.kubeep.event.deleted"
+	TypeEventStatusChanged = "io.kubeep.event.status_changed"
+	TypeHPAStatusChanged   = "io.kubeep.hpa.status_changed"
+	TypeNodePoolUpdated    = "io.kubeep.node_pool.updated"
+)
+
+// Event is a CloudEvents v1.0 event in the structured JSON format
+type Event struct {
+	SpecVersion     string          `json:"specversion"`
+	ID              string          `json:"id"`
+	Source          string          `json:"source"`
+	Type            string          `json:"type"`
+	Subject         string          `json:"subject,omitempty"`
+	Time            time.Time       `json:"time"`
+	DataContentType string          `json:"datacontenttype"`
+	Data            json.RawMessage `json:"data"`
+}
+
+func New(source, eventType, subject string, data interface{}) (*Event, error) {
+	rawData, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	return &Event{
+		SpecVersion:     SpecVersion,
+		ID:              uuid.NewString(),
+		Source:          source,
+		Type:            eventType,
+		Subject:         subject,
+		Time:            time.Now().UTC(),
+		DataContentType: DataContentType,
+		Data:            rawData,
+	}, nil
+}
+
+// Sink delivers the events to a broker, new brokers (NATS, Kafka) only need to implement it
+type Sink interface {
+	Publish(ctx context.Context, event *Event) error
+}
+
+type nopSink struct{}
+
+// NewNopSink returns the sink used when publishing is disabled
+func NewNopSink() Sink {
+	return nopSink{}
+}
+
+func (nopSink) Publish(context.Context, *Event) error {
+	return nil
+}
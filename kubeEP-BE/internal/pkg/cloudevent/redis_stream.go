@@ -0,0 +1,72 @@
+package cloudevent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/go-redis/redis/v8"
+)
+
+const (
+	SinkRedisStream = "redis-stream"
+
+	DefaultRedisStream = "kubeep:events"
+
+	// RedisStreamEventField holds the whole event, the other fields are copied for filtering
+	// without decoding it
+	RedisStreamEventField = "event"
+)
+
+type redisStreamSink struct {
+	client *redis.Client
+	stream string
+	maxLen int64
+}
+
+// NewRedisStreamSink appends the events to the stream, the stream is trimmed approximately to
+// maxLen entries when maxLen is positive
+func NewRedisStreamSink(client *redis.Client, stream string, maxLen int64) Sink {
+	if stream == "" {
+		stream = DefaultRedisStream
+	}
+	return &redisStreamSink{
+		client: client,
+		stream: stream,
+		maxLen: maxLen,
+	}
+}
+
+func (r *redisStreamSink) Publish(ctx context.Context, event *Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	args := &redis.XAddArgs{
+		Stream: r.stream,
+		Values: map[string]interface{}{
+			"id":                  event.ID,
+			"type":                event.Type,
+			"source":              event.Source,
+			"subject":             event.Subject,
+			RedisStreamEventField: string(data),
+		},
+	}
+	if r.maxLen > 0 {
+		args.MaxLen = r.maxLen
+		args.Approx = true
+	}
+	return r.client.XAdd(ctx, args).Err()
+}
+
+// DecodeRedisStreamMessage reads back an event appended by the redis stream sink
+func DecodeRedisStreamMessage(message redis.XMessage) (*Event, error) {
+	raw, ok := message.Values[RedisStreamEventField].(string)
+	if !ok {
+		return nil, fmt.Errorf("message %s has no %s field", message.ID, RedisStreamEventField)
+	}
+	event := &Event{}
+	if err := json.Unmarshal([]byte(raw), event); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
@@ -2,6 +2,7 @@ package k8sClient
 
 import (
 	"encoding/base64"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/pkg/metrics"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/tools/clientcmd/api"
@@ -55,6 +56,8 @@ func GetClient(credentials *Credentials) (*kubernetes.Clientset, error) {
 		return nil, err
 	}
 
+	cfg.Wrap(metrics.WrapKubernetesTransport(name))
+
 	k8sClient, err := kubernetes.NewForConfig(cfg)
 	if err != nil {
 		return nil, err
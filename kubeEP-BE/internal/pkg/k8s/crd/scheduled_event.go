@@ -0,0 +1,131 @@
+// Package crd holds the ScheduledEvent custom resource reconciled by the kubeEP controller, the
+// spec mirrors the event registration request of the api
+package crd
+
+import (
+	"encoding/json"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	Group                  = "kubeep.io"
+	Version                = "v1alpha1"
+	ScheduledEventKind     = "ScheduledEvent"
+	ScheduledEventResource = "scheduledevents"
+
+	// EventFinalizer keeps the custom resource until its kubeEP event is deleted
+	EventFinalizer = "kubeep.io/event"
+	// EventOwnerFormat is the owner of the kubeEP events registered for a resource, formatted with
+	// the resource uid
+	EventOwnerFormat = "kubeep.io/scheduledevent/%s"
+)
+
+var ScheduledEventGVR = schema.GroupVersionResource{
+	Group:    Group,
+	Version:  Version,
+	Resource: ScheduledEventResource,
+}
+
+type ScheduledEventPhase string
+
+// Besides the kubeEP event status, the phase tells whether the resource is synced to kubeEP
+const (
+	PhaseSyncFailed ScheduledEventPhase = "SYNC_FAILED"
+)
+
+type ScheduledEvent struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ScheduledEventSpec   `json:"spec"`
+	Status ScheduledEventStatus `json:"status,omitempty"`
+}
+
+// ScheduledEventSpec uses the resource name as the event name when Name is empty
+type ScheduledEventSpec struct {
+	Name               string              `json:"name,omitempty"`
+	ClusterID          string              `json:"clusterID"`
+	StartTime          metav1.Time         `json:"startTime"`
+	EndTime            metav1.Time         `json:"endTime"`
+	ExecuteConfigAt    metav1.Time         `json:"executeConfigAt"`
+	WatchingAt         metav1.Time         `json:"watchingAt"`
+	CalculateNodePool  *bool               `json:"calculateNodePool,omitempty"`
+	ModifiedHPAConfigs []ModifiedHPAConfig `json:"modifiedHPAConfigs"`
+	ScaleDown          *ScaleDownConfig    `json:"scaleDown,omitempty"`
+	Watch              *WatchConfig        `json:"watch,omitempty"`
+	GuardRules         []GuardRule         `json:"guardRules,omitempty"`
+}
+
+type ModifiedHPAConfig struct {
+	Name                 string `json:"name"`
+	Namespace            string `json:"namespace"`
+	MinReplicas          int32  `json:"minReplicas"`
+	MaxReplicas          int32  `json:"maxReplicas"`
+	PostEventMinReplicas *int32 `json:"postEventMinReplicas,omitempty"`
+	PostEventMaxReplicas *int32 `json:"postEventMaxReplicas,omitempty"`
+}
+
+type ScaleDownConfig struct {
+	WindowMinutes int64  `json:"windowMinutes"`
+	Steps         int32  `json:"steps"`
+	Gate          string `json:"gate,omitempty"`
+}
+
+type WatchConfig struct {
+	IntervalSeconds    int64  `json:"intervalSeconds"`
+	MaxIntervalSeconds *int64 `json:"maxIntervalSeconds,omitempty"`
+}
+
+type GuardRule struct {
+	HPAName         string   `json:"hpaName,omitempty"`
+	HPANamespace    string   `json:"hpaNamespace,omitempty"`
+	Condition       string   `json:"condition"`
+	Threshold       *float64 `json:"threshold,omitempty"`
+	DurationSeconds int64    `json:"durationSeconds"`
+	Action          string   `json:"action"`
+	ActionValue     *int32   `json:"actionValue,omitempty"`
+	NodePoolName    string   `json:"nodePoolName,omitempty"`
+	CooldownSeconds *int64   `json:"cooldownSeconds,omitempty"`
+}
+
+// ScheduledEventStatus is written back by the controller, SpecHash detects spec changes already
+// pushed to kubeEP
+type ScheduledEventStatus struct {
+	EventID            string              `json:"eventID,omitempty"`
+	Phase              ScheduledEventPhase `json:"phase,omitempty"`
+	Message            string              `json:"message,omitempty"`
+	ObservedGeneration int64               `json:"observedGeneration,omitempty"`
+	SpecHash           string              `json:"specHash,omitempty"`
+	LastSyncTime       *metav1.Time        `json:"lastSyncTime,omitempty"`
+}
+
+func FromUnstructured(obj *unstructured.Unstructured) (*ScheduledEvent, error) {
+	data, err := obj.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	scheduledEvent := &ScheduledEvent{}
+	if err := json.Unmarshal(data, scheduledEvent); err != nil {
+		return nil, err
+	}
+	return scheduledEvent, nil
+}
+
+// StatusToUnstructured returns a copy of the object with the given status, ready for UpdateStatus
+func StatusToUnstructured(
+	obj *unstructured.Unstructured,
+	status ScheduledEventStatus,
+) (*unstructured.Unstructured, error) {
+	statusMap, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&status)
+	if err != nil {
+		return nil, err
+	}
+	output := obj.DeepCopy()
+	if err := unstructured.SetNestedMap(output.Object, statusMap, "status"); err != nil {
+		return nil, err
+	}
+	return output, nil
+}
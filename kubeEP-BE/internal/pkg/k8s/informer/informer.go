@@ -0,0 +1,303 @@
+package k8sInformer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant"
+	errorConstant "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	appsListers "k8s.io/client-go/listers/apps/v1"
+	coreListers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"sync"
+	"time"
+)
+
+const (
+	resyncPeriod = 10 * time.Minute
+	syncTimeout  = 2 * time.Minute
+)
+
+type ObjectKind string
+
+const (
+	NodeKind       ObjectKind = "Node"
+	DeploymentKind ObjectKind = "Deployment"
+	HPAKind        ObjectKind = "HorizontalPodAutoscaler"
+)
+
+// ChangeFilter selects the changes a subscriber is notified of
+type ChangeFilter func(kind ObjectKind, namespace, name string) bool
+
+// Manager shares one set of informers per cluster between every user of the cluster, the
+// informers are stopped when the last user releases the cluster
+type Manager interface {
+	Acquire(
+		ctx context.Context,
+		clusterID uuid.UUID,
+		client kubernetes.Interface,
+		hpaVersion constant.HPAVersion,
+	) (*ClusterCache, error)
+	Release(clusterID uuid.UUID)
+}
+
+type clusterEntry struct {
+	cache    *ClusterCache
+	refCount int
+	ready    chan struct{}
+	err      error
+}
+
+type manager struct {
+	lock     sync.Mutex
+	clusters map[uuid.UUID]*clusterEntry
+}
+
+func NewManager() Manager {
+	return &manager{clusters: map[uuid.UUID]*clusterEntry{}}
+}
+
+// Acquire returns the synced cache of the cluster, every successful Acquire must be followed by a
+// Release. The client and hpa version of the first user are used until the informers are stopped
+func (m *manager) Acquire(
+	ctx context.Context,
+	clusterID uuid.UUID,
+	client kubernetes.Interface,
+	hpaVersion constant.HPAVersion,
+) (*ClusterCache, error) {
+	m.lock.Lock()
+	entry, ok := m.clusters[clusterID]
+	if ok {
+		entry.refCount += 1
+		m.lock.Unlock()
+	} else {
+		entry = &clusterEntry{
+			cache:    newClusterCache(client, hpaVersion),
+			refCount: 1,
+			ready:    make(chan struct{}),
+		}
+		m.clusters[clusterID] = entry
+		m.lock.Unlock()
+
+		entry.err = entry.cache.start(ctx)
+		close(entry.ready)
+	}
+
+	select {
+	case <-entry.ready:
+	case <-ctx.Done():
+		m.Release(clusterID)
+		return nil, ctx.Err()
+	}
+	if entry.err != nil {
+		m.Release(clusterID)
+		return nil, entry.err
+	}
+	return entry.cache, nil
+}
+
+func (m *manager) Release(clusterID uuid.UUID) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	entry, ok := m.clusters[clusterID]
+	if !ok {
+		return
+	}
+	entry.refCount -= 1
+	if entry.refCount > 0 {
+		return
+	}
+	delete(m.clusters, clusterID)
+	entry.cache.stop()
+}
+
+// ClusterCache serves nodes, pods, deployments, hpa and failed scheduling events of a cluster from
+// memory
+type ClusterCache struct {
+	factory      informers.SharedInformerFactory
+	eventFactory informers.SharedInformerFactory
+	hpaIndexer   cache.Indexer
+	stopCh       chan struct{}
+	stopOnce     sync.Once
+	synced       []cache.InformerSynced
+
+	Nodes       coreListers.NodeLister
+	Pods        coreListers.PodLister
+	Deployments appsListers.DeploymentLister
+	Events      coreListers.EventLister
+
+	subscriberLock sync.RWMutex
+	subscriberID   int
+	subscribers    map[int]*subscriber
+}
+
+type subscriber struct {
+	filter  ChangeFilter
+	changes chan struct{}
+}
+
+func newClusterCache(client kubernetes.Interface, hpaVersion constant.HPAVersion) *ClusterCache {
+	factory := informers.NewSharedInformerFactory(client, resyncPeriod)
+	eventFactory := informers.NewSharedInformerFactoryWithOptions(
+		client,
+		resyncPeriod,
+		informers.WithTweakListOptions(
+			func(options *v1.ListOptions) {
+				options.FieldSelector = fields.Set{
+					"involvedObject.kind": "Pod",
+					"reason":              constant.K8sFailedSchedulingReason,
+				}.String()
+			},
+		),
+	)
+	c := &ClusterCache{
+		factory:      factory,
+		eventFactory: eventFactory,
+		stopCh:       make(chan struct{}),
+		subscribers:  map[int]*subscriber{},
+	}
+
+	nodeInformer := factory.Core().V1().Nodes()
+	podInformer := factory.Core().V1().Pods()
+	deploymentInformer := factory.Apps().V1().Deployments()
+	eventInformer := eventFactory.Core().V1().Events()
+	c.Nodes = nodeInformer.Lister()
+	c.Pods = podInformer.Lister()
+	c.Deployments = deploymentInformer.Lister()
+	c.Events = eventInformer.Lister()
+
+	var hpaInformer cache.SharedIndexInformer
+	switch hpaVersion {
+	case constant.AutoscalingV1:
+		hpaInformer = factory.Autoscaling().V1().HorizontalPodAutoscalers().Informer()
+	case constant.AutoscalingV2Beta1:
+		hpaInformer = factory.Autoscaling().V2beta1().HorizontalPodAutoscalers().Informer()
+	case constant.AutoscalingV2Beta2:
+		hpaInformer = factory.Autoscaling().V2beta2().HorizontalPodAutoscalers().Informer()
+	}
+
+	// Only node add and delete change the node pool sizes, node status updates are too frequent
+	nodeInformer.Informer().AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    c.notifyFunc(NodeKind),
+			DeleteFunc: c.notifyFunc(NodeKind),
+		},
+	)
+	deploymentInformer.Informer().AddEventHandler(c.changeHandler(DeploymentKind))
+
+	c.synced = []cache.InformerSynced{
+		nodeInformer.Informer().HasSynced,
+		podInformer.Informer().HasSynced,
+		deploymentInformer.Informer().HasSynced,
+		eventInformer.Informer().HasSynced,
+	}
+	if hpaInformer != nil {
+		hpaInformer.AddEventHandler(c.changeHandler(HPAKind))
+		c.hpaIndexer = hpaInformer.GetIndexer()
+		c.synced = append(c.synced, hpaInformer.HasSynced)
+	}
+	return c
+}
+
+func (c *ClusterCache) start(ctx context.Context) error {
+	c.factory.Start(c.stopCh)
+	c.eventFactory.Start(c.stopCh)
+	syncCtx, cancel := context.WithTimeout(ctx, syncTimeout)
+	defer cancel()
+	if !cache.WaitForCacheSync(syncCtx.Done(), c.synced...) {
+		c.stop()
+		return errors.New(errorConstant.InformerSyncError)
+	}
+	return nil
+}
+
+func (c *ClusterCache) stop() {
+	c.stopOnce.Do(
+		func() {
+			close(c.stopCh)
+		},
+	)
+}
+
+// GetHPA returns the cached hpa object with the type of the cluster hpa version
+func (c *ClusterCache) GetHPA(name, namespace string) (interface{}, error) {
+	if c.hpaIndexer == nil {
+		return nil, errors.New(errorConstant.HPAVersionUnknown)
+	}
+	obj, exist, err := c.hpaIndexer.GetByKey(fmt.Sprintf("%s/%s", namespace, name))
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.New(errorConstant.HPANotFound)
+	}
+	return obj, nil
+}
+
+// Subscribe returns a channel receiving a signal after changes selected by filter, signals are
+// coalesced while the previous one is not consumed. The returned func ends the subscription
+func (c *ClusterCache) Subscribe(filter ChangeFilter) (<-chan struct{}, func()) {
+	c.subscriberLock.Lock()
+	defer c.subscriberLock.Unlock()
+	c.subscriberID += 1
+	id := c.subscriberID
+	sub := &subscriber{filter: filter, changes: make(chan struct{}, 1)}
+	c.subscribers[id] = sub
+	return sub.changes, func() {
+		c.subscriberLock.Lock()
+		defer c.subscriberLock.Unlock()
+		delete(c.subscribers, id)
+	}
+}
+
+func (c *ClusterCache) changeHandler(kind ObjectKind) cache.ResourceEventHandler {
+	notify := c.notifyFunc(kind)
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc: notify,
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldMeta, err := meta.Accessor(oldObj)
+			if err != nil {
+				return
+			}
+			newMeta, err := meta.Accessor(newObj)
+			if err != nil {
+				return
+			}
+			// Resync delivers the same object again
+			if oldMeta.GetResourceVersion() == newMeta.GetResourceVersion() {
+				return
+			}
+			notify(newObj)
+		},
+		DeleteFunc: notify,
+	}
+}
+
+func (c *ClusterCache) notifyFunc(kind ObjectKind) func(obj interface{}) {
+	return func(obj interface{}) {
+		if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+			obj = tombstone.Obj
+		}
+		objMeta, err := meta.Accessor(obj)
+		if err != nil {
+			return
+		}
+		c.subscriberLock.RLock()
+		defer c.subscriberLock.RUnlock()
+		for _, sub := range c.subscribers {
+			if sub.filter != nil && !sub.filter(kind, objMeta.GetNamespace(), objMeta.GetName()) {
+				continue
+			}
+			select {
+			case sub.changes <- struct{}{}:
+			default:
+			}
+		}
+	}
+}
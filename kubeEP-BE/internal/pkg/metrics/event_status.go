@@ -0,0 +1,64 @@
+package metrics
+
+import (
+	"context"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository/model"
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+	"time"
+)
+
+const eventStatusCountTimeout = 10 * time.Second
+
+var eventStatuses = []model.EventStatus{
+	model.EventPending,
+	model.EventExecuting,
+	model.EventPrescaled,
+	model.EventWatching,
+	model.EventScalingDown,
+	model.EventSuccess,
+	model.EventFailed,
+}
+
+type EventStatusCountFunc func(ctx context.Context) (map[model.EventStatus]int64, error)
+
+// eventStatusCollector counts the events on every scrape so both binaries report the same numbers
+type eventStatusCollector struct {
+	count EventStatusCountFunc
+	desc  *prometheus.Desc
+}
+
+func NewEventStatusCollector(count EventStatusCountFunc) prometheus.Collector {
+	return &eventStatusCollector{
+		count: count,
+		desc: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "events"),
+			"Number of events per status.",
+			[]string{"status"},
+			nil,
+		),
+	}
+}
+
+func (c *eventStatusCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+func (c *eventStatusCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), eventStatusCountTimeout)
+	defer cancel()
+	counts, err := c.count(ctx)
+	if err != nil {
+		log.Errorf("[Metrics] Error counting events by status : %s", err.Error())
+		ch <- prometheus.NewInvalidMetric(c.desc, err)
+		return
+	}
+	for _, status := range eventStatuses {
+		ch <- prometheus.MustNewConstMetric(
+			c.desc,
+			prometheus.GaugeValue,
+			float64(counts[status]),
+			string(status),
+		)
+	}
+}
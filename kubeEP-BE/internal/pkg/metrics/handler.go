@@ -0,0 +1,56 @@
+package metrics
+
+import (
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	log "github.com/sirupsen/logrus"
+	"github.com/valyala/fasthttp/fasthttpadaptor"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	Path               = "/metrics"
+	DefaultCronAddress = ":9090"
+)
+
+// FiberMiddleware records the latency of every request labeled with the matched route pattern
+func FiberMiddleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+		err := c.Next()
+		status := c.Response().StatusCode()
+		if err != nil {
+			status = fiber.StatusInternalServerError
+			var fiberErr *fiber.Error
+			if errors.As(err, &fiberErr) {
+				status = fiberErr.Code
+			}
+		}
+		HTTPRequestDuration.WithLabelValues(
+			c.Method(),
+			c.Route().Path,
+			strconv.Itoa(status),
+		).Observe(time.Since(start).Seconds())
+		return err
+	}
+}
+
+func FiberHandler() fiber.Handler {
+	handler := fasthttpadaptor.NewFastHTTPHandler(promhttp.Handler())
+	return func(c *fiber.Ctx) error {
+		handler(c.Context())
+		return nil
+	}
+}
+
+// Serve exposes the metrics on a dedicated server, used by the binaries without a fiber app
+func Serve(address string) {
+	mux := http.NewServeMux()
+	mux.Handle(Path, promhttp.Handler())
+	if err := http.ListenAndServe(address, mux); err != nil {
+		log.Errorf("[Metrics] Error serving metrics on %s : %s", address, err.Error())
+	}
+}
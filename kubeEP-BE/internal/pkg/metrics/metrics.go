@@ -0,0 +1,79 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"time"
+)
+
+const namespace = "kubeep"
+
+type Phase string
+
+const (
+	PhaseHPAFetch         Phase = "hpa_fetch"
+	PhaseDaemonSetCalc    Phase = "daemonset_calc"
+	PhaseNodePoolUpdate   Phase = "node_pool_update"
+	PhaseGKEOperationWait Phase = "gke_operation_wait"
+)
+
+type SampleKind string
+
+const (
+	SampleHPA      SampleKind = "hpa"
+	SampleNodePool SampleKind = "node_pool"
+)
+
+var (
+	EventPhaseDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "event_phase_duration_seconds",
+			Help:      "Duration of the event execution phases.",
+			Buckets:   []float64{0.1, 0.5, 1, 2.5, 5, 10, 30, 60, 120, 300, 600, 1200},
+		},
+		[]string{"phase"},
+	)
+	GKEOperationFailures = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "gke_operation_failures_total",
+			Help:      "GKE operations failed to start or finished with an error.",
+		},
+		[]string{"operation"},
+	)
+	KubernetesAPIErrors = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "kubernetes_api_errors_total",
+			Help:      "Kubernetes API requests failed with a transport error, 429 or 5xx.",
+		},
+		[]string{"cluster", "code"},
+	)
+	WatcherSampleLag = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "watcher_sample_lag_seconds",
+			Help:      "Time between the watcher sample time and the sample being stored.",
+			Buckets:   []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60},
+		},
+		[]string{"kind"},
+	)
+	HTTPRequestDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "Latency of the HTTP requests per route.",
+			Buckets:   prometheus.DefBuckets,
+		},
+		[]string{"method", "route", "status"},
+	)
+)
+
+func ObservePhase(phase Phase, start time.Time) {
+	EventPhaseDuration.WithLabelValues(string(phase)).Observe(time.Since(start).Seconds())
+}
+
+func ObserveSampleLag(kind SampleKind, sampledAt time.Time) {
+	WatcherSampleLag.WithLabelValues(string(kind)).Observe(time.Since(sampledAt).Seconds())
+}
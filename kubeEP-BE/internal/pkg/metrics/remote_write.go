@@ -0,0 +1,170 @@
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/config"
+	"github.com/klauspost/compress/snappy"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/encoding/protowire"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	defaultRemoteWriteInterval = 15 * time.Second
+	defaultRemoteWriteTimeout  = 10 * time.Second
+	remoteWriteMaxErrorBody    = 512
+)
+
+type remoteWriteLabel struct {
+	name  string
+	value string
+}
+
+type remoteWriteSeries struct {
+	labels []remoteWriteLabel
+	value  float64
+}
+
+// RemoteWriter periodically pushes the watch gauges to a Prometheus remote write endpoint
+type RemoteWriter struct {
+	config   config.RemoteWriteConfig
+	gatherer prometheus.Gatherer
+	client   *http.Client
+	interval time.Duration
+}
+
+func NewRemoteWriter(remoteWriteConfig config.RemoteWriteConfig) *RemoteWriter {
+	interval := time.Duration(remoteWriteConfig.IntervalSeconds) * time.Second
+	if interval <= 0 {
+		interval = defaultRemoteWriteInterval
+	}
+	timeout := time.Duration(remoteWriteConfig.TimeoutSeconds) * time.Second
+	if timeout <= 0 {
+		timeout = defaultRemoteWriteTimeout
+	}
+	return &RemoteWriter{
+		config:   remoteWriteConfig,
+		gatherer: prometheus.DefaultGatherer,
+		client:   &http.Client{Timeout: timeout},
+		interval: interval,
+	}
+}
+
+func (w *RemoteWriter) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case now := <-ticker.C:
+			if err := w.push(ctx, now); err != nil {
+				log.Errorf("[Metrics] Error remote writing watch samples : %s", err.Error())
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (w *RemoteWriter) push(ctx context.Context, now time.Time) error {
+	families, err := w.gatherer.Gather()
+	if err != nil {
+		return err
+	}
+	series := w.collectSeries(families)
+	if len(series) == 0 {
+		return nil
+	}
+
+	body := snappy.Encode(nil, encodeWriteRequest(series, now.UnixMilli()))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.config.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Encoding", "snappy")
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("X-Prometheus-Remote-Write-Version", "0.1.0")
+	if w.config.BearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+w.config.BearerToken)
+	} else if w.config.Username != "" {
+		req.SetBasicAuth(w.config.Username, w.config.Password)
+	}
+
+	res, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode/100 != 2 {
+		message, _ := io.ReadAll(io.LimitReader(res.Body, remoteWriteMaxErrorBody))
+		return fmt.Errorf("remote write status %d : %s", res.StatusCode, strings.TrimSpace(string(message)))
+	}
+	return nil
+}
+
+func (w *RemoteWriter) collectSeries(families []*dto.MetricFamily) []remoteWriteSeries {
+	var output []remoteWriteSeries
+	for _, family := range families {
+		if !strings.HasPrefix(family.GetName(), WatchMetricPrefix) ||
+			family.GetType() != dto.MetricType_GAUGE {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			labels := []remoteWriteLabel{{name: "__name__", value: family.GetName()}}
+			for name, value := range w.config.ExternalLabels {
+				labels = append(labels, remoteWriteLabel{name: name, value: value})
+			}
+			for _, label := range metric.GetLabel() {
+				labels = append(labels, remoteWriteLabel{name: label.GetName(), value: label.GetValue()})
+			}
+			sort.Slice(
+				labels, func(i, j int) bool {
+					return labels[i].name < labels[j].name
+				},
+			)
+			output = append(
+				output, remoteWriteSeries{
+					labels: labels,
+					value:  metric.GetGauge().GetValue(),
+				},
+			)
+		}
+	}
+	return output
+}
+
+// encodeWriteRequest encodes the prometheus.WriteRequest protobuf message
+func encodeWriteRequest(series []remoteWriteSeries, timestamp int64) []byte {
+	var request []byte
+	for _, s := range series {
+		var timeSeries []byte
+		for _, label := range s.labels {
+			var labelMessage []byte
+			labelMessage = protowire.AppendTag(labelMessage, 1, protowire.BytesType)
+			labelMessage = protowire.AppendString(labelMessage, label.name)
+			labelMessage = protowire.AppendTag(labelMessage, 2, protowire.BytesType)
+			labelMessage = protowire.AppendString(labelMessage, label.value)
+			timeSeries = protowire.AppendTag(timeSeries, 1, protowire.BytesType)
+			timeSeries = protowire.AppendBytes(timeSeries, labelMessage)
+		}
+		var sample []byte
+		sample = protowire.AppendTag(sample, 1, protowire.Fixed64Type)
+		sample = protowire.AppendFixed64(sample, math.Float64bits(s.value))
+		sample = protowire.AppendTag(sample, 2, protowire.VarintType)
+		sample = protowire.AppendVarint(sample, uint64(timestamp))
+		timeSeries = protowire.AppendTag(timeSeries, 2, protowire.BytesType)
+		timeSeries = protowire.AppendBytes(timeSeries, sample)
+
+		request = protowire.AppendTag(request, 1, protowire.BytesType)
+		request = protowire.AppendBytes(request, timeSeries)
+	}
+	return request
+}
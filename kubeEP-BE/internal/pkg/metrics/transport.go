@@ -0,0 +1,33 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+)
+
+type kubernetesAPITransport struct {
+	cluster string
+	next    http.RoundTripper
+}
+
+// WrapKubernetesTransport counts the failed requests of the cluster api server
+func WrapKubernetesTransport(cluster string) func(rt http.RoundTripper) http.RoundTripper {
+	return func(rt http.RoundTripper) http.RoundTripper {
+		return &kubernetesAPITransport{cluster: cluster, next: rt}
+	}
+}
+
+func (t *kubernetesAPITransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	res, err := t.next.RoundTrip(req)
+	if err != nil {
+		// Cancelled requests are not api errors, informers cancel their watches on stop
+		if req.Context().Err() == nil {
+			KubernetesAPIErrors.WithLabelValues(t.cluster, "error").Inc()
+		}
+		return res, err
+	}
+	if res.StatusCode == http.StatusTooManyRequests || res.StatusCode >= http.StatusInternalServerError {
+		KubernetesAPIErrors.WithLabelValues(t.cluster, strconv.Itoa(res.StatusCode)).Inc()
+	}
+	return res, nil
+}
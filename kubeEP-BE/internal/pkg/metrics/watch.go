@@ -0,0 +1,259 @@
+package metrics
+
+import (
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository/model"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"strings"
+	"sync"
+)
+
+// WatchMetricPrefix is the name prefix of the watch sample gauges, the remote writer only pushes
+// these
+const WatchMetricPrefix = namespace + "_watch_"
+
+var (
+	hpaLabels      = []string{"event", "cluster", "namespace", "hpa"}
+	nodePoolLabels = []string{"event", "cluster", "node_pool"}
+)
+
+type hpaGauge struct {
+	vec   *prometheus.GaugeVec
+	value func(hpa *model.HPAStatus, pod *model.PodStatus) (float64, bool)
+}
+
+type nodePoolGauge struct {
+	vec   *prometheus.GaugeVec
+	value func(nodePool *model.NodePoolStatus) (float64, bool)
+}
+
+func newWatchGaugeVec(name, help string, labels []string) *prometheus.GaugeVec {
+	return promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "watch",
+			Name:      name,
+			Help:      help,
+		},
+		labels,
+	)
+}
+
+func newHPAGauge(
+	name, help string,
+	value func(hpa *model.HPAStatus, pod *model.PodStatus) (float64, bool),
+) hpaGauge {
+	return hpaGauge{vec: newWatchGaugeVec(name, help, hpaLabels), value: value}
+}
+
+func newNodePoolGauge(
+	name, help string,
+	value func(nodePool *model.NodePoolStatus) (float64, bool),
+) nodePoolGauge {
+	return nodePoolGauge{vec: newWatchGaugeVec(name, help, nodePoolLabels), value: value}
+}
+
+func podValue(value func(pod *model.PodStatus) float64) func(
+	*model.HPAStatus,
+	*model.PodStatus,
+) (float64, bool) {
+	return func(_ *model.HPAStatus, pod *model.PodStatus) (float64, bool) {
+		if pod == nil {
+			return 0, false
+		}
+		return value(pod), true
+	}
+}
+
+func hpaValue(value func(hpa *model.HPAStatus) float64) func(
+	*model.HPAStatus,
+	*model.PodStatus,
+) (float64, bool) {
+	return func(hpa *model.HPAStatus, _ *model.PodStatus) (float64, bool) {
+		return value(hpa), true
+	}
+}
+
+var (
+	hpaGauges = []hpaGauge{
+		newHPAGauge(
+			"replicas", "Replicas of the hpa target.",
+			hpaValue(func(hpa *model.HPAStatus) float64 { return float64(hpa.Replicas) }),
+		),
+		newHPAGauge(
+			"ready_replicas", "Ready replicas of the hpa target.",
+			hpaValue(func(hpa *model.HPAStatus) float64 { return float64(hpa.ReadyReplicas) }),
+		),
+		newHPAGauge(
+			"available_replicas", "Available replicas of the hpa target.",
+			hpaValue(func(hpa *model.HPAStatus) float64 { return float64(hpa.AvailableReplicas) }),
+		),
+		newHPAGauge(
+			"unavailable_replicas", "Unavailable replicas of the hpa target.",
+			hpaValue(func(hpa *model.HPAStatus) float64 { return float64(hpa.UnavailableReplicas) }),
+		),
+		newHPAGauge(
+			"hpa_min_replicas", "Min replicas of the hpa.",
+			hpaValue(func(hpa *model.HPAStatus) float64 { return float64(hpa.HPAMinReplicas) }),
+		),
+		newHPAGauge(
+			"hpa_max_replicas", "Max replicas of the hpa.",
+			hpaValue(func(hpa *model.HPAStatus) float64 { return float64(hpa.HPAMaxReplicas) }),
+		),
+		newHPAGauge(
+			"hpa_desired_replicas", "Desired replicas of the hpa.",
+			hpaValue(func(hpa *model.HPAStatus) float64 { return float64(hpa.HPADesiredReplicas) }),
+		),
+		newHPAGauge(
+			"hpa_scaling_limited", "1 when the hpa desired replicas are limited by min or max replicas.",
+			hpaValue(
+				func(hpa *model.HPAStatus) float64 {
+					if hpa.ScalingLimited {
+						return 1
+					}
+					return 0
+				},
+			),
+		),
+		newHPAGauge(
+			"pending_pods", "Pending pods of the hpa target.",
+			podValue(func(pod *model.PodStatus) float64 { return float64(pod.PendingPods) }),
+		),
+		newHPAGauge(
+			"unschedulable_pods", "Unschedulable pods of the hpa target.",
+			podValue(func(pod *model.PodStatus) float64 { return float64(pod.UnschedulablePods) }),
+		),
+		newHPAGauge(
+			"restart_count", "Container restarts of the hpa target pods.",
+			podValue(func(pod *model.PodStatus) float64 { return float64(pod.RestartCount) }),
+		),
+		newHPAGauge(
+			"max_startup_latency_seconds", "Max startup latency of the pods started since the previous sample.",
+			podValue(func(pod *model.PodStatus) float64 { return pod.MaxStartupLatencySeconds }),
+		),
+	}
+	nodePoolGauges = []nodePoolGauge{
+		newNodePoolGauge(
+			"node_pool_node_count", "Nodes of the node pool.",
+			func(nodePool *model.NodePoolStatus) (float64, bool) {
+				return float64(nodePool.NodeCount), true
+			},
+		),
+		newNodePoolGauge(
+			"node_pool_allocatable_cpu_millicores", "Allocatable cpu of the node pool.",
+			func(nodePool *model.NodePoolStatus) (float64, bool) {
+				return float64(nodePool.AllocatableCPU), true
+			},
+		),
+		newNodePoolGauge(
+			"node_pool_allocatable_memory_bytes", "Allocatable memory of the node pool.",
+			func(nodePool *model.NodePoolStatus) (float64, bool) {
+				return float64(nodePool.AllocatableMemory), true
+			},
+		),
+		newNodePoolGauge(
+			"node_pool_requested_cpu_millicores", "Cpu requested by the pods on the node pool.",
+			func(nodePool *model.NodePoolStatus) (float64, bool) {
+				return float64(nodePool.RequestedCPU), true
+			},
+		),
+		newNodePoolGauge(
+			"node_pool_requested_memory_bytes", "Memory requested by the pods on the node pool.",
+			func(nodePool *model.NodePoolStatus) (float64, bool) {
+				return float64(nodePool.RequestedMemory), true
+			},
+		),
+		newNodePoolGauge(
+			"node_pool_usage_cpu_millicores", "Cpu usage of the node pool.",
+			func(nodePool *model.NodePoolStatus) (float64, bool) {
+				if nodePool.UsageCPU == nil {
+					return 0, false
+				}
+				return float64(*nodePool.UsageCPU), true
+			},
+		),
+		newNodePoolGauge(
+			"node_pool_usage_memory_bytes", "Memory usage of the node pool.",
+			func(nodePool *model.NodePoolStatus) (float64, bool) {
+				if nodePool.UsageMemory == nil {
+					return 0, false
+				}
+				return float64(*nodePool.UsageMemory), true
+			},
+		),
+	}
+)
+
+// watchSeries keeps the label values of every series set for an event, so the series are dropped
+// once the event is not watched anymore
+var watchSeries = struct {
+	sync.Mutex
+	hpa      map[string]map[string][]string
+	nodePool map[string]map[string][]string
+}{
+	hpa:      map[string]map[string][]string{},
+	nodePool: map[string]map[string][]string{},
+}
+
+func trackSeries(series map[string]map[string][]string, event string, labelValues []string) {
+	eventSeries, ok := series[event]
+	if !ok {
+		eventSeries = map[string][]string{}
+		series[event] = eventSeries
+	}
+	eventSeries[strings.Join(labelValues, "\x00")] = labelValues
+}
+
+// SetHPAWatchSample sets the hpa gauges to the latest sample, pod is nil when the pod statistic is
+// not available
+func SetHPAWatchSample(
+	event, cluster, namespace, hpa string,
+	hpaStatus *model.HPAStatus,
+	podStatus *model.PodStatus,
+) {
+	labelValues := []string{event, cluster, namespace, hpa}
+	watchSeries.Lock()
+	defer watchSeries.Unlock()
+	trackSeries(watchSeries.hpa, event, labelValues)
+	for _, gauge := range hpaGauges {
+		value, ok := gauge.value(hpaStatus, podStatus)
+		if !ok {
+			gauge.vec.DeleteLabelValues(labelValues...)
+			continue
+		}
+		gauge.vec.WithLabelValues(labelValues...).Set(value)
+	}
+}
+
+func SetNodePoolWatchSample(event, cluster, nodePool string, status *model.NodePoolStatus) {
+	labelValues := []string{event, cluster, nodePool}
+	watchSeries.Lock()
+	defer watchSeries.Unlock()
+	trackSeries(watchSeries.nodePool, event, labelValues)
+	for _, gauge := range nodePoolGauges {
+		value, ok := gauge.value(status)
+		if !ok {
+			gauge.vec.DeleteLabelValues(labelValues...)
+			continue
+		}
+		gauge.vec.WithLabelValues(labelValues...).Set(value)
+	}
+}
+
+// DeleteEventWatchSeries drops every watch gauge series of the event
+func DeleteEventWatchSeries(event string) {
+	watchSeries.Lock()
+	defer watchSeries.Unlock()
+	for _, labelValues := range watchSeries.hpa[event] {
+		for _, gauge := range hpaGauges {
+			gauge.vec.DeleteLabelValues(labelValues...)
+		}
+	}
+	for _, labelValues := range watchSeries.nodePool[event] {
+		for _, gauge := range nodePoolGauges {
+			gauge.vec.DeleteLabelValues(labelValues...)
+		}
+	}
+	delete(watchSeries.hpa, event)
+	delete(watchSeries.nodePool, event)
+}
@@ -0,0 +1,101 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/config"
+	"io"
+	"net"
+	"net/http"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	SignatureHeader = "X-KubeEP-Signature"
+	TimestampHeader = "X-KubeEP-Timestamp"
+	maxErrorBody    = 512
+)
+
+// Sign returns the hex hmac sha256 of "<timestamp>.<payload>", receivers recompute it with the
+// shared secret and the timestamp header to verify the webhook
+func Sign(secret string, timestamp int64, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// SendWebhook posts the json payload, the signature header is only set when secret is not empty
+func SendWebhook(ctx context.Context, client *http.Client, url, secret string, payload []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if secret != "" {
+		timestamp := time.Now().Unix()
+		req.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+		req.Header.Set(SignatureHeader, "sha256="+Sign(secret, timestamp, payload))
+	}
+	return doRequest(client, req)
+}
+
+func SendSlack(ctx context.Context, client *http.Client, webhookURL, text string) error {
+	payload, err := json.Marshal(map[string]string{"text": text})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return doRequest(client, req)
+}
+
+func SendEmail(smtpConfig config.SMTPConfig, recipients []string, subject, body string) error {
+	if smtpConfig.Host == "" || smtpConfig.From == "" {
+		return errors.New("smtp server is not configured")
+	}
+	var auth smtp.Auth
+	if smtpConfig.Username != "" {
+		auth = smtp.PlainAuth("", smtpConfig.Username, smtpConfig.Password, smtpConfig.Host)
+	}
+	message := &bytes.Buffer{}
+	fmt.Fprintf(message, "From: %s\r\n", smtpConfig.From)
+	fmt.Fprintf(message, "To: %s\r\n", strings.Join(recipients, ", "))
+	fmt.Fprintf(message, "Subject: %s\r\n", strings.NewReplacer("\r", "", "\n", " ").Replace(subject))
+	message.WriteString("MIME-Version: 1.0\r\n")
+	message.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	message.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+	return smtp.SendMail(
+		net.JoinHostPort(smtpConfig.Host, smtpConfig.Port),
+		auth,
+		smtpConfig.From,
+		recipients,
+		message.Bytes(),
+	)
+}
+
+func doRequest(client *http.Client, req *http.Request) error {
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode/100 != 2 {
+		message, _ := io.ReadAll(io.LimitReader(res.Body, maxErrorBody))
+		return fmt.Errorf("status %d : %s", res.StatusCode, strings.TrimSpace(string(message)))
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+package openapi
+
+import (
+	"fmt"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+const (
+	Version        = "3.0.3"
+	bearerAuthName = "bearerAuth"
+	errorComponent = "ErrorResponse"
+)
+
+var pathParamPattern = regexp.MustCompile(`:([a-zA-Z0-9_]+)`)
+
+// Builder describes the routes with the request and response entities of the handlers, so the
+// schemas follow the entities
+type Builder struct {
+	doc            *Document
+	componentNames map[reflect.Type]string
+	enums          map[reflect.Type]*Schema
+}
+
+func NewBuilder(info Info) *Builder {
+	b := &Builder{
+		doc: &Document{
+			OpenAPI: Version,
+			Info:    info,
+			Paths:   map[string]PathItem{},
+			Components: Components{
+				Schemas: map[string]*Schema{},
+				SecuritySchemes: map[string]SecurityScheme{
+					bearerAuthName: {
+						Type:        "http",
+						Scheme:      "bearer",
+						Description: "Required when the api has auth tokens",
+					},
+				},
+			},
+			Security: []map[string][]string{{bearerAuthName: {}}},
+		},
+		componentNames: map[reflect.Type]string{},
+		enums:          map[reflect.Type]*Schema{},
+	}
+	b.Enum(
+		constant.ResponseStatus(""),
+		constant.Success,
+		constant.Error,
+		constant.Fail,
+	)
+	b.doc.Components.Schemas[errorComponent] = b.Envelope(
+		&Schema{Description: "Error message or structured result of the failed request"},
+	)
+	return b
+}
+
+func (b *Builder) Tag(name, description string) {
+	b.doc.Tags = append(b.doc.Tags, Tag{Name: name, Description: description})
+}
+
+// Enum describes the named string type of value with its values
+func (b *Builder) Enum(value interface{}, values ...interface{}) {
+	schema := &Schema{Type: "string"}
+	for _, v := range values {
+		schema.Enum = append(schema.Enum, fmt.Sprint(v))
+	}
+	b.enums[reflect.TypeOf(value)] = schema
+}
+
+func (b *Builder) Schema(value interface{}) *Schema {
+	return b.schemaOf(reflect.TypeOf(value))
+}
+
+func (b *Builder) QueryParameters(value interface{}) []Parameter {
+	return b.queryParameters(reflect.TypeOf(value))
+}
+
+func (b *Builder) JSONBody(value interface{}) *RequestBody {
+	return &RequestBody{
+		Required: true,
+		Content:  map[string]MediaType{"application/json": {Schema: b.Schema(value)}},
+	}
+}
+
+// Envelope wraps data in the base response of the handlers
+func (b *Builder) Envelope(data *Schema) *Schema {
+	return &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"status": b.Schema(constant.ResponseStatus("")),
+			"code":   {Type: "integer", Format: "int64"},
+			"data":   data,
+		},
+		Required: []string{"status", "data"},
+	}
+}
+
+// ErrorResponses are answered by every route, the unauthorized response only when the api has
+// auth tokens
+func (b *Builder) ErrorResponses() map[string]Response {
+	errorContent := map[string]MediaType{
+		"application/json": {Schema: &Schema{Ref: componentRef(errorComponent)}},
+	}
+	return map[string]Response{
+		"400": {Description: "Invalid request", Content: errorContent},
+		"401": {Description: "Missing or invalid bearer token", Content: errorContent},
+	}
+}
+
+// JSONResponses answers value as the data of the base response
+func (b *Builder) JSONResponses(value interface{}) map[string]Response {
+	responses := b.ErrorResponses()
+	responses["200"] = Response{
+		Description: "Success",
+		Content: map[string]MediaType{
+			"application/json": {Schema: b.Envelope(b.Schema(value))},
+		},
+	}
+	return responses
+}
+
+// Add registers the operation of a fiber route, the path parameters are uuids in the kubeEP api
+func (b *Builder) Add(method, path string, operation *Operation) {
+	var parameters []Parameter
+	for _, match := range pathParamPattern.FindAllStringSubmatch(path, -1) {
+		parameters = append(
+			parameters, Parameter{
+				Name:     match[1],
+				In:       "path",
+				Required: true,
+				Schema:   &Schema{Type: "string", Format: "uuid"},
+			},
+		)
+	}
+	operation.Parameters = append(parameters, operation.Parameters...)
+
+	path = pathParamPattern.ReplaceAllString(path, "{$1}")
+	if len(path) > 1 {
+		path = strings.TrimSuffix(path, "/")
+	}
+	item, ok := b.doc.Paths[path]
+	if !ok {
+		item = PathItem{}
+		b.doc.Paths[path] = item
+	}
+	item[strings.ToLower(method)] = operation
+}
+
+func (b *Builder) Document() *Document {
+	return b.doc
+}
+
+// QueryParameter describes a query parameter outside of the request entities (e.g. format)
+func QueryParameter(name, description string, values ...string) Parameter {
+	return Parameter{
+		Name:        name,
+		In:          "query",
+		Description: description,
+		Schema:      &Schema{Type: "string", Enum: values},
+	}
+}
+
+// RawResponse answers a document outside of the base response in one of the content types
+func RawResponse(description string, contentTypes ...string) Response {
+	content := map[string]MediaType{}
+	for _, contentType := range contentTypes {
+		content[contentType] = MediaType{Schema: &Schema{Type: "string"}}
+	}
+	return Response{Description: description, Content: content}
+}
@@ -0,0 +1,50 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	Path          = "/openapi.json"
+	SwaggerUIPath = "/swagger"
+)
+
+// The assets of the Swagger UI are loaded from the CDN, the api does not ship them
+const swaggerUIPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8"/>
+  <title>kubeEP API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@4/swagger-ui.css"/>
+</head>
+<body>
+<div id="swagger-ui"></div>
+<script src="https://unpkg.com/swagger-ui-dist@4/swagger-ui-bundle.js"></script>
+<script>
+  window.ui = SwaggerUIBundle({url: %q, dom_id: "#swagger-ui"});
+</script>
+</body>
+</html>
+`
+
+// FiberHandler serves the document, it is encoded once since the routes do not change
+func FiberHandler(doc *Document) (fiber.Handler, error) {
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	return func(c *fiber.Ctx) error {
+		c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		return c.Send(data)
+	}, nil
+}
+
+func SwaggerUIHandler() fiber.Handler {
+	page := fmt.Sprintf(swaggerUIPage, Path)
+	return func(c *fiber.Ctx) error {
+		c.Set(fiber.HeaderContentType, fiber.MIMETextHTMLCharsetUTF8)
+		return c.SendString(page)
+	}
+}
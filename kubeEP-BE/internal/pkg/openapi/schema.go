@@ -0,0 +1,188 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"reflect"
+	"strings"
+	"time"
+	"unicode"
+)
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	uuidType       = reflect.TypeOf(uuid.UUID{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+)
+
+// schemaOf describes t with the json names of its fields, the structs are registered as components.
+// The required fields and enums are read from the validator tags of the request entities
+func (b *Builder) schemaOf(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if schema, ok := b.enums[t]; ok {
+		return b.component(t, func() *Schema { return schema })
+	}
+
+	switch t {
+	case timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case uuidType:
+		return &Schema{Type: "string", Format: "uuid"}
+	case rawMessageType:
+		return &Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: b.schemaOf(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: b.schemaOf(t.Elem())}
+	case reflect.Struct:
+		return b.component(t, func() *Schema { return b.structSchema(t) })
+	default:
+		return &Schema{}
+	}
+}
+
+// component registers the schema once and refers to it, a type named like an already registered
+// type of another package is suffixed with its package name (e.g. EventDocumentRequest)
+func (b *Builder) component(t reflect.Type, build func() *Schema) *Schema {
+	if name, ok := b.componentNames[t]; ok {
+		return &Schema{Ref: componentRef(name)}
+	}
+	name := t.Name()
+	if _, taken := b.doc.Components.Schemas[name]; taken {
+		pkgPath := strings.Split(t.PkgPath(), "/")
+		pkgName := []rune(pkgPath[len(pkgPath)-1])
+		pkgName[0] = unicode.ToUpper(pkgName[0])
+		name += string(pkgName)
+	}
+	b.componentNames[t] = name
+	// The placeholder stops the recursion of self referencing types
+	b.doc.Components.Schemas[name] = &Schema{}
+	*b.doc.Components.Schemas[name] = *build()
+	return &Schema{Ref: componentRef(name)}
+}
+
+func (b *Builder) structSchema(t reflect.Type) *Schema {
+	schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	b.addStructFields(schema, t)
+	return schema
+}
+
+// addStructFields adds the fields of t, the fields of the embedded structs are flattened like
+// encoding/json does
+func (b *Builder) addStructFields(schema *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, ok := jsonName(field)
+		if !ok {
+			continue
+		}
+		if field.Anonymous && field.Tag.Get("json") == "" {
+			embedded := field.Type
+			for embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				b.addStructFields(schema, embedded)
+				continue
+			}
+		}
+
+		fieldRules, itemRules := validateRules(field.Tag.Get("validate"))
+		fieldSchema := b.schemaOf(field.Type)
+		if values := oneOf(fieldRules); values != nil {
+			fieldSchema = &Schema{Type: "string", Enum: values}
+		} else if values := oneOf(itemRules); values != nil && fieldSchema.Type == "array" {
+			fieldSchema = &Schema{Type: "array", Items: &Schema{Type: "string", Enum: values}}
+		}
+		schema.Properties[name] = fieldSchema
+		for _, rule := range fieldRules {
+			if rule == "required" {
+				schema.Required = append(schema.Required, name)
+			}
+		}
+	}
+}
+
+func jsonName(field reflect.StructField) (string, bool) {
+	if field.PkgPath != "" && !field.Anonymous {
+		return "", false
+	}
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false
+	}
+	name := strings.Split(tag, ",")[0]
+	if name == "" {
+		name = field.Name
+	}
+	return name, true
+}
+
+// validateRules splits the rules of the field from the rules of its items (after dive)
+func validateRules(tag string) ([]string, []string) {
+	if tag == "" {
+		return nil, nil
+	}
+	rules := strings.Split(tag, ",")
+	for i, rule := range rules {
+		if rule == "dive" {
+			return rules[:i], rules[i+1:]
+		}
+	}
+	return rules, nil
+}
+
+func oneOf(rules []string) []string {
+	for _, rule := range rules {
+		if strings.HasPrefix(rule, "oneof=") {
+			return strings.Fields(strings.TrimPrefix(rule, "oneof="))
+		}
+	}
+	return nil
+}
+
+// queryParameters describes the fields with a query tag of a request entity
+func (b *Builder) queryParameters(t reflect.Type) []Parameter {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	var parameters []Parameter
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := field.Tag.Get("query")
+		if name == "" {
+			continue
+		}
+		fieldRules, _ := validateRules(field.Tag.Get("validate"))
+		parameter := Parameter{Name: name, In: "query", Schema: b.schemaOf(field.Type)}
+		for _, rule := range fieldRules {
+			if rule == "required" {
+				parameter.Required = true
+			}
+		}
+		parameters = append(parameters, parameter)
+	}
+	return parameters
+}
+
+func componentRef(name string) string {
+	return fmt.Sprintf("#/components/schemas/%s", name)
+}
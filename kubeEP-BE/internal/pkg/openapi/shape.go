@@ -0,0 +1,143 @@
+package openapi
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// CompareShapes reports the first difference between the json encodings of a and b: the json names
+// of the fields, their omitempty option and the kind of their values. The named types are compared
+// by their kind, so a copy of an entity with plain strings matches the enum types of the entity
+func CompareShapes(a, b interface{}) error {
+	return compareShapes(reflect.TypeOf(a), reflect.TypeOf(b), "", map[[2]reflect.Type]bool{})
+}
+
+func compareShapes(a, b reflect.Type, path string, seen map[[2]reflect.Type]bool) error {
+	for a.Kind() == reflect.Ptr {
+		a = a.Elem()
+	}
+	for b.Kind() == reflect.Ptr {
+		b = b.Elem()
+	}
+	if seen[[2]reflect.Type{a, b}] {
+		return nil
+	}
+	seen[[2]reflect.Type{a, b}] = true
+
+	kindA, kindB := shapeKind(a), shapeKind(b)
+	if kindA != kindB {
+		return fmt.Errorf("%s : %s is %s, %s is %s", shapePath(path), a, kindA, b, kindB)
+	}
+	switch kindA {
+	case "array", "map":
+		return compareShapes(a.Elem(), b.Elem(), path+"[]", seen)
+	case "object":
+		fieldsA, fieldsB := map[string]shapeField{}, map[string]shapeField{}
+		collectShapeFields(a, fieldsA)
+		collectShapeFields(b, fieldsB)
+		for _, name := range shapeFieldNames(fieldsA, fieldsB) {
+			fieldA, okA := fieldsA[name]
+			fieldB, okB := fieldsB[name]
+			if !okA || !okB {
+				return fmt.Errorf("%s : field %s is missing in %s", shapePath(path), name, missingIn(a, b, okA))
+			}
+			if fieldA.omitEmpty != fieldB.omitEmpty {
+				return fmt.Errorf("%s : field %s has a different omitempty", shapePath(path), name)
+			}
+			if err := compareShapes(fieldA.t, fieldB.t, path+"."+name, seen); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+type shapeField struct {
+	t         reflect.Type
+	omitEmpty bool
+}
+
+// collectShapeFields flattens the embedded structs like encoding/json does
+func collectShapeFields(t reflect.Type, fields map[string]shapeField) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, ok := jsonName(field)
+		if !ok {
+			continue
+		}
+		if field.Anonymous && field.Tag.Get("json") == "" {
+			embedded := field.Type
+			for embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				collectShapeFields(embedded, fields)
+				continue
+			}
+		}
+		fields[name] = shapeField{
+			t:         field.Type,
+			omitEmpty: strings.Contains(field.Tag.Get("json"), ",omitempty"),
+		}
+	}
+}
+
+func shapeKind(t reflect.Type) string {
+	switch t {
+	case timeType:
+		return "time"
+	case uuidType:
+		return "uuid"
+	case rawMessageType:
+		return "raw"
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.String:
+		return "string"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	case reflect.Map:
+		return "map"
+	case reflect.Struct:
+		return "object"
+	default:
+		return t.Kind().String()
+	}
+}
+
+func shapeFieldNames(fieldsA, fieldsB map[string]shapeField) []string {
+	var names []string
+	for name := range fieldsA {
+		names = append(names, name)
+	}
+	for name := range fieldsB {
+		if _, ok := fieldsA[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func missingIn(a, b reflect.Type, inA bool) reflect.Type {
+	if inA {
+		return b
+	}
+	return a
+}
+
+func shapePath(path string) string {
+	if path == "" {
+		return "$"
+	}
+	return "$" + path
+}
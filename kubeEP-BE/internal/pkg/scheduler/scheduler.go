@@ -0,0 +1,336 @@
+package scheduler
+
+import (
+	v1 "k8s.io/api/core/v1"
+	v1Meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sort"
+)
+
+const hostnameTopologyKey = "kubernetes.io/hostname"
+
+type NodePool struct {
+	Name string
+	// Allocatable resources of a single node, after daemonsets
+	Allocatable v1.ResourceList
+	// MaxPods of a single node, after daemonsets
+	MaxPods int64
+}
+
+type AntiAffinityTerm struct {
+	Selector   labels.Selector
+	Namespaces map[string]bool
+}
+
+type SpreadConstraint struct {
+	MaxSkew  int32
+	Selector labels.Selector
+}
+
+type Workload struct {
+	Name      string
+	Namespace string
+	Labels    labels.Set
+	Requests  v1.ResourceList
+	Replicas  int32
+	// NodePools the workload can be scheduled on, ordered by preference
+	NodePools         []string
+	AntiAffinityTerms []AntiAffinityTerm
+	SpreadConstraints []SpreadConstraint
+}
+
+type NodePoolResult struct {
+	NodeCount int
+	PodCount  int64
+	Requested v1.ResourceList
+}
+
+type Result struct {
+	NodePools map[string]*NodePoolResult
+	// Unschedulable maps workload name to the number of replicas not fitting in any node pool
+	Unschedulable map[string]int32
+}
+
+type pod struct {
+	workload *Workload
+	size     float64
+}
+
+type node struct {
+	pool      *NodePool
+	free      v1.ResourceList
+	freePods  int64
+	workloads []*Workload
+}
+
+// NewWorkload builds the scheduling constraints of a pod template, only the hostname topology is
+// simulated for pod anti affinity and topology spread constraints
+func NewWorkload(
+	name, namespace string,
+	template *v1.PodTemplateSpec,
+	requests v1.ResourceList,
+	replicas int32,
+	nodePools []string,
+) (*Workload, error) {
+	workload := &Workload{
+		Name:      name,
+		Namespace: namespace,
+		Labels:    template.Labels,
+		Requests:  requests,
+		Replicas:  replicas,
+		NodePools: nodePools,
+	}
+
+	spec := template.Spec
+	if spec.Affinity != nil && spec.Affinity.PodAntiAffinity != nil {
+		for _, term := range spec.Affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution {
+			if term.TopologyKey != hostnameTopologyKey {
+				continue
+			}
+			selector, err := v1Meta.LabelSelectorAsSelector(term.LabelSelector)
+			if err != nil {
+				return nil, err
+			}
+			namespaces := map[string]bool{}
+			for _, ns := range term.Namespaces {
+				namespaces[ns] = true
+			}
+			if len(namespaces) == 0 {
+				namespaces[namespace] = true
+			}
+			workload.AntiAffinityTerms = append(
+				workload.AntiAffinityTerms, AntiAffinityTerm{
+					Selector:   selector,
+					Namespaces: namespaces,
+				},
+			)
+		}
+	}
+
+	for _, constraint := range spec.TopologySpreadConstraints {
+		if constraint.TopologyKey != hostnameTopologyKey ||
+			constraint.WhenUnsatisfiable != v1.DoNotSchedule {
+			continue
+		}
+		selector, err := v1Meta.LabelSelectorAsSelector(constraint.LabelSelector)
+		if err != nil {
+			return nil, err
+		}
+		workload.SpreadConstraints = append(
+			workload.SpreadConstraints, SpreadConstraint{
+				MaxSkew:  constraint.MaxSkew,
+				Selector: selector,
+			},
+		)
+	}
+
+	return workload, nil
+}
+
+// Simulate places every replica onto virtual nodes with first-fit-decreasing. A replica goes to the
+// first existing node that fits in its preferred node pools, otherwise a new node is opened in the
+// first node pool able to hold it.
+func Simulate(nodePools []NodePool, workloads []*Workload) *Result {
+	result := &Result{
+		NodePools:     map[string]*NodePoolResult{},
+		Unschedulable: map[string]int32{},
+	}
+	nodePoolMap := map[string]*NodePool{}
+	for idx := range nodePools {
+		nodePoolMap[nodePools[idx].Name] = &nodePools[idx]
+		result.NodePools[nodePools[idx].Name] = &NodePoolResult{Requested: v1.ResourceList{}}
+	}
+
+	var pods []pod
+	for _, workload := range workloads {
+		size := podSize(workload, nodePoolMap)
+		for i := int32(0); i < workload.Replicas; i++ {
+			pods = append(pods, pod{workload: workload, size: size})
+		}
+	}
+	sort.SliceStable(
+		pods, func(i, j int) bool {
+			return pods[i].size > pods[j].size
+		},
+	)
+
+	nodes := map[string][]*node{}
+	for _, p := range pods {
+		target := findNode(p.workload, nodes)
+		if target == nil {
+			target = openNode(p.workload, nodePoolMap, nodes)
+		}
+		if target == nil {
+			result.Unschedulable[p.workload.Name] += 1
+			continue
+		}
+		target.place(p.workload)
+
+		poolResult := result.NodePools[target.pool.Name]
+		poolResult.PodCount += 1
+		addResources(poolResult.Requested, p.workload.Requests)
+	}
+
+	for poolName, poolNodes := range nodes {
+		result.NodePools[poolName].NodeCount = len(poolNodes)
+	}
+	return result
+}
+
+func findNode(workload *Workload, nodes map[string][]*node) *node {
+	for _, poolName := range workload.NodePools {
+		poolNodes := nodes[poolName]
+		minMatches := map[int]int{}
+		for idx, constraint := range workload.SpreadConstraints {
+			minMatches[idx] = minSpreadMatches(constraint, workload.Namespace, poolNodes)
+		}
+		for _, n := range poolNodes {
+			if n.fits(workload) && n.satisfySpread(workload, minMatches) {
+				return n
+			}
+		}
+	}
+	return nil
+}
+
+func openNode(
+	workload *Workload,
+	nodePoolMap map[string]*NodePool,
+	nodes map[string][]*node,
+) *node {
+	for _, poolName := range workload.NodePools {
+		nodePool, ok := nodePoolMap[poolName]
+		if !ok {
+			continue
+		}
+		n := &node{
+			pool:     nodePool,
+			free:     nodePool.Allocatable.DeepCopy(),
+			freePods: nodePool.MaxPods,
+		}
+		if !n.fits(workload) {
+			continue
+		}
+		nodes[poolName] = append(nodes[poolName], n)
+		return n
+	}
+	return nil
+}
+
+func (n *node) fits(workload *Workload) bool {
+	if n.freePods < 1 {
+		return false
+	}
+	for resourceName, quantity := range workload.Requests {
+		if quantity.Sign() <= 0 {
+			continue
+		}
+		// Resources unknown to the node (e.g. ephemeral storage of a template node) are not limiting
+		free, ok := n.free[resourceName]
+		if !ok {
+			continue
+		}
+		if free.Cmp(quantity) < 0 {
+			return false
+		}
+	}
+
+	for _, existing := range n.workloads {
+		if violateAntiAffinity(workload, existing) || violateAntiAffinity(existing, workload) {
+			return false
+		}
+	}
+	return true
+}
+
+func (n *node) satisfySpread(workload *Workload, minMatches map[int]int) bool {
+	for idx, constraint := range workload.SpreadConstraints {
+		matches := n.countMatches(constraint.Selector, workload.Namespace)
+		if matches+1-minMatches[idx] > int(constraint.MaxSkew) {
+			return false
+		}
+	}
+	return true
+}
+
+func (n *node) countMatches(selector labels.Selector, namespace string) int {
+	count := 0
+	for _, existing := range n.workloads {
+		if existing.Namespace == namespace && selector.Matches(existing.Labels) {
+			count += 1
+		}
+	}
+	return count
+}
+
+func (n *node) place(workload *Workload) {
+	n.freePods -= 1
+	for resourceName, quantity := range workload.Requests {
+		free, ok := n.free[resourceName]
+		if !ok {
+			continue
+		}
+		free.Sub(quantity)
+		n.free[resourceName] = free
+	}
+	n.workloads = append(n.workloads, workload)
+}
+
+func minSpreadMatches(constraint SpreadConstraint, namespace string, nodes []*node) int {
+	minCount := -1
+	for _, n := range nodes {
+		count := n.countMatches(constraint.Selector, namespace)
+		if minCount == -1 || count < minCount {
+			minCount = count
+		}
+	}
+	if minCount == -1 {
+		return 0
+	}
+	return minCount
+}
+
+func violateAntiAffinity(workload, existing *Workload) bool {
+	for _, term := range workload.AntiAffinityTerms {
+		if term.Namespaces[existing.Namespace] && term.Selector.Matches(existing.Labels) {
+			return true
+		}
+	}
+	return false
+}
+
+// podSize is the largest share of a node the pod takes in its preferred node pool, used to sort
+// pods for first-fit-decreasing
+func podSize(workload *Workload, nodePoolMap map[string]*NodePool) float64 {
+	size := float64(0)
+	for _, poolName := range workload.NodePools {
+		nodePool, ok := nodePoolMap[poolName]
+		if !ok {
+			continue
+		}
+		for resourceName, quantity := range workload.Requests {
+			allocatable, ok := nodePool.Allocatable[resourceName]
+			if !ok || allocatable.Sign() <= 0 {
+				continue
+			}
+			share := quantity.AsApproximateFloat64() / allocatable.AsApproximateFloat64()
+			if share > size {
+				size = share
+			}
+		}
+		break
+	}
+	return size
+}
+
+func addResources(dst, src v1.ResourceList) {
+	for resourceName, quantity := range src {
+		existing, ok := dst[resourceName]
+		if !ok {
+			dst[resourceName] = quantity.DeepCopy()
+			continue
+		}
+		existing.Add(quantity)
+		dst[resourceName] = existing
+	}
+}
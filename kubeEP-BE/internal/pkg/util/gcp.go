@@ -0,0 +1,139 @@
+package util
+
+import (
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant"
+	"google.golang.org/genproto/googleapis/container/v1"
+	v1 "k8s.io/api/core/v1"
+	"math"
+	"net"
+	"strings"
+)
+
+// ParseGCPResourceURL maps each collection in a GCP resource url to its name,
+// e.g. ".../projects/p/zones/z/instanceGroupManagers/igm" gives projects=p, zones=z, instanceGroupManagers=igm
+func ParseGCPResourceURL(url string) map[string]string {
+	res := map[string]string{}
+	parts := strings.Split(url, "/")
+	for i := 0; i+1 < len(parts); i++ {
+		switch parts[i] {
+		case "projects", "zones", "regions", "global", "instanceGroupManagers",
+			"instanceTemplates", "machineTypes":
+			res[parts[i]] = parts[i+1]
+		}
+	}
+	return res
+}
+
+// CalculateGKEAllocatable estimates node allocatable cpu (cores) and memory (bytes) with the GKE
+// kube-reserved formula and the default 100MiB hard eviction threshold
+func CalculateGKEAllocatable(guestCPUs int32, memoryMB int32) (float64, float64) {
+	const mib = float64(1 << 20)
+	const gib = float64(1 << 30)
+
+	cpu := float64(guestCPUs)
+	reservedCPU := 0.06 * math.Min(cpu, 1)
+	if cpu > 1 {
+		reservedCPU += 0.01 * math.Min(cpu-1, 1)
+	}
+	if cpu > 2 {
+		reservedCPU += 0.005 * math.Min(cpu-2, 2)
+	}
+	if cpu > 4 {
+		reservedCPU += 0.0025 * (cpu - 4)
+	}
+
+	memory := float64(memoryMB) * mib
+	var reservedMemory float64
+	if memory < gib {
+		reservedMemory = 255 * mib
+	} else {
+		tiers := []struct {
+			limit, ratio float64
+		}{
+			{4 * gib, 0.25},
+			{8 * gib, 0.2},
+			{16 * gib, 0.1},
+			{128 * gib, 0.06},
+			{math.Inf(1), 0.02},
+		}
+		lower := float64(0)
+		for _, tier := range tiers {
+			if memory <= lower {
+				break
+			}
+			reservedMemory += (math.Min(memory, tier.limit) - lower) * tier.ratio
+			lower = tier.limit
+		}
+	}
+	evictionThreshold := 100 * mib
+
+	return cpu - reservedCPU, math.Max(memory-reservedMemory-evictionThreshold, 0)
+}
+
+// GCPRegionFromLocation returns the region of a GCP location, e.g. "us-central1-a" gives "us-central1"
+func GCPRegionFromLocation(location string) string {
+	parts := strings.Split(location, "-")
+	if len(parts) == 3 {
+		return strings.Join(parts[:2], "-")
+	}
+	return location
+}
+
+// GCPCPUsQuotaMetric returns the regional quota metric limiting the vCPUs of a machine type,
+// e.g. "n2-standard-4" gives "N2_CPUS". N1 and E2 machine types share the generic CPUS quota
+func GCPCPUsQuotaMetric(machineType string) string {
+	family := strings.ToUpper(strings.SplitN(machineType, "-", 2)[0])
+	switch family {
+	case "N1", "E2", "F1", "G1":
+		return constant.GCPCPUsQuotaMetric
+	}
+	return family + "_CPUS"
+}
+
+// CalculatePodCIDRNodeCapacity returns how many nodes fit in a pod ipv4 range when each node gets
+// a range with the given prefix size
+func CalculatePodCIDRNodeCapacity(podRange string, nodePrefixSize int32) (int64, error) {
+	_, ipNet, err := net.ParseCIDR(podRange)
+	if err != nil {
+		return 0, err
+	}
+	rangePrefixSize, _ := ipNet.Mask.Size()
+	if int(nodePrefixSize) < rangePrefixSize {
+		return 0, nil
+	}
+	return int64(1) << (int(nodePrefixSize) - rangePrefixSize), nil
+}
+
+// GKENodePoolConfigNode returns the labels and taints the node pool config puts on its nodes, the
+// labels only hold the config labels and the node pool label
+func GKENodePoolConfigNode(nodePool *container.NodePool) (map[string]string, []v1.Taint) {
+	nodeLabels := map[string]string{}
+	var taints []v1.Taint
+	if nodePool.Config != nil {
+		for key, value := range nodePool.Config.Labels {
+			nodeLabels[key] = value
+		}
+		for _, taint := range nodePool.Config.Taints {
+			var effect v1.TaintEffect
+			switch taint.Effect {
+			case container.NodeTaint_NO_SCHEDULE:
+				effect = v1.TaintEffectNoSchedule
+			case container.NodeTaint_PREFER_NO_SCHEDULE:
+				effect = v1.TaintEffectPreferNoSchedule
+			case container.NodeTaint_NO_EXECUTE:
+				effect = v1.TaintEffectNoExecute
+			default:
+				continue
+			}
+			taints = append(
+				taints, v1.Taint{
+					Key:    taint.Key,
+					Value:  taint.Value,
+					Effect: effect,
+				},
+			)
+		}
+	}
+	nodeLabels[constant.GCPNodePoolLabel] = nodePool.Name
+	return nodeLabels, taints
+}
@@ -0,0 +1,255 @@
+package util
+
+import (
+	"fmt"
+	"k8s.io/api/autoscaling/v2beta1"
+	"k8s.io/api/autoscaling/v2beta2"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// HPAMetricValue pairs the current value of an HPA metric with its target, values are formatted
+// like kubectl does, e.g. "75%" for utilization
+type HPAMetricValue struct {
+	Name    string
+	Current string
+	Target  string
+}
+
+func formatUtilization(utilization *int32) string {
+	if utilization == nil {
+		return ""
+	}
+	return fmt.Sprintf("%d%%", *utilization)
+}
+
+func formatQuantity(quantity *resource.Quantity) string {
+	if quantity == nil {
+		return ""
+	}
+	return quantity.String()
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+func mergeHPAMetricValues(targets, currents []HPAMetricValue) []HPAMetricValue {
+	var res []HPAMetricValue
+	index := map[string]int{}
+	for _, target := range targets {
+		index[target.Name] = len(res)
+		res = append(res, target)
+	}
+	for _, current := range currents {
+		if idx, ok := index[current.Name]; ok {
+			res[idx].Current = current.Current
+			continue
+		}
+		index[current.Name] = len(res)
+		res = append(res, current)
+	}
+	return res
+}
+
+func GetV2beta1HPAMetricValues(hpa *v2beta1.HorizontalPodAutoscaler) []HPAMetricValue {
+	var targets, currents []HPAMetricValue
+	for _, metric := range hpa.Spec.Metrics {
+		switch metric.Type {
+		case v2beta1.ResourceMetricSourceType:
+			targets = append(
+				targets, HPAMetricValue{
+					Name: fmt.Sprintf("resource/%s", metric.Resource.Name),
+					Target: firstNonEmpty(
+						formatUtilization(metric.Resource.TargetAverageUtilization),
+						formatQuantity(metric.Resource.TargetAverageValue),
+					),
+				},
+			)
+		case v2beta1.ContainerResourceMetricSourceType:
+			targets = append(
+				targets, HPAMetricValue{
+					Name: fmt.Sprintf(
+						"container-resource/%s/%s",
+						metric.ContainerResource.Container,
+						metric.ContainerResource.Name,
+					),
+					Target: firstNonEmpty(
+						formatUtilization(metric.ContainerResource.TargetAverageUtilization),
+						formatQuantity(metric.ContainerResource.TargetAverageValue),
+					),
+				},
+			)
+		case v2beta1.PodsMetricSourceType:
+			targets = append(
+				targets, HPAMetricValue{
+					Name:   fmt.Sprintf("pods/%s", metric.Pods.MetricName),
+					Target: metric.Pods.TargetAverageValue.String(),
+				},
+			)
+		case v2beta1.ObjectMetricSourceType:
+			targets = append(
+				targets, HPAMetricValue{
+					Name: fmt.Sprintf("object/%s", metric.Object.MetricName),
+					Target: firstNonEmpty(
+						formatQuantity(metric.Object.AverageValue),
+						metric.Object.TargetValue.String(),
+					),
+				},
+			)
+		case v2beta1.ExternalMetricSourceType:
+			targets = append(
+				targets, HPAMetricValue{
+					Name: fmt.Sprintf("external/%s", metric.External.MetricName),
+					Target: firstNonEmpty(
+						formatQuantity(metric.External.TargetAverageValue),
+						formatQuantity(metric.External.TargetValue),
+					),
+				},
+			)
+		}
+	}
+	for _, metric := range hpa.Status.CurrentMetrics {
+		switch metric.Type {
+		case v2beta1.ResourceMetricSourceType:
+			currents = append(
+				currents, HPAMetricValue{
+					Name: fmt.Sprintf("resource/%s", metric.Resource.Name),
+					Current: firstNonEmpty(
+						formatUtilization(metric.Resource.CurrentAverageUtilization),
+						metric.Resource.CurrentAverageValue.String(),
+					),
+				},
+			)
+		case v2beta1.ContainerResourceMetricSourceType:
+			currents = append(
+				currents, HPAMetricValue{
+					Name: fmt.Sprintf(
+						"container-resource/%s/%s",
+						metric.ContainerResource.Container,
+						metric.ContainerResource.Name,
+					),
+					Current: firstNonEmpty(
+						formatUtilization(metric.ContainerResource.CurrentAverageUtilization),
+						metric.ContainerResource.CurrentAverageValue.String(),
+					),
+				},
+			)
+		case v2beta1.PodsMetricSourceType:
+			currents = append(
+				currents, HPAMetricValue{
+					Name:    fmt.Sprintf("pods/%s", metric.Pods.MetricName),
+					Current: metric.Pods.CurrentAverageValue.String(),
+				},
+			)
+		case v2beta1.ObjectMetricSourceType:
+			currents = append(
+				currents, HPAMetricValue{
+					Name: fmt.Sprintf("object/%s", metric.Object.MetricName),
+					Current: firstNonEmpty(
+						formatQuantity(metric.Object.AverageValue),
+						metric.Object.CurrentValue.String(),
+					),
+				},
+			)
+		case v2beta1.ExternalMetricSourceType:
+			currents = append(
+				currents, HPAMetricValue{
+					Name: fmt.Sprintf("external/%s", metric.External.MetricName),
+					Current: firstNonEmpty(
+						formatQuantity(metric.External.CurrentAverageValue),
+						metric.External.CurrentValue.String(),
+					),
+				},
+			)
+		}
+	}
+	return mergeHPAMetricValues(targets, currents)
+}
+
+func formatV2beta2MetricTarget(target v2beta2.MetricTarget) string {
+	return firstNonEmpty(
+		formatUtilization(target.AverageUtilization),
+		formatQuantity(target.AverageValue),
+		formatQuantity(target.Value),
+	)
+}
+
+func formatV2beta2MetricValueStatus(current v2beta2.MetricValueStatus) string {
+	return firstNonEmpty(
+		formatUtilization(current.AverageUtilization),
+		formatQuantity(current.AverageValue),
+		formatQuantity(current.Value),
+	)
+}
+
+func GetV2beta2HPAMetricValues(hpa *v2beta2.HorizontalPodAutoscaler) []HPAMetricValue {
+	var targets, currents []HPAMetricValue
+	for _, metric := range hpa.Spec.Metrics {
+		var name string
+		var target v2beta2.MetricTarget
+		switch metric.Type {
+		case v2beta2.ResourceMetricSourceType:
+			name = fmt.Sprintf("resource/%s", metric.Resource.Name)
+			target = metric.Resource.Target
+		case v2beta2.ContainerResourceMetricSourceType:
+			name = fmt.Sprintf(
+				"container-resource/%s/%s",
+				metric.ContainerResource.Container,
+				metric.ContainerResource.Name,
+			)
+			target = metric.ContainerResource.Target
+		case v2beta2.PodsMetricSourceType:
+			name = fmt.Sprintf("pods/%s", metric.Pods.Metric.Name)
+			target = metric.Pods.Target
+		case v2beta2.ObjectMetricSourceType:
+			name = fmt.Sprintf("object/%s", metric.Object.Metric.Name)
+			target = metric.Object.Target
+		case v2beta2.ExternalMetricSourceType:
+			name = fmt.Sprintf("external/%s", metric.External.Metric.Name)
+			target = metric.External.Target
+		default:
+			continue
+		}
+		targets = append(
+			targets,
+			HPAMetricValue{Name: name, Target: formatV2beta2MetricTarget(target)},
+		)
+	}
+	for _, metric := range hpa.Status.CurrentMetrics {
+		var name string
+		var current v2beta2.MetricValueStatus
+		switch metric.Type {
+		case v2beta2.ResourceMetricSourceType:
+			name = fmt.Sprintf("resource/%s", metric.Resource.Name)
+			current = metric.Resource.Current
+		case v2beta2.ContainerResourceMetricSourceType:
+			name = fmt.Sprintf(
+				"container-resource/%s/%s",
+				metric.ContainerResource.Container,
+				metric.ContainerResource.Name,
+			)
+			current = metric.ContainerResource.Current
+		case v2beta2.PodsMetricSourceType:
+			name = fmt.Sprintf("pods/%s", metric.Pods.Metric.Name)
+			current = metric.Pods.Current
+		case v2beta2.ObjectMetricSourceType:
+			name = fmt.Sprintf("object/%s", metric.Object.Metric.Name)
+			current = metric.Object.Current
+		case v2beta2.ExternalMetricSourceType:
+			name = fmt.Sprintf("external/%s", metric.External.Metric.Name)
+			current = metric.External.Current
+		default:
+			continue
+		}
+		currents = append(
+			currents,
+			HPAMetricValue{Name: name, Current: formatV2beta2MetricValueStatus(current)},
+		)
+	}
+	return mergeHPAMetricValues(targets, currents)
+}
@@ -6,6 +6,7 @@ import (
 	v1Core "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/component-helpers/scheduling/corev1"
+	"k8s.io/component-helpers/scheduling/corev1/nodeaffinity"
 	"regexp"
 	"strings"
 )
@@ -18,8 +19,10 @@ func ParseGCPNodePoolByNodeName(projectName, nodeName string) string {
 
 func CheckPodNodePoolMatch(
 	nodeLabels labels.Set,
+	nodeTaints []v1.Taint,
 	podNodeAffinity *v1.NodeAffinity,
 	nodeSelector labels.Selector,
+	podTolerations []v1.Toleration,
 ) (res bool, err error) {
 	matchNodeSelector := nodeSelector.Matches(nodeLabels)
 	nodeData := &v1.Node{ObjectMeta: v1Core.ObjectMeta{Labels: nodeLabels}}
@@ -36,6 +39,49 @@ func CheckPodNodePoolMatch(
 		}
 	}
 
-	return matchNodeSelector && matchNodeAffinity, nil
+	_, hasUntoleratedTaint := corev1.FindMatchingUntoleratedTaint(
+		nodeTaints,
+		podTolerations,
+		func(t *v1.Taint) bool {
+			return t.Effect == v1.TaintEffectNoSchedule || t.Effect == v1.TaintEffectNoExecute
+		},
+	)
 
+	return matchNodeSelector && matchNodeAffinity && !hasUntoleratedTaint, nil
+
+}
+
+// CalculatePreferredAffinityScore sums the weight of preferred node affinity terms matching the
+// node labels
+func CalculatePreferredAffinityScore(
+	nodeLabels labels.Set,
+	podNodeAffinity *v1.NodeAffinity,
+) (int64, error) {
+	if podNodeAffinity == nil || len(podNodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution) == 0 {
+		return 0, nil
+	}
+	preferredTerms, err := nodeaffinity.NewPreferredSchedulingTerms(
+		podNodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution,
+	)
+	if err != nil {
+		return 0, err
+	}
+	nodeData := &v1.Node{ObjectMeta: v1Core.ObjectMeta{Labels: nodeLabels}}
+	return preferredTerms.Score(nodeData), nil
+}
+
+// FilterNodePoolTaints drops the taints kubernetes and the cluster autoscaler put on a node for
+// its condition, keeping only the taints coming from the node pool configuration
+func FilterNodePoolTaints(taints []v1.Taint) []v1.Taint {
+	var res []v1.Taint
+	for _, taint := range taints {
+		if strings.HasPrefix(taint.Key, "node.kubernetes.io/") ||
+			strings.HasPrefix(taint.Key, "node.cloudprovider.kubernetes.io/") ||
+			taint.Key == "ToBeDeletedByClusterAutoscaler" ||
+			taint.Key == "DeletionCandidateOfClusterAutoscaler" {
+			continue
+		}
+		res = append(res, taint)
+	}
+	return res
 }
@@ -0,0 +1,120 @@
+package util
+
+import (
+	"fmt"
+	"gopkg.in/inf.v0"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sort"
+	"strings"
+)
+
+// CalculateContainerRequests returns the effective requests of a container, a resource with only
+// a limit set is requested at its limit
+func CalculateContainerRequests(container *v1.Container) v1.ResourceList {
+	res := v1.ResourceList{}
+	for name, quantity := range container.Resources.Limits {
+		res[name] = quantity.DeepCopy()
+	}
+	for name, quantity := range container.Resources.Requests {
+		res[name] = quantity.DeepCopy()
+	}
+	return res
+}
+
+// CalculatePodRequests returns the effective requests of a pod the way the scheduler sees it, the
+// max of the steady state and of the init container peaks plus the pod overhead. The init
+// containers named in sidecars run as native sidecars (restartPolicy: Always): they are part of the
+// steady state and they keep running next to the init containers started after them
+func CalculatePodRequests(podSpec *v1.PodSpec, sidecars map[string]bool) v1.ResourceList {
+	res := v1.ResourceList{}
+	for idx := range podSpec.Containers {
+		AddResourceList(res, CalculateContainerRequests(&podSpec.Containers[idx]))
+	}
+
+	initPeak := v1.ResourceList{}
+	startedSidecars := v1.ResourceList{}
+	for idx := range podSpec.InitContainers {
+		initContainer := &podSpec.InitContainers[idx]
+		requests := CalculateContainerRequests(initContainer)
+		if sidecars[initContainer.Name] {
+			AddResourceList(res, requests)
+			AddResourceList(startedSidecars, requests)
+			MaxResourceList(initPeak, startedSidecars)
+			continue
+		}
+		AddResourceList(requests, startedSidecars)
+		MaxResourceList(initPeak, requests)
+	}
+	MaxResourceList(res, initPeak)
+	AddResourceList(res, podSpec.Overhead)
+	return res
+}
+
+func AddResourceList(dst, src v1.ResourceList) {
+	for name, quantity := range src {
+		if existing, ok := dst[name]; ok {
+			existing.Add(quantity)
+			dst[name] = existing
+			continue
+		}
+		dst[name] = quantity.DeepCopy()
+	}
+}
+
+// SubtractResourceList subtracts src from dst, resources never go below zero
+func SubtractResourceList(dst, src v1.ResourceList) {
+	for name, quantity := range src {
+		existing, ok := dst[name]
+		if !ok {
+			continue
+		}
+		existing.Sub(quantity)
+		if existing.Sign() < 0 {
+			existing = *resource.NewQuantity(0, existing.Format)
+		}
+		dst[name] = existing
+	}
+}
+
+func MaxResourceList(dst, src v1.ResourceList) {
+	for name, quantity := range src {
+		if existing, ok := dst[name]; ok && existing.Cmp(quantity) >= 0 {
+			continue
+		}
+		dst[name] = quantity.DeepCopy()
+	}
+}
+
+// MultiplyResourceList multiplies with the quantity arithmetic, the milli value of large quantities
+// (e.g. the memory of many replicas) would overflow an int64
+func MultiplyResourceList(src v1.ResourceList, multiplier int64) v1.ResourceList {
+	res := v1.ResourceList{}
+	for name, quantity := range src {
+		product := resource.NewQuantity(0, quantity.Format)
+		product.AsDec().Mul(quantity.AsDec(), inf.NewDec(multiplier, 0))
+		res[name] = *product
+	}
+	return res
+}
+
+// IsExtendedResourceName reports whether the resource is advertised by a device plugin or the
+// cluster admin (e.g. nvidia.com/gpu) instead of being a native node resource
+func IsExtendedResourceName(name v1.ResourceName) bool {
+	return strings.Contains(string(name), "/") &&
+		!strings.HasPrefix(string(name), v1.ResourceDefaultNamespacePrefix)
+}
+
+func FormatResourceList(list v1.ResourceList) string {
+	var names []string
+	for name := range list {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+	var res []string
+	for _, name := range names {
+		quantity := list[v1.ResourceName(name)]
+		res = append(res, fmt.Sprintf("%s=%s", name, quantity.String()))
+	}
+	return strings.Join(res, ", ")
+}
@@ -13,6 +13,7 @@ type Cluster interface {
 	InsertClusterBatch(tx *gorm.DB, data []*model.Cluster) error
 	ListAllRegisteredCluster(tx *gorm.DB) ([]*model.Cluster, error)
 	GetClusterByID(tx *gorm.DB, id uuid.UUID) (*model.Cluster, error)
+	ListClusterWithDatacenterByName(tx *gorm.DB, name string) ([]*model.Cluster, error)
 }
 
 type cluster struct {
@@ -125,6 +126,51 @@ func (d *cluster) ListAllRegisteredCluster(tx *gorm.DB) ([]*model.Cluster, error
 	return data, tx.Error
 }
 
+func (d *cluster) ListClusterWithDatacenterByName(tx *gorm.DB, name string) ([]*model.Cluster, error) {
+	var data []*model.Cluster
+	rows, err := tx.Raw(
+		`
+		SELECT 
+		       c.id, 
+		       c.datacenter_id, 
+		       c.name, 
+		       c.certificate, 
+		       c.server_endpoint,
+		       c.latest_hpa_api_version,
+		       d.id,
+		       d.datacenter,
+		       d.name
+		from clusters c
+		join datacenters d on d.id = c.datacenter_id and d.deleted_at is null
+		where c.deleted_at is null and c.name = ?
+	`, name,
+	).Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		cluster := &model.Cluster{}
+		err = rows.Scan(
+			&cluster.ID,
+			&cluster.DatacenterID,
+			&cluster.Name,
+			&cluster.Certificate,
+			&cluster.ServerEndpoint,
+			&cluster.LatestHPAAPIVersion,
+			&cluster.Datacenter.ID,
+			&cluster.Datacenter.Datacenter,
+			&cluster.Datacenter.Name,
+		)
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, cluster)
+	}
+	return data, rows.Err()
+}
+
 func (d cluster) InsertCluster(tx *gorm.DB, data *model.Cluster) error {
 	return tx.Create(data).Error
 }
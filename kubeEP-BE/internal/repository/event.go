@@ -4,6 +4,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository/model"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"time"
 )
 
@@ -11,6 +12,7 @@ type Event interface {
 	GetEventByID(tx *gorm.DB, id uuid.UUID) (*model.Event, error)
 	GetEventByName(tx *gorm.DB, name string) (*model.Event, error)
 	ListEventByClusterID(tx *gorm.DB, id uuid.UUID) ([]*model.Event, error)
+	CountEventByStatus(tx *gorm.DB) (map[model.EventStatus]int64, error)
 	InsertEvent(tx *gorm.DB, data *model.Event) error
 	SaveEvent(tx *gorm.DB, data *model.Event) error
 	DeleteEvent(tx *gorm.DB, id uuid.UUID) error
@@ -33,7 +35,16 @@ type Event interface {
 		error,
 	)
 	FindWatchedEvent(tx *gorm.DB, now time.Time) ([]*model.Event, error)
-	FinishWatchedEvent(tx *gorm.DB, now time.Time) error
+	FinishWatchedEvent(tx *gorm.DB, now time.Time) ([]*model.Event, error)
+	FindEventByEndTime(
+		tx *gorm.DB,
+		status model.EventStatus,
+		withScaleDown bool,
+		now time.Time,
+	) (
+		[]*model.Event,
+		error,
+	)
 	FindEventByExecuteConfigAt(
 		tx *gorm.DB,
 		status model.EventStatus,
@@ -77,6 +88,22 @@ func (e *event) ListEventByClusterID(tx *gorm.DB, id uuid.UUID) ([]*model.Event,
 	return data, tx.Error
 }
 
+func (e *event) CountEventByStatus(tx *gorm.DB) (map[model.EventStatus]int64, error) {
+	var rows []struct {
+		Status model.EventStatus
+		Count  int64
+	}
+	err := tx.Model(&model.Event{}).Select("status, count(*) as count").Group("status").Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	output := map[model.EventStatus]int64{}
+	for _, row := range rows {
+		output[row.Status] = row.Count
+	}
+	return output, nil
+}
+
 func (e *event) InsertEvent(tx *gorm.DB, data *model.Event) error {
 	return tx.Create(data).Error
 }
@@ -97,10 +124,91 @@ func (e *event) FindWatchedEvent(tx *gorm.DB, now time.Time) ([]*model.Event, er
 	return data, tx.Error
 }
 
-func (e *event) FinishWatchedEvent(tx *gorm.DB, now time.Time) error {
-	return tx.Model(&model.Event{}).Where(
-		"status = ? and end_time < ?", model.EventWatching, now.UTC(),
+// FinishWatchedEvent returns the events moved to success
+func (e *event) FinishWatchedEvent(tx *gorm.DB, now time.Time) ([]*model.Event, error) {
+	var data []*model.Event
+	err := tx.Model(&data).Clauses(clause.Returning{}).Where(
+		"status = ? and end_time < ? and scale_down_steps = 0", model.EventWatching, now.UTC(),
 	).Update("status", model.EventSuccess).Error
+	return data, err
+}
+
+func (e *event) FindEventByEndTime(
+	tx *gorm.DB,
+	status model.EventStatus,
+	withScaleDown bool,
+	now time.Time,
+) (
+	[]*model.Event,
+	error,
+) {
+	var data []*model.Event
+	scaleDownCondition := "e.scale_down_steps = 0"
+	if withScaleDown {
+		scaleDownCondition = "e.scale_down_steps > 0"
+	}
+	rows, err := tx.Raw(
+		`select 
+    e.id, 
+    e.created_at, 
+    e.updated_at, 
+    e.deleted_at, 
+    e.name, 
+    e.start_time, 
+    e.end_time, 
+    e.cluster_id, 
+    e.status, 
+    e.message,
+    e.execute_config_at,
+    e.watching_at,
+    e.scale_down_window_minutes,
+    e.scale_down_steps,
+    e.scale_down_gate,
+    e.watch_interval_seconds,
+    e.watch_max_interval_seconds,
+    c.name, 
+    d.datacenter,
+    e.calculate_node_pool from events e 
+    join clusters c on c.id = e.cluster_id and c.deleted_at is null
+    join datacenters d on d.id = c.datacenter_id and d.deleted_at is null
+             where e.end_time < ? and e.status = ? and e.deleted_at is null and `+scaleDownCondition,
+		now.UTC(),
+		status,
+	).Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		eventData := &model.Event{}
+		err = rows.Scan(
+			&eventData.ID,
+			&eventData.CreatedAt,
+			&eventData.UpdatedAt,
+			&eventData.DeletedAt,
+			&eventData.Name,
+			&eventData.StartTime,
+			&eventData.EndTime,
+			&eventData.ClusterID,
+			&eventData.Status,
+			&eventData.Message,
+			&eventData.ExecuteConfigAt,
+			&eventData.WatchingAt,
+			&eventData.ScaleDownWindowMinutes,
+			&eventData.ScaleDownSteps,
+			&eventData.ScaleDownGate,
+			&eventData.WatchIntervalSeconds,
+			&eventData.WatchMaxIntervalSeconds,
+			&eventData.Cluster.Name,
+			&eventData.Cluster.Datacenter.Datacenter,
+			&eventData.CalculateNodePool,
+		)
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, eventData)
+	}
+	return data, nil
 }
 
 func (e *event) FindEventByWatchingAt(
@@ -126,6 +234,11 @@ func (e *event) FindEventByWatchingAt(
     e.message,
     e.execute_config_at,
     e.watching_at,
+    e.scale_down_window_minutes,
+    e.scale_down_steps,
+    e.scale_down_gate,
+    e.watch_interval_seconds,
+    e.watch_max_interval_seconds,
     c.name, 
     d.datacenter,
     e.calculate_node_pool from events e 
@@ -154,6 +267,11 @@ func (e *event) FindEventByWatchingAt(
 			&eventData.Message,
 			&eventData.ExecuteConfigAt,
 			&eventData.WatchingAt,
+			&eventData.ScaleDownWindowMinutes,
+			&eventData.ScaleDownSteps,
+			&eventData.ScaleDownGate,
+			&eventData.WatchIntervalSeconds,
+			&eventData.WatchMaxIntervalSeconds,
 			&eventData.Cluster.Name,
 			&eventData.Cluster.Datacenter.Datacenter,
 			&eventData.CalculateNodePool,
@@ -189,6 +307,11 @@ func (e *event) FindEventByExecuteConfigAt(
     e.message,
     e.execute_config_at,
     e.watching_at,
+    e.scale_down_window_minutes,
+    e.scale_down_steps,
+    e.scale_down_gate,
+    e.watch_interval_seconds,
+    e.watch_max_interval_seconds,
     c.name, 
     d.datacenter,
     e.calculate_node_pool from events e 
@@ -217,6 +340,11 @@ func (e *event) FindEventByExecuteConfigAt(
 			&eventData.Message,
 			&eventData.ExecuteConfigAt,
 			&eventData.WatchingAt,
+			&eventData.ScaleDownWindowMinutes,
+			&eventData.ScaleDownSteps,
+			&eventData.ScaleDownGate,
+			&eventData.WatchIntervalSeconds,
+			&eventData.WatchMaxIntervalSeconds,
 			&eventData.Cluster.Name,
 			&eventData.Cluster.Datacenter.Datacenter,
 			&eventData.CalculateNodePool,
@@ -253,6 +381,11 @@ func (e *event) FindEventByStatusWithStarTimeBeforeMinuteAndClusterData(
     e.message,
     e.execute_config_at,
     e.watching_at,
+    e.scale_down_window_minutes,
+    e.scale_down_steps,
+    e.scale_down_gate,
+    e.watch_interval_seconds,
+    e.watch_max_interval_seconds,
     c.name, 
     d.datacenter,
     e.calculate_node_pool from events e 
@@ -282,6 +415,11 @@ func (e *event) FindEventByStatusWithStarTimeBeforeMinuteAndClusterData(
 			&eventData.Message,
 			&eventData.ExecuteConfigAt,
 			&eventData.WatchingAt,
+			&eventData.ScaleDownWindowMinutes,
+			&eventData.ScaleDownSteps,
+			&eventData.ScaleDownGate,
+			&eventData.WatchIntervalSeconds,
+			&eventData.WatchMaxIntervalSeconds,
 			&eventData.Cluster.Name,
 			&eventData.Cluster.Datacenter.Datacenter,
 			&eventData.CalculateNodePool,
@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type EventReport interface {
+	GetEventReportByEventID(tx *gorm.DB, eventID uuid.UUID) (*model.EventReport, error)
+	SaveEventReport(tx *gorm.DB, data *model.EventReport) error
+}
+
+type eventReport struct {
+}
+
+func newEventReport() EventReport {
+	return &eventReport{}
+}
+
+func (e *eventReport) GetEventReportByEventID(
+	tx *gorm.DB,
+	eventID uuid.UUID,
+) (*model.EventReport, error) {
+	data := &model.EventReport{}
+	err := tx.Model(data).Where("event_id = ?", eventID).First(data).Error
+	return data, err
+}
+
+// SaveEventReport replaces the report of the event
+func (e *eventReport) SaveEventReport(tx *gorm.DB, data *model.EventReport) error {
+	return tx.Clauses(
+		clause.OnConflict{
+			Columns:   []clause.Column{{Name: "event_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"report", "updated_at", "deleted_at"}),
+		},
+	).Create(data).Error
+}
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+)
+
+// EventStream bridges the live samples written by the cron to the api through redis pub/sub
+type EventStream interface {
+	PublishEventStreamMessage(ctx context.Context, eventID uuid.UUID, payload []byte) error
+	SubscribeEventStream(ctx context.Context, eventID uuid.UUID) (*redis.PubSub, error)
+}
+
+type eventStream struct {
+	redisClient *redis.Client
+}
+
+func newEventStream(redisClient *redis.Client) EventStream {
+	return &eventStream{
+		redisClient: redisClient,
+	}
+}
+
+func eventStreamChannel(eventID uuid.UUID) string {
+	return fmt.Sprintf("event_stream_%s", eventID)
+}
+
+func (e *eventStream) PublishEventStreamMessage(
+	ctx context.Context,
+	eventID uuid.UUID,
+	payload []byte,
+) error {
+	return e.redisClient.Publish(ctx, eventStreamChannel(eventID), payload).Err()
+}
+
+// SubscribeEventStream waits for the subscription confirmation so no message published after the
+// call returns is missed
+func (e *eventStream) SubscribeEventStream(
+	ctx context.Context,
+	eventID uuid.UUID,
+) (*redis.PubSub, error) {
+	pubSub := e.redisClient.Subscribe(ctx, eventStreamChannel(eventID))
+	if _, err := pubSub.Receive(ctx); err != nil {
+		_ = pubSub.Close()
+		return nil, err
+	}
+	return pubSub, nil
+}
@@ -1,9 +1,11 @@
 package repository
 
 import (
+	compute "cloud.google.com/go/compute/apiv1"
 	container "cloud.google.com/go/container/apiv1"
 	"context"
 	"fmt"
+	computeEntity "google.golang.org/genproto/googleapis/cloud/compute/v1"
 	containerEntity "google.golang.org/genproto/googleapis/container/v1"
 )
 
@@ -31,6 +33,26 @@ type GCPCluster interface {
 		clusterClient *container.ClusterManagerClient,
 		project, location, operationName string,
 	) (*containerEntity.Operation, error)
+	GetInstanceGroupManager(
+		ctx context.Context,
+		igmClient *compute.InstanceGroupManagersClient,
+		project, zone, name string,
+	) (*computeEntity.InstanceGroupManager, error)
+	GetInstanceTemplate(
+		ctx context.Context,
+		instanceTemplatesClient *compute.InstanceTemplatesClient,
+		project, name string,
+	) (*computeEntity.InstanceTemplate, error)
+	GetMachineType(
+		ctx context.Context,
+		machineTypesClient *compute.MachineTypesClient,
+		project, zone, name string,
+	) (*computeEntity.MachineType, error)
+	GetRegion(
+		ctx context.Context,
+		regionsClient *compute.RegionsClient,
+		project, region string,
+	) (*computeEntity.Region, error)
 }
 
 type gcpCluster struct {
@@ -108,3 +130,57 @@ func (g *gcpCluster) GetOperation(
 		},
 	)
 }
+
+func (g *gcpCluster) GetInstanceGroupManager(
+	ctx context.Context,
+	igmClient *compute.InstanceGroupManagersClient,
+	project, zone, name string,
+) (*computeEntity.InstanceGroupManager, error) {
+	return igmClient.Get(
+		ctx, &computeEntity.GetInstanceGroupManagerRequest{
+			Project:              project,
+			Zone:                 zone,
+			InstanceGroupManager: name,
+		},
+	)
+}
+
+func (g *gcpCluster) GetInstanceTemplate(
+	ctx context.Context,
+	instanceTemplatesClient *compute.InstanceTemplatesClient,
+	project, name string,
+) (*computeEntity.InstanceTemplate, error) {
+	return instanceTemplatesClient.Get(
+		ctx, &computeEntity.GetInstanceTemplateRequest{
+			Project:          project,
+			InstanceTemplate: name,
+		},
+	)
+}
+
+func (g *gcpCluster) GetMachineType(
+	ctx context.Context,
+	machineTypesClient *compute.MachineTypesClient,
+	project, zone, name string,
+) (*computeEntity.MachineType, error) {
+	return machineTypesClient.Get(
+		ctx, &computeEntity.GetMachineTypeRequest{
+			Project:     project,
+			Zone:        zone,
+			MachineType: name,
+		},
+	)
+}
+
+func (g *gcpCluster) GetRegion(
+	ctx context.Context,
+	regionsClient *compute.RegionsClient,
+	project, region string,
+) (*computeEntity.Region, error) {
+	return regionsClient.Get(
+		ctx, &computeEntity.GetRegionRequest{
+			Project: project,
+			Region:  region,
+		},
+	)
+}
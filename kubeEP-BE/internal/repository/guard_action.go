@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository/model"
+	"gorm.io/gorm"
+)
+
+type GuardAction interface {
+	InsertGuardAction(tx *gorm.DB, data *model.GuardAction) error
+	ListGuardActionByEventID(tx *gorm.DB, eventID uuid.UUID) ([]*model.GuardAction, error)
+}
+
+type guardAction struct {
+}
+
+func newGuardAction() GuardAction {
+	return &guardAction{}
+}
+
+func (g *guardAction) InsertGuardAction(tx *gorm.DB, data *model.GuardAction) error {
+	return tx.Omit("GuardRule", "Event").Create(data).Error
+}
+
+func (g *guardAction) ListGuardActionByEventID(
+	tx *gorm.DB,
+	eventID uuid.UUID,
+) ([]*model.GuardAction, error) {
+	var data []*model.GuardAction
+	err := tx.Model(&model.GuardAction{}).
+		Where("event_id = ?", eventID).
+		Order("created_at").
+		Find(&data).Error
+	return data, err
+}
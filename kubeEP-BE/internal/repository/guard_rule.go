@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository/model"
+	"gorm.io/gorm"
+)
+
+type GuardRule interface {
+	InsertBatchGuardRule(tx *gorm.DB, data []*model.GuardRule) error
+	ListGuardRuleByEventID(tx *gorm.DB, eventID uuid.UUID) ([]*model.GuardRule, error)
+	DeletePermanentGuardRuleByEventID(tx *gorm.DB, eventID uuid.UUID) error
+}
+
+type guardRule struct {
+}
+
+func newGuardRule() GuardRule {
+	return &guardRule{}
+}
+
+func (g *guardRule) InsertBatchGuardRule(tx *gorm.DB, data []*model.GuardRule) error {
+	return tx.Create(data).Error
+}
+
+func (g *guardRule) ListGuardRuleByEventID(
+	tx *gorm.DB,
+	eventID uuid.UUID,
+) ([]*model.GuardRule, error) {
+	var data []*model.GuardRule
+	err := tx.Model(&model.GuardRule{}).
+		Where("event_id = ?", eventID).
+		Order("created_at").
+		Find(&data).Error
+	return data, err
+}
+
+func (g *guardRule) DeletePermanentGuardRuleByEventID(tx *gorm.DB, eventID uuid.UUID) error {
+	return tx.Unscoped().Delete(&model.GuardRule{}, "event_id = ?", eventID).Error
+}
@@ -28,6 +28,6 @@ func (h *hpaStatus) GetAllHPAStatusByScheduledHPAConfigID(
 	err := tx.Model(&model.HPAStatus{}).Where(
 		"scheduled_hpa_config_id = ?",
 		scheduledHPAConfigID,
-	).Find(&data).Error
+	).Order("created_at").Find(&data).Error
 	return data, err
 }
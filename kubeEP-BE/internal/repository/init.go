@@ -7,23 +7,36 @@ import (
 )
 
 type Repositories struct {
-	Cluster            Cluster
-	Datacenter         Datacenter
-	Event              Event
-	ScheduledHPAConfig ScheduledHPAConfig
-	K8sHPA             K8sHPA
-	K8sNamespace       K8sNamespace
-	GCPCluster         GCPCluster
-	K8SDiscovery       K8SDiscovery
-	K8sDeployment      K8sDeployment
-	NodePoolStatus     NodePoolStatus
-	UpdatedNodePool    UpdatedNodePool
-	HPAStatus          HPAStatus
-	K8sNode            K8sNode
-	K8sDaemonSets      K8sDaemonSets
+	Cluster                  Cluster
+	Datacenter               Datacenter
+	Event                    Event
+	ScheduledHPAConfig       ScheduledHPAConfig
+	K8sHPA                   K8sHPA
+	K8sNamespace             K8sNamespace
+	GCPCluster               GCPCluster
+	K8SDiscovery             K8SDiscovery
+	K8sDeployment            K8sDeployment
+	NodePoolStatus           NodePoolStatus
+	UpdatedNodePool          UpdatedNodePool
+	HPAStatus                HPAStatus
+	K8sNode                  K8sNode
+	K8sDaemonSets            K8sDaemonSets
+	K8sRuntimeClass          K8sRuntimeClass
+	ScaleDownStep            ScaleDownStep
+	K8sPod                   K8sPod
+	K8sEvent                 K8sEvent
+	PodStatus                PodStatus
+	K8sMetrics               K8sMetrics
+	EventReport              EventReport
+	NotificationChannel      NotificationChannel
+	NotificationSubscription NotificationSubscription
+	NotificationDelivery     NotificationDelivery
+	EventStream              EventStream
+	GuardRule                GuardRule
+	GuardAction              GuardAction
 }
 
-func Migrate(db *gorm.DB) error {
+func Migrate(db *gorm.DB, timescaleConfig config.TimescaleConfig) error {
 	tableList := []interface{}{
 		&model.Datacenter{},
 		&model.Cluster{},
@@ -32,6 +45,14 @@ func Migrate(db *gorm.DB) error {
 		&model.NodePoolStatus{},
 		&model.HPAStatus{},
 		&model.UpdatedNodePool{},
+		&model.ScaleDownStep{},
+		&model.PodStatus{},
+		&model.EventReport{},
+		&model.NotificationChannel{},
+		&model.NotificationSubscription{},
+		&model.NotificationDelivery{},
+		&model.GuardRule{},
+		&model.GuardAction{},
 	}
 
 	err := db.AutoMigrate(
@@ -50,24 +71,37 @@ func Migrate(db *gorm.DB) error {
 		}
 	}
 
-	return nil
+	return migrateTimescale(db, timescaleConfig)
 }
 
 func BuildRepositories(resources *config.KubeEPResources) *Repositories {
 	return &Repositories{
-		Cluster:            newCluster(),
-		Datacenter:         newDatacenter(resources.Redis),
-		Event:              newEvent(),
-		ScheduledHPAConfig: newScheduledHPAConfig(),
-		K8sHPA:             newK8sHPA(resources.Redis),
-		K8sNamespace:       newK8sNamespace(),
-		GCPCluster:         newGcpCluster(),
-		K8SDiscovery:       newK8sDiscovery(),
-		K8sDeployment:      newK8sDeployment(),
-		NodePoolStatus:     newNodePoolStatus(),
-		HPAStatus:          newHpaStatus(),
-		UpdatedNodePool:    newUpdatedNodePool(),
-		K8sNode:            newK8sNode(),
-		K8sDaemonSets:      newK8sDaemonSets(),
+		Cluster:                  newCluster(),
+		Datacenter:               newDatacenter(resources.Redis),
+		Event:                    newEvent(),
+		ScheduledHPAConfig:       newScheduledHPAConfig(),
+		K8sHPA:                   newK8sHPA(resources.Redis),
+		K8sNamespace:             newK8sNamespace(),
+		GCPCluster:               newGcpCluster(),
+		K8SDiscovery:             newK8sDiscovery(),
+		K8sDeployment:            newK8sDeployment(),
+		NodePoolStatus:           newNodePoolStatus(),
+		HPAStatus:                newHpaStatus(),
+		UpdatedNodePool:          newUpdatedNodePool(),
+		K8sNode:                  newK8sNode(),
+		K8sDaemonSets:            newK8sDaemonSets(),
+		K8sRuntimeClass:          newK8sRuntimeClass(),
+		ScaleDownStep:            newScaleDownStep(),
+		K8sPod:                   newK8sPod(),
+		K8sEvent:                 newK8sEvent(),
+		PodStatus:                newPodStatus(),
+		K8sMetrics:               newK8sMetrics(),
+		EventReport:              newEventReport(),
+		NotificationChannel:      newNotificationChannel(),
+		NotificationSubscription: newNotificationSubscription(),
+		NotificationDelivery:     newNotificationDelivery(),
+		EventStream:              newEventStream(resources.Redis),
+		GuardRule:                newGuardRule(),
+		GuardAction:              newGuardAction(),
 	}
 }
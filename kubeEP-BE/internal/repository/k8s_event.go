@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"context"
+	"k8s.io/api/core/v1"
+	v1Option "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+type K8sEvent interface {
+	GetEventList(
+		ctx context.Context,
+		client kubernetes.Interface,
+		namespace string,
+		option ...v1Option.ListOptions,
+	) (*v1.EventList, error)
+}
+
+type k8sEvent struct {
+}
+
+func newK8sEvent() K8sEvent {
+	return &k8sEvent{}
+}
+
+func (k *k8sEvent) GetEventList(
+	ctx context.Context,
+	client kubernetes.Interface,
+	namespace string,
+	option ...v1Option.ListOptions,
+) (*v1.EventList, error) {
+	reqOption := v1Option.ListOptions{}
+	if len(option) > 0 {
+		reqOption = option[0]
+	}
+	return client.CoreV1().Events(namespace).List(ctx, reqOption)
+}
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const nodeMetricsPath = "/apis/metrics.k8s.io/v1beta1/nodes"
+
+type nodeMetricsList struct {
+	Items []struct {
+		Metadata struct {
+			Name string `json:"name"`
+		} `json:"metadata"`
+		Usage v1.ResourceList `json:"usage"`
+	} `json:"items"`
+}
+
+type K8sMetrics interface {
+	GetNodesUsage(
+		ctx context.Context,
+		client kubernetes.Interface,
+	) (map[string]v1.ResourceList, error)
+}
+
+type k8sMetrics struct {
+}
+
+func newK8sMetrics() K8sMetrics {
+	return &k8sMetrics{}
+}
+
+// GetNodesUsage reads node usage from metrics.k8s.io with a raw request, so the cluster doesn't
+// need the metrics client. It fails when metrics-server is not installed
+func (k *k8sMetrics) GetNodesUsage(
+	ctx context.Context,
+	client kubernetes.Interface,
+) (map[string]v1.ResourceList, error) {
+	data, err := client.CoreV1().RESTClient().Get().AbsPath(nodeMetricsPath).DoRaw(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var metrics nodeMetricsList
+	if err := json.Unmarshal(data, &metrics); err != nil {
+		return nil, err
+	}
+	output := map[string]v1.ResourceList{}
+	for _, item := range metrics.Items {
+		output[item.Metadata.Name] = item.Usage
+	}
+	return output, nil
+}
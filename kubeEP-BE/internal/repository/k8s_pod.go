@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"context"
+	"k8s.io/api/core/v1"
+	v1Option "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+type K8sPod interface {
+	GetPodList(
+		ctx context.Context,
+		client kubernetes.Interface,
+		namespace string,
+		option ...v1Option.ListOptions,
+	) (*v1.PodList, error)
+}
+
+type k8sPod struct {
+}
+
+func newK8sPod() K8sPod {
+	return &k8sPod{}
+}
+
+func (k *k8sPod) GetPodList(
+	ctx context.Context,
+	client kubernetes.Interface,
+	namespace string,
+	option ...v1Option.ListOptions,
+) (*v1.PodList, error) {
+	reqOption := v1Option.ListOptions{}
+	if len(option) > 0 {
+		reqOption = option[0]
+	}
+	return client.CoreV1().Pods(namespace).List(ctx, reqOption)
+}
@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"context"
+	v1Node "k8s.io/api/node/v1"
+	v1Option "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+type K8sRuntimeClass interface {
+	GetRuntimeClassList(
+		ctx context.Context,
+		client kubernetes.Interface,
+		option ...v1Option.ListOptions,
+	) (*v1Node.RuntimeClassList, error)
+}
+
+type k8sRuntimeClass struct {
+}
+
+func newK8sRuntimeClass() K8sRuntimeClass {
+	return &k8sRuntimeClass{}
+}
+
+func (k *k8sRuntimeClass) GetRuntimeClassList(
+	ctx context.Context,
+	client kubernetes.Interface,
+	option ...v1Option.ListOptions,
+) (*v1Node.RuntimeClassList, error) {
+	reqOption := v1Option.ListOptions{}
+	if len(option) > 0 {
+		reqOption = option[0]
+	}
+	return client.NodeV1().RuntimeClasses().List(ctx, reqOption)
+}
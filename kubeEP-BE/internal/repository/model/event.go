@@ -8,26 +8,44 @@ import (
 type EventStatus string
 
 const (
-	EventFailed    EventStatus = "FAILED"
-	EventSuccess   EventStatus = "SUCCESS"
-	EventExecuting EventStatus = "EXECUTING"
-	EventPrescaled EventStatus = "PRESCALED"
-	EventWatching  EventStatus = "WATCHING"
-	EventPending   EventStatus = "PENDING"
+	EventFailed      EventStatus = "FAILED"
+	EventSuccess     EventStatus = "SUCCESS"
+	EventExecuting   EventStatus = "EXECUTING"
+	EventPrescaled   EventStatus = "PRESCALED"
+	EventWatching    EventStatus = "WATCHING"
+	EventPending     EventStatus = "PENDING"
+	EventScalingDown EventStatus = "SCALING_DOWN"
+)
+
+type ScaleDownGate string
+
+const (
+	ScaleDownGateNone              ScaleDownGate = "NONE"
+	ScaleDownGateCurrentReplicas   ScaleDownGate = "CURRENT_REPLICAS"
+	ScaleDownGateHPARecommendation ScaleDownGate = "HPA_RECOMMENDATION"
 )
 
 type Event struct {
 	BaseModel
-	Name              string
-	StartTime         time.Time
-	EndTime           time.Time
-	ClusterID         gormDatatype.UUID
-	Status            EventStatus `gorm:"default:PENDING"`
-	Message           string
-	CalculateNodePool bool
-	Cluster           Cluster `gorm:"ForeignKey:ClusterID;constraint:OnDelete:CASCADE"`
-	ExecuteConfigAt   time.Time
-	WatchingAt        time.Time
+	Name                   string
+	StartTime              time.Time
+	EndTime                time.Time
+	ClusterID              gormDatatype.UUID
+	Status                 EventStatus `gorm:"default:PENDING"`
+	Message                string
+	CalculateNodePool      bool
+	Cluster                Cluster `gorm:"ForeignKey:ClusterID;constraint:OnDelete:CASCADE"`
+	ExecuteConfigAt        time.Time
+	WatchingAt             time.Time
+	ScaleDownWindowMinutes int64
+	ScaleDownSteps         int32
+	ScaleDownGate          ScaleDownGate `gorm:"default:NONE"`
+	// Zero interval uses the watcher default, adaptive sampling is enabled when max is above interval
+	WatchIntervalSeconds    int64
+	WatchMaxIntervalSeconds int64
+	// Owner marks the events managed by another system, it is written at registration only so the
+	// saves of the event keep it
+	Owner string `gorm:"<-:create"`
 }
 
 func (e *Event) TableName() string {
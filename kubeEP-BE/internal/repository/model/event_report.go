@@ -0,0 +1,14 @@
+package model
+
+import gormDatatype "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/pkg/gorm/datatype"
+
+type EventReport struct {
+	BaseModel
+	EventID gormDatatype.UUID `gorm:"uniqueIndex"`
+	Event   Event             `gorm:"ForeignKey:EventID;constraint:OnDelete:CASCADE"`
+	Report  gormDatatype.JSON
+}
+
+func (EventReport) TableName() string {
+	return "event_reports"
+}
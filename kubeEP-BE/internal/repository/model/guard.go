@@ -0,0 +1,73 @@
+package model
+
+import (
+	gormDatatype "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/pkg/gorm/datatype"
+	"time"
+)
+
+type GuardCondition string
+
+const (
+	// GuardUnavailableReplicas breaches when the unavailable replicas percentage is above the threshold
+	GuardUnavailableReplicas GuardCondition = "UNAVAILABLE_REPLICAS"
+	// GuardHPAAtMaxReplicas breaches when the hpa current replicas reached its max replicas
+	GuardHPAAtMaxReplicas GuardCondition = "HPA_AT_MAX_REPLICAS"
+	// GuardUnschedulablePods breaches when the unschedulable pods count is above the threshold
+	GuardUnschedulablePods GuardCondition = "UNSCHEDULABLE_PODS"
+)
+
+type GuardActionType string
+
+const (
+	GuardRaiseMaxReplicas GuardActionType = "RAISE_MAX_REPLICAS"
+	GuardRaiseNodePoolMax GuardActionType = "RAISE_NODE_POOL_MAX"
+	GuardNotify           GuardActionType = "NOTIFY"
+)
+
+type GuardActionStatus string
+
+const (
+	GuardActionSuccess GuardActionStatus = "SUCCESS"
+	GuardActionFailed  GuardActionStatus = "FAILED"
+)
+
+// GuardRule is evaluated on every watch sample of the event, the rule applies to every hpa of the
+// event when HPAName is empty
+type GuardRule struct {
+	BaseModel
+	HPAName         string
+	HPANamespace    string
+	Condition       GuardCondition
+	Threshold       float64
+	DurationSeconds int64
+	Action          GuardActionType
+	ActionValue     int32
+	NodePoolName    string
+	CooldownSeconds int64
+	EventID         gormDatatype.UUID
+	Event           Event `gorm:"ForeignKey:EventID;constraint:OnDelete:CASCADE"`
+}
+
+func (GuardRule) TableName() string {
+	return "guard_rules"
+}
+
+// GuardAction records an automatic action taken by a breached guard rule
+type GuardAction struct {
+	BaseModel
+	Action        GuardActionType
+	Status        GuardActionStatus
+	Target        string
+	PreviousValue int32
+	NewValue      int32
+	Message       string
+	BreachedSince time.Time
+	GuardRuleID   gormDatatype.UUID
+	GuardRule     GuardRule `gorm:"ForeignKey:GuardRuleID;constraint:OnDelete:CASCADE"`
+	EventID       gormDatatype.UUID
+	Event         Event `gorm:"ForeignKey:EventID;constraint:OnDelete:CASCADE"`
+}
+
+func (GuardAction) TableName() string {
+	return "guard_actions"
+}
@@ -13,6 +13,13 @@ type HPAStatus struct {
 	AvailableReplicas    int32
 	ReadyReplicas        int32
 	UnavailableReplicas  int32
+	HPAMinReplicas       int32
+	HPAMaxReplicas       int32
+	HPACurrentReplicas   int32
+	HPADesiredReplicas   int32
+	ScalingLimited       bool
+	ScalingLimitedReason string
+	Metrics              gormDatatype.JSON
 	ScheduledHPAConfig   ScheduledHPAConfig `gorm:"ForeignKey:ScheduledHPAConfigID;constraint:OnDelete:CASCADE"`
 }
 
@@ -10,6 +10,13 @@ type NodePoolStatus struct {
 	CreatedAt         time.Time         `gorm:"primaryKey;default:now()"`
 	UpdatedNodePoolID gormDatatype.UUID `gorm:"primaryKey"`
 	NodeCount         int32
+	// CPU in millicores and memory in bytes, usage is null when metrics.k8s.io is not available
+	AllocatableCPU    int64
+	AllocatableMemory int64
+	RequestedCPU      int64
+	RequestedMemory   int64
+	UsageCPU          *int64
+	UsageMemory       *int64
 	UpdatedNodePool   UpdatedNodePool `gorm:"ForeignKey:UpdatedNodePoolID;constraint:OnDelete:CASCADE"`
 }
 
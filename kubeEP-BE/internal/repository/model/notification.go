@@ -0,0 +1,80 @@
+package model
+
+import (
+	gormDatatype "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/pkg/gorm/datatype"
+	"time"
+)
+
+type NotificationChannelType string
+
+const (
+	NotificationWebhook NotificationChannelType = "WEBHOOK"
+	NotificationSlack   NotificationChannelType = "SLACK"
+	NotificationEmail   NotificationChannelType = "EMAIL"
+)
+
+type NotificationTransition string
+
+const (
+	TransitionPrescaled     NotificationTransition = "PRESCALED"
+	TransitionWatching      NotificationTransition = "WATCHING"
+	TransitionFailed        NotificationTransition = "FAILED"
+	TransitionFinished      NotificationTransition = "FINISHED"
+	TransitionRolledBack    NotificationTransition = "ROLLED_BACK"
+	TransitionGuardBreached NotificationTransition = "GUARD_BREACHED"
+)
+
+type NotificationDeliveryStatus string
+
+const (
+	NotificationDeliveryPending NotificationDeliveryStatus = "PENDING"
+	NotificationDeliverySuccess NotificationDeliveryStatus = "SUCCESS"
+	NotificationDeliveryFailed  NotificationDeliveryStatus = "FAILED"
+)
+
+type NotificationChannel struct {
+	BaseModel
+	Name   string
+	Type   NotificationChannelType
+	Config gormDatatype.JSON
+}
+
+func (NotificationChannel) TableName() string {
+	return "notification_channels"
+}
+
+// NotificationSubscription matches every cluster or event when ClusterID or EventID is null
+type NotificationSubscription struct {
+	BaseModel
+	ChannelID   gormDatatype.UUID
+	Channel     NotificationChannel `gorm:"ForeignKey:ChannelID;constraint:OnDelete:CASCADE"`
+	ClusterID   *gormDatatype.UUID  `gorm:"index"`
+	Cluster     *Cluster            `gorm:"ForeignKey:ClusterID;constraint:OnDelete:CASCADE"`
+	EventID     *gormDatatype.UUID  `gorm:"index"`
+	Event       *Event              `gorm:"ForeignKey:EventID;constraint:OnDelete:CASCADE"`
+	Transitions gormDatatype.JSON
+}
+
+func (NotificationSubscription) TableName() string {
+	return "notification_subscriptions"
+}
+
+type NotificationDelivery struct {
+	BaseModel
+	SubscriptionID gormDatatype.UUID
+	Subscription   NotificationSubscription `gorm:"ForeignKey:SubscriptionID;constraint:OnDelete:CASCADE"`
+	ChannelID      gormDatatype.UUID
+	Channel        NotificationChannel `gorm:"ForeignKey:ChannelID;constraint:OnDelete:CASCADE"`
+	EventID        gormDatatype.UUID   `gorm:"index"`
+	Event          Event               `gorm:"ForeignKey:EventID;constraint:OnDelete:CASCADE"`
+	Transition     NotificationTransition
+	Status         NotificationDeliveryStatus `gorm:"default:PENDING;index"`
+	Attempts       int32
+	LastError      string
+	DeliveredAt    *time.Time
+	Payload        gormDatatype.JSON
+}
+
+func (NotificationDelivery) TableName() string {
+	return "notification_deliveries"
+}
@@ -0,0 +1,47 @@
+package model
+
+import (
+	gormDatatype "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/pkg/gorm/datatype"
+	"gorm.io/gorm"
+	"time"
+)
+
+type PodStatus struct {
+	CreatedAt                time.Time         `gorm:"primaryKey;default:now()"`
+	ScheduledHPAConfigID     gormDatatype.UUID `gorm:"primaryKey"`
+	PendingPods              int32
+	UnschedulablePods        int32
+	FailedSchedulingEvents   int32
+	RestartCount             int32
+	OOMKilledContainers      int32
+	StartedPods              int32
+	AvgStartupLatencySeconds float64
+	MaxStartupLatencySeconds float64
+	ScheduledHPAConfig       ScheduledHPAConfig `gorm:"ForeignKey:ScheduledHPAConfigID;constraint:OnDelete:CASCADE"`
+}
+
+func (PodStatus) TableName() string {
+	return "pod_status"
+}
+
+func (p *PodStatus) AdditionalMigration(db *gorm.DB) error {
+	tableName := p.TableName()
+	var exist bool
+	row := db.Raw(
+		"select exists(select * from timescaledb_information.hypertables where hypertable_name = ?)",
+		tableName,
+	).Row()
+	if err := row.Err(); err != nil {
+		return err
+	}
+	if err := row.Scan(&exist); err != nil {
+		return err
+	}
+	if !exist {
+		return db.Exec(
+			`select create_hypertable(?,'created_at', 'scheduled_hpa_config_id', 4)`,
+			tableName,
+		).Error
+	}
+	return nil
+}
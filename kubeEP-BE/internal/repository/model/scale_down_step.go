@@ -0,0 +1,34 @@
+package model
+
+import (
+	gormDatatype "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/pkg/gorm/datatype"
+	"time"
+)
+
+type ScaleDownStepStatus string
+
+const (
+	ScaleDownStepFailed  ScaleDownStepStatus = "FAILED"
+	ScaleDownStepSuccess ScaleDownStepStatus = "SUCCESS"
+	ScaleDownStepPending ScaleDownStepStatus = "PENDING"
+	ScaleDownStepSkipped ScaleDownStepStatus = "SKIPPED"
+)
+
+type ScaleDownStep struct {
+	BaseModel
+	StepNumber           int32
+	ScheduledAt          time.Time
+	ExecutedAt           *time.Time
+	MinPods              int32
+	MaxPods              int32
+	Status               ScaleDownStepStatus `gorm:"default:PENDING"`
+	Message              string
+	ScheduledHPAConfigID gormDatatype.UUID
+	ScheduledHPAConfig   ScheduledHPAConfig `gorm:"ForeignKey:ScheduledHPAConfigID;constraint:OnDelete:CASCADE"`
+	EventID              gormDatatype.UUID
+	Event                Event `gorm:"ForeignKey:EventID;constraint:OnDelete:CASCADE"`
+}
+
+func (ScaleDownStep) TableName() string {
+	return "scale_down_steps"
+}
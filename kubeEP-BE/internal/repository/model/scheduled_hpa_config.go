@@ -14,14 +14,18 @@ const (
 
 type ScheduledHPAConfig struct {
 	BaseModel
-	Name      string
-	MinPods   *int32
-	MaxPods   int32
-	Namespace string
-	Status    HPAUpdateStatus `gorm:"default:PENDING"`
-	Message   string
-	EventID   gormDatatype.UUID
-	Event     Event `gorm:"ForeignKey:EventID;constraint:OnDelete:CASCADE"`
+	Name             string
+	MinPods          *int32
+	MaxPods          int32
+	Namespace        string
+	Status           HPAUpdateStatus `gorm:"default:PENDING"`
+	Message          string
+	OriginalMinPods  *int32
+	OriginalMaxPods  *int32
+	PostEventMinPods *int32
+	PostEventMaxPods *int32
+	EventID          gormDatatype.UUID
+	Event            Event `gorm:"ForeignKey:EventID;constraint:OnDelete:CASCADE"`
 }
 
 func (s *ScheduledHPAConfig) TableName() string {
@@ -6,6 +6,7 @@ type UpdatedNodePool struct {
 	BaseModel
 	NodePoolName string
 	MaxNode      int32
+	Message      string
 	EventID      gormDatatype.UUID
 	Event        Event `gorm:"ForeignKey:EventID;constraint:OnDelete:CASCADE"`
 }
@@ -44,6 +44,6 @@ func (n *nodePoolStatus) GetAllNodePoolStatusByUpdatedNodePoolID(
 	err := tx.Model(&model.NodePoolStatus{}).Where(
 		"updated_node_pool_id = ?",
 		updatedNodePoolID,
-	).Find(&output).Error
+	).Order("created_at").Find(&output).Error
 	return output, err
 }
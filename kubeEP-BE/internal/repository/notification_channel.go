@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository/model"
+	"gorm.io/gorm"
+)
+
+type NotificationChannel interface {
+	InsertNotificationChannel(tx *gorm.DB, data *model.NotificationChannel) error
+	GetNotificationChannelByID(tx *gorm.DB, id uuid.UUID) (*model.NotificationChannel, error)
+	ListNotificationChannel(tx *gorm.DB) ([]*model.NotificationChannel, error)
+	DeleteNotificationChannel(tx *gorm.DB, id uuid.UUID) error
+}
+
+type notificationChannel struct {
+}
+
+func newNotificationChannel() NotificationChannel {
+	return &notificationChannel{}
+}
+
+func (n *notificationChannel) InsertNotificationChannel(
+	tx *gorm.DB,
+	data *model.NotificationChannel,
+) error {
+	return tx.Create(data).Error
+}
+
+func (n *notificationChannel) GetNotificationChannelByID(
+	tx *gorm.DB,
+	id uuid.UUID,
+) (*model.NotificationChannel, error) {
+	data := &model.NotificationChannel{}
+	err := tx.Model(data).First(data, id).Error
+	return data, err
+}
+
+func (n *notificationChannel) ListNotificationChannel(
+	tx *gorm.DB,
+) ([]*model.NotificationChannel, error) {
+	var data []*model.NotificationChannel
+	err := tx.Model(&model.NotificationChannel{}).Order("created_at").Find(&data).Error
+	return data, err
+}
+
+func (n *notificationChannel) DeleteNotificationChannel(tx *gorm.DB, id uuid.UUID) error {
+	return tx.Delete(&model.NotificationChannel{}, "id = ?", id).Error
+}
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository/model"
+	"gorm.io/gorm"
+)
+
+type NotificationDelivery interface {
+	InsertNotificationDelivery(tx *gorm.DB, data *model.NotificationDelivery) error
+	SaveNotificationDelivery(tx *gorm.DB, data *model.NotificationDelivery) error
+	ListNotificationDeliveryByEventID(
+		tx *gorm.DB,
+		eventID uuid.UUID,
+	) ([]*model.NotificationDelivery, error)
+	ListPendingNotificationDelivery(tx *gorm.DB) ([]*model.NotificationDelivery, error)
+}
+
+type notificationDelivery struct {
+}
+
+func newNotificationDelivery() NotificationDelivery {
+	return &notificationDelivery{}
+}
+
+func (n *notificationDelivery) InsertNotificationDelivery(
+	tx *gorm.DB,
+	data *model.NotificationDelivery,
+) error {
+	return tx.Create(data).Error
+}
+
+func (n *notificationDelivery) SaveNotificationDelivery(
+	tx *gorm.DB,
+	data *model.NotificationDelivery,
+) error {
+	return tx.Omit("Subscription", "Channel", "Event").Save(data).Error
+}
+
+func (n *notificationDelivery) ListNotificationDeliveryByEventID(
+	tx *gorm.DB,
+	eventID uuid.UUID,
+) ([]*model.NotificationDelivery, error) {
+	var data []*model.NotificationDelivery
+	err := tx.Model(&model.NotificationDelivery{}).
+		Preload("Channel").
+		Where("event_id = ?", eventID).
+		Order("created_at").
+		Find(&data).Error
+	return data, err
+}
+
+// ListPendingNotificationDelivery lists the deliveries interrupted before their last attempt
+func (n *notificationDelivery) ListPendingNotificationDelivery(
+	tx *gorm.DB,
+) ([]*model.NotificationDelivery, error) {
+	var data []*model.NotificationDelivery
+	err := tx.Model(&model.NotificationDelivery{}).
+		Preload("Channel").
+		Where("status = ?", model.NotificationDeliveryPending).
+		Order("created_at").
+		Find(&data).Error
+	return data, err
+}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository/model"
+	"gorm.io/gorm"
+)
+
+type NotificationSubscription interface {
+	InsertNotificationSubscription(tx *gorm.DB, data *model.NotificationSubscription) error
+	ListNotificationSubscription(
+		tx *gorm.DB,
+		clusterID *uuid.UUID,
+	) ([]*model.NotificationSubscription, error)
+	FindMatchingNotificationSubscription(
+		tx *gorm.DB,
+		clusterID uuid.UUID,
+		eventID uuid.UUID,
+	) ([]*model.NotificationSubscription, error)
+	DeleteNotificationSubscription(tx *gorm.DB, id uuid.UUID) error
+	DeleteNotificationSubscriptionByChannelID(tx *gorm.DB, channelID uuid.UUID) error
+}
+
+type notificationSubscription struct {
+}
+
+func newNotificationSubscription() NotificationSubscription {
+	return &notificationSubscription{}
+}
+
+func (n *notificationSubscription) InsertNotificationSubscription(
+	tx *gorm.DB,
+	data *model.NotificationSubscription,
+) error {
+	return tx.Create(data).Error
+}
+
+// ListNotificationSubscription lists every subscription, or the subscriptions of the cluster
+func (n *notificationSubscription) ListNotificationSubscription(
+	tx *gorm.DB,
+	clusterID *uuid.UUID,
+) ([]*model.NotificationSubscription, error) {
+	var data []*model.NotificationSubscription
+	tx = tx.Model(&model.NotificationSubscription{}).Preload("Channel")
+	if clusterID != nil {
+		tx = tx.Where("cluster_id = ?", *clusterID)
+	}
+	err := tx.Order("created_at").Find(&data).Error
+	return data, err
+}
+
+// FindMatchingNotificationSubscription finds the subscriptions of the event, its cluster, or every
+// cluster
+func (n *notificationSubscription) FindMatchingNotificationSubscription(
+	tx *gorm.DB,
+	clusterID uuid.UUID,
+	eventID uuid.UUID,
+) ([]*model.NotificationSubscription, error) {
+	var data []*model.NotificationSubscription
+	err := tx.Model(&model.NotificationSubscription{}).
+		Preload("Channel").
+		Where("(cluster_id is null or cluster_id = ?)", clusterID).
+		Where("(event_id is null or event_id = ?)", eventID).
+		Find(&data).Error
+	return data, err
+}
+
+func (n *notificationSubscription) DeleteNotificationSubscription(tx *gorm.DB, id uuid.UUID) error {
+	return tx.Delete(&model.NotificationSubscription{}, "id = ?", id).Error
+}
+
+func (n *notificationSubscription) DeleteNotificationSubscriptionByChannelID(
+	tx *gorm.DB,
+	channelID uuid.UUID,
+) error {
+	return tx.Delete(&model.NotificationSubscription{}, "channel_id = ?", channelID).Error
+}
@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository/model"
+	"gorm.io/gorm"
+)
+
+type PodStatus interface {
+	GetAllPodStatusByScheduledHPAConfigID(
+		tx *gorm.DB,
+		scheduledHPAConfigID uuid.UUID,
+	) ([]*model.PodStatus, error)
+}
+
+type podStatus struct {
+}
+
+func newPodStatus() PodStatus {
+	return &podStatus{}
+}
+
+func (p *podStatus) GetAllPodStatusByScheduledHPAConfigID(
+	tx *gorm.DB,
+	scheduledHPAConfigID uuid.UUID,
+) ([]*model.PodStatus, error) {
+	var data []*model.PodStatus
+	err := tx.Model(&model.PodStatus{}).Where(
+		"scheduled_hpa_config_id = ?",
+		scheduledHPAConfigID,
+	).Order("created_at").Find(&data).Error
+	return data, err
+}
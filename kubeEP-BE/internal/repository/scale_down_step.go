@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository/model"
+	"gorm.io/gorm"
+	"time"
+)
+
+type ScaleDownStep interface {
+	InsertBatchScaleDownStep(tx *gorm.DB, data []*model.ScaleDownStep) error
+	ListScaleDownStepByEventID(tx *gorm.DB, eventID uuid.UUID) ([]*model.ScaleDownStep, error)
+	ListDueScaleDownStepByEventID(
+		tx *gorm.DB,
+		eventID uuid.UUID,
+		now time.Time,
+	) ([]*model.ScaleDownStep, error)
+	CountPendingScaleDownStepByEventID(tx *gorm.DB, eventID uuid.UUID) (int64, error)
+	SaveScaleDownStep(tx *gorm.DB, data *model.ScaleDownStep) error
+}
+
+type scaleDownStep struct {
+}
+
+func newScaleDownStep() ScaleDownStep {
+	return &scaleDownStep{}
+}
+
+func (s *scaleDownStep) InsertBatchScaleDownStep(
+	tx *gorm.DB,
+	data []*model.ScaleDownStep,
+) error {
+	return tx.Create(data).Error
+}
+
+func (s *scaleDownStep) ListScaleDownStepByEventID(
+	tx *gorm.DB,
+	eventID uuid.UUID,
+) ([]*model.ScaleDownStep, error) {
+	var data []*model.ScaleDownStep
+	err := tx.Model(&model.ScaleDownStep{}).
+		Where("event_id = ?", eventID).
+		Order("scheduled_at, step_number").
+		Find(&data).Error
+	return data, err
+}
+
+func (s *scaleDownStep) ListDueScaleDownStepByEventID(
+	tx *gorm.DB,
+	eventID uuid.UUID,
+	now time.Time,
+) ([]*model.ScaleDownStep, error) {
+	var data []*model.ScaleDownStep
+	err := tx.Model(&model.ScaleDownStep{}).
+		Where(
+			"event_id = ? and status = ? and scheduled_at <= ?",
+			eventID,
+			model.ScaleDownStepPending,
+			now.UTC(),
+		).
+		Order("step_number").
+		Find(&data).Error
+	return data, err
+}
+
+func (s *scaleDownStep) CountPendingScaleDownStepByEventID(
+	tx *gorm.DB,
+	eventID uuid.UUID,
+) (int64, error) {
+	var count int64
+	err := tx.Model(&model.ScaleDownStep{}).
+		Where("event_id = ? and status = ?", eventID, model.ScaleDownStepPending).
+		Count(&count).Error
+	return count, err
+}
+
+func (s *scaleDownStep) SaveScaleDownStep(tx *gorm.DB, data *model.ScaleDownStep) error {
+	return tx.Save(data).Error
+}
@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"fmt"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/config"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository/model"
+	"gorm.io/gorm"
+)
+
+const (
+	hpaStatusAggregateView      = "hpa_status_5m"
+	nodePoolStatusAggregateView = "node_pool_status_5m"
+)
+
+var continuousAggregateQueries = map[string]string{
+	hpaStatusAggregateView: `create materialized view if not exists hpa_status_5m
+with (timescaledb.continuous) as
+select
+    time_bucket('5 minutes', created_at) as bucket,
+    scheduled_hpa_config_id,
+    avg(replicas) as avg_replicas,
+    max(replicas) as max_replicas,
+    avg(ready_replicas) as avg_ready_replicas,
+    min(ready_replicas) as min_ready_replicas,
+    max(unavailable_replicas) as max_unavailable_replicas,
+    max(hpa_max_replicas) as hpa_max_replicas,
+    max(hpa_desired_replicas) as max_hpa_desired_replicas,
+    bool_or(scaling_limited) as scaling_limited,
+    count(*) as sample_count
+from hpa_status
+group by bucket, scheduled_hpa_config_id
+with no data`,
+	nodePoolStatusAggregateView: `create materialized view if not exists node_pool_status_5m
+with (timescaledb.continuous) as
+select
+    time_bucket('5 minutes', created_at) as bucket,
+    updated_node_pool_id,
+    avg(node_count) as avg_node_count,
+    max(node_count) as max_node_count,
+    max(allocatable_cpu) as max_allocatable_cpu,
+    max(allocatable_memory) as max_allocatable_memory,
+    avg(requested_cpu) as avg_requested_cpu,
+    max(requested_cpu) as max_requested_cpu,
+    avg(requested_memory) as avg_requested_memory,
+    max(requested_memory) as max_requested_memory,
+    avg(usage_cpu) as avg_usage_cpu,
+    max(usage_cpu) as max_usage_cpu,
+    avg(usage_memory) as avg_usage_memory,
+    max(usage_memory) as max_usage_memory,
+    count(*) as sample_count
+from node_pool_status
+group by bucket, updated_node_pool_id
+with no data`,
+}
+
+// migrateTimescale creates the 5 minutes continuous aggregates of the status hypertables and
+// replaces the retention policies of the raw hypertables and the aggregates
+func migrateTimescale(db *gorm.DB, timescaleConfig config.TimescaleConfig) error {
+	for _, view := range []string{hpaStatusAggregateView, nodePoolStatusAggregateView} {
+		if err := db.Exec(continuousAggregateQueries[view]).Error; err != nil {
+			return err
+		}
+		err := db.Exec(
+			`select add_continuous_aggregate_policy(?,
+    start_offset => interval '1 day',
+    end_offset => interval '5 minutes',
+    schedule_interval => interval '5 minutes',
+    if_not_exists => true)`,
+			view,
+		).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	rawTables := []string{
+		(model.HPAStatus{}).TableName(),
+		(model.NodePoolStatus{}).TableName(),
+		(model.PodStatus{}).TableName(),
+	}
+	for _, table := range rawTables {
+		if err := setRetentionPolicy(db, table, timescaleConfig.RawRetentionDays); err != nil {
+			return err
+		}
+	}
+	for _, view := range []string{hpaStatusAggregateView, nodePoolStatusAggregateView} {
+		if err := setRetentionPolicy(db, view, timescaleConfig.AggregateRetentionDays); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func setRetentionPolicy(db *gorm.DB, relation string, days int64) error {
+	err := db.Exec(`select remove_retention_policy(?, if_exists => true)`, relation).Error
+	if err != nil {
+		return err
+	}
+	if days <= 0 {
+		return nil
+	}
+	return db.Exec(
+		`select add_retention_policy(?, cast(? as interval))`,
+		relation,
+		fmt.Sprintf("%d days", days),
+	).Error
+}
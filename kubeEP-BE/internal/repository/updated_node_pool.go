@@ -11,6 +11,12 @@ type UpdatedNodePool interface {
 		tx *gorm.DB,
 		eventID uuid.UUID,
 	) ([]*model.UpdatedNodePool, error)
+	UpdateUpdatedNodePoolMaxNode(
+		tx *gorm.DB,
+		eventID uuid.UUID,
+		nodePoolName string,
+		maxNode int32,
+	) error
 }
 
 type updatedNodePool struct {
@@ -28,3 +34,14 @@ func (u *updatedNodePool) GetAllUpdatedNodePoolByEventID(
 	err := tx.Model(&model.UpdatedNodePool{}).Where("event_id = ?", eventID).Find(&output).Error
 	return output, err
 }
+
+func (u *updatedNodePool) UpdateUpdatedNodePoolMaxNode(
+	tx *gorm.DB,
+	eventID uuid.UUID,
+	nodePoolName string,
+	maxNode int32,
+) error {
+	return tx.Model(&model.UpdatedNodePool{}).
+		Where("event_id = ? and node_pool_name = ?", eventID, nodePoolName).
+		Update("max_node", maxNode).Error
+}
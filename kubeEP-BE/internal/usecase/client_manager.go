@@ -0,0 +1,320 @@
+package useCase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"github.com/google/uuid"
+	errorConstant "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant/errors"
+	UCEntity "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/usecase"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository/model"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/oauth2/google"
+	"k8s.io/client-go/kubernetes"
+	"sync"
+	"time"
+)
+
+const defaultClientTTL = 30 * time.Minute
+
+// ClientManager caches the datacenter and kubernetes clients. Entries are rebuilt after the ttl or
+// when the stored credentials change, replaced google clients are closed once every caller holding
+// them released them
+type ClientManager interface {
+	// GetGCPClients returns the google clients of the datacenter, release must be called once the
+	// caller is done with them
+	GetGCPClients(
+		ctx context.Context,
+		datacenter UCEntity.DatacenterDetailedData,
+	) (clients *UCEntity.GCPClientsData, release func(), err error)
+	GetKubernetesClient(
+		ctx context.Context,
+		clusterData *UCEntity.ClusterData,
+	) (kubernetes.Interface, error)
+	Close()
+}
+
+type gcpClientsEntry struct {
+	clients         *UCEntity.GCPClientsData
+	credentialsHash string
+	createdAt       time.Time
+	// refs counts the callers holding the clients, a retired entry is closed when it drops to zero
+	refs    int
+	retired bool
+}
+
+type kubernetesClientEntry struct {
+	client          kubernetes.Interface
+	credentialsHash string
+	createdAt       time.Time
+}
+
+// clientManager only holds its lock to read and replace the entries, the credentials are fetched
+// and the clients are built outside of it
+type clientManager struct {
+	gcpDatacenterUC   GCPDatacenter
+	gcpClusterUC      GCPCluster
+	ttl               time.Duration
+	lock              sync.Mutex
+	gcpClients        map[uuid.UUID]*gcpClientsEntry
+	kubernetesClients map[uuid.UUID]*kubernetesClientEntry
+}
+
+func newClientManager(
+	gcpDatacenterUC GCPDatacenter,
+	gcpClusterUC GCPCluster,
+	ttl time.Duration,
+) ClientManager {
+	if ttl <= 0 {
+		ttl = defaultClientTTL
+	}
+	return &clientManager{
+		gcpDatacenterUC:   gcpDatacenterUC,
+		gcpClusterUC:      gcpClusterUC,
+		ttl:               ttl,
+		gcpClients:        map[uuid.UUID]*gcpClientsEntry{},
+		kubernetesClients: map[uuid.UUID]*kubernetesClientEntry{},
+	}
+}
+
+func (m *clientManager) GetGCPClients(
+	ctx context.Context,
+	datacenter UCEntity.DatacenterDetailedData,
+) (*UCEntity.GCPClientsData, func(), error) {
+	entry, err := m.getGCPClientsEntry(ctx, datacenter, true)
+	if err != nil {
+		return nil, nil, err
+	}
+	var once sync.Once
+	release := func() {
+		once.Do(
+			func() {
+				m.releaseGCPClientsEntry(entry)
+			},
+		)
+	}
+	return entry.clients, release, nil
+}
+
+func (m *clientManager) GetKubernetesClient(
+	ctx context.Context,
+	clusterData *UCEntity.ClusterData,
+) (kubernetes.Interface, error) {
+	switch clusterData.Datacenter.Datacenter {
+	case model.GCP:
+	default:
+		return nil, errors.New(errorConstant.DatacenterTypeNotFound)
+	}
+
+	// The kubernetes client authenticates with the registered google credentials, it does not
+	// hold the google clients
+	gcpEntry, err := m.getGCPClientsEntry(ctx, clusterData.Datacenter, false)
+	if err != nil {
+		return nil, err
+	}
+	credentialsHash := hashCredentials(
+		[]byte(gcpEntry.credentialsHash),
+		[]byte(clusterData.Certificate),
+		[]byte(clusterData.ServerEndpoint),
+	)
+
+	m.lock.Lock()
+	entry, ok := m.kubernetesClients[clusterData.ID]
+	m.lock.Unlock()
+	if ok && entry.credentialsHash == credentialsHash && time.Since(entry.createdAt) < m.ttl {
+		return entry.client, nil
+	}
+
+	client, err := m.gcpClusterUC.GetKubernetesClusterClient(clusterData.Datacenter.Name, clusterData)
+	if err != nil {
+		return nil, err
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.kubernetesClients[clusterData.ID] = &kubernetesClientEntry{
+		client:          client,
+		credentialsHash: credentialsHash,
+		createdAt:       time.Now(),
+	}
+	return client, nil
+}
+
+func (m *clientManager) Close() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	for id, entry := range m.gcpClients {
+		m.retireGCPClientsEntry(entry)
+		delete(m.gcpClients, id)
+	}
+	for id := range m.kubernetesClients {
+		delete(m.kubernetesClients, id)
+	}
+}
+
+// getGCPClientsEntry returns the cached entry or builds a new one, hold counts the caller as a
+// holder of the clients
+func (m *clientManager) getGCPClientsEntry(
+	ctx context.Context,
+	datacenter UCEntity.DatacenterDetailedData,
+	hold bool,
+) (*gcpClientsEntry, error) {
+	if datacenter.Datacenter != model.GCP {
+		return nil, errors.New(errorConstant.DatacenterMismatch)
+	}
+	credentialsHash := hashCredentials(datacenter.Credentials)
+
+	m.lock.Lock()
+	entry := m.cachedGCPClientsEntry(datacenter.ID, credentialsHash, hold)
+	m.lock.Unlock()
+	if entry != nil {
+		return entry, nil
+	}
+
+	clients, googleCredential, err := m.newGCPClients(ctx, datacenter)
+	if err != nil {
+		return nil, err
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	// Another caller may have built the clients in the meantime
+	if entry := m.cachedGCPClientsEntry(datacenter.ID, credentialsHash, hold); entry != nil {
+		closeGCPClients(clients)
+		return entry, nil
+	}
+	m.gcpClusterUC.RegisterGoogleCredentials(datacenter.Name, googleCredential)
+	if previous, ok := m.gcpClients[datacenter.ID]; ok {
+		m.retireGCPClientsEntry(previous)
+	}
+	entry = &gcpClientsEntry{
+		clients:         clients,
+		credentialsHash: credentialsHash,
+		createdAt:       time.Now(),
+	}
+	if hold {
+		entry.refs++
+	}
+	m.gcpClients[datacenter.ID] = entry
+	return entry, nil
+}
+
+// cachedGCPClientsEntry must be called with the lock held
+func (m *clientManager) cachedGCPClientsEntry(
+	datacenterID uuid.UUID,
+	credentialsHash string,
+	hold bool,
+) *gcpClientsEntry {
+	entry, ok := m.gcpClients[datacenterID]
+	if !ok || entry.credentialsHash != credentialsHash || time.Since(entry.createdAt) >= m.ttl {
+		return nil
+	}
+	if hold {
+		entry.refs++
+	}
+	return entry
+}
+
+// retireGCPClientsEntry must be called with the lock held
+func (m *clientManager) retireGCPClientsEntry(entry *gcpClientsEntry) {
+	entry.retired = true
+	if entry.refs == 0 {
+		closeGCPClients(entry.clients)
+	}
+}
+
+func (m *clientManager) releaseGCPClientsEntry(entry *gcpClientsEntry) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	entry.refs--
+	if entry.retired && entry.refs == 0 {
+		closeGCPClients(entry.clients)
+	}
+}
+
+func (m *clientManager) newGCPClients(
+	ctx context.Context,
+	datacenter UCEntity.DatacenterDetailedData,
+) (*UCEntity.GCPClientsData, *google.Credentials, error) {
+	// The clients outlive the request context
+	clientCtx := context.Background()
+	googleCredential, err := m.gcpDatacenterUC.GetGoogleCredentials(
+		ctx,
+		UCEntity.DatacenterData{
+			Credentials: datacenter.Credentials,
+			Name:        datacenter.Name,
+		},
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	clients := &UCEntity.GCPClientsData{}
+	clients.ClusterClient, err = m.gcpClusterUC.GetGoogleClusterClient(clientCtx, googleCredential)
+	if err != nil {
+		closeGCPClients(clients)
+		return nil, nil, err
+	}
+	clients.InstanceGroupManagersClient, err = m.gcpClusterUC.GetGoogleInstanceGroupManagersClient(
+		clientCtx,
+		googleCredential,
+	)
+	if err != nil {
+		closeGCPClients(clients)
+		return nil, nil, err
+	}
+	clients.InstanceTemplatesClient, err = m.gcpClusterUC.GetGoogleInstanceTemplatesClient(
+		clientCtx,
+		googleCredential,
+	)
+	if err != nil {
+		closeGCPClients(clients)
+		return nil, nil, err
+	}
+	clients.MachineTypesClient, err = m.gcpClusterUC.GetGoogleMachineTypesClient(
+		clientCtx,
+		googleCredential,
+	)
+	if err != nil {
+		closeGCPClients(clients)
+		return nil, nil, err
+	}
+	clients.RegionsClient, err = m.gcpClusterUC.GetGoogleRegionsClient(clientCtx, googleCredential)
+	if err != nil {
+		closeGCPClients(clients)
+		return nil, nil, err
+	}
+	return clients, googleCredential, nil
+}
+
+func closeGCPClients(clients *UCEntity.GCPClientsData) {
+	closers := []interface{ Close() error }{}
+	if clients.ClusterClient != nil {
+		closers = append(closers, clients.ClusterClient)
+	}
+	if clients.InstanceGroupManagersClient != nil {
+		closers = append(closers, clients.InstanceGroupManagersClient)
+	}
+	if clients.InstanceTemplatesClient != nil {
+		closers = append(closers, clients.InstanceTemplatesClient)
+	}
+	if clients.MachineTypesClient != nil {
+		closers = append(closers, clients.MachineTypesClient)
+	}
+	if clients.RegionsClient != nil {
+		closers = append(closers, clients.RegionsClient)
+	}
+	for _, closer := range closers {
+		if err := closer.Close(); err != nil {
+			log.Warnf("[ClientManager] Error closing google client : %s", err.Error())
+		}
+	}
+}
+
+func hashCredentials(parts ...[]byte) string {
+	hash := sha256.New()
+	for _, part := range parts {
+		hash.Write(part)
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
@@ -0,0 +1,147 @@
+package useCase
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-redis/redis/v8"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/config"
+	UCEntity "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/usecase"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/pkg/cloudevent"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository/model"
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// CloudEvent publishes the state changes of kubeEP, publish errors are only logged so the callers
+// never fail because of the sink
+type CloudEvent interface {
+	PublishEventChange(tx *gorm.DB, eventType string, eventData *UCEntity.Event)
+	PublishEventStatusChange(
+		tx *gorm.DB,
+		eventData *UCEntity.Event,
+		previousStatus model.EventStatus,
+	)
+	PublishHPAStatusChange(tx *gorm.DB, data *UCEntity.HPAStatusChangedData)
+	PublishNodePoolUpdate(
+		tx *gorm.DB,
+		eventData *UCEntity.Event,
+		nodePools []*model.UpdatedNodePool,
+	)
+}
+
+type cloudEvent struct {
+	sink        cloudevent.Sink
+	source      string
+	clusterRepo repository.Cluster
+}
+
+func newCloudEvent(
+	sink cloudevent.Sink,
+	source string,
+	clusterRepo repository.Cluster,
+) CloudEvent {
+	if source == "" {
+		source = cloudevent.DefaultSource
+	}
+	return &cloudEvent{
+		sink:        sink,
+		source:      source,
+		clusterRepo: clusterRepo,
+	}
+}
+
+func buildCloudEventSink(redisClient *redis.Client, cloudEventsConfig config.CloudEventsConfig) cloudevent.Sink {
+	switch cloudEventsConfig.Sink {
+	case "":
+	case cloudevent.SinkRedisStream:
+		if redisClient != nil {
+			return cloudevent.NewRedisStreamSink(
+				redisClient,
+				cloudEventsConfig.RedisStream.Stream,
+				cloudEventsConfig.RedisStream.MaxLen,
+			)
+		}
+		log.Warnf("[CloudEvent] Redis is not available, publishing is disabled")
+	default:
+		log.Warnf("[CloudEvent] Unknown sink %s, publishing is disabled", cloudEventsConfig.Sink)
+	}
+	return cloudevent.NewNopSink()
+}
+
+func (c *cloudEvent) PublishEventChange(tx *gorm.DB, eventType string, eventData *UCEntity.Event) {
+	c.publish(
+		tx,
+		eventType,
+		eventData.ID.String(),
+		buildEventSummary(tx, c.clusterRepo, eventData),
+	)
+}
+
+func (c *cloudEvent) PublishEventStatusChange(
+	tx *gorm.DB,
+	eventData *UCEntity.Event,
+	previousStatus model.EventStatus,
+) {
+	c.publish(
+		tx,
+		cloudevent.TypeEventStatusChanged,
+		eventData.ID.String(),
+		UCEntity.EventStatusChangedData{
+			Event:          buildEventSummary(tx, c.clusterRepo, eventData),
+			PreviousStatus: previousStatus,
+			Message:        eventData.Message,
+		},
+	)
+}
+
+func (c *cloudEvent) PublishHPAStatusChange(tx *gorm.DB, data *UCEntity.HPAStatusChangedData) {
+	c.publish(
+		tx,
+		cloudevent.TypeHPAStatusChanged,
+		fmt.Sprintf("%s/%s", data.Namespace, data.Name),
+		data,
+	)
+}
+
+func (c *cloudEvent) PublishNodePoolUpdate(
+	tx *gorm.DB,
+	eventData *UCEntity.Event,
+	nodePools []*model.UpdatedNodePool,
+) {
+	data := UCEntity.NodePoolUpdatedData{
+		Event:     buildEventSummary(tx, c.clusterRepo, eventData),
+		NodePools: []UCEntity.UpdatedNodePoolSummary{},
+	}
+	for _, nodePool := range nodePools {
+		data.NodePools = append(
+			data.NodePools, UCEntity.UpdatedNodePoolSummary{
+				ID:      nodePool.ID.GetUUID(),
+				Name:    nodePool.NodePoolName,
+				MaxNode: nodePool.MaxNode,
+				Message: nodePool.Message,
+			},
+		)
+	}
+	c.publish(tx, cloudevent.TypeNodePoolUpdated, eventData.ID.String(), data)
+}
+
+func (c *cloudEvent) publish(tx *gorm.DB, eventType, subject string, data interface{}) {
+	event, err := cloudevent.New(c.source, eventType, subject, data)
+	if err != nil {
+		log.Errorf("[CloudEvent] Type : %s, Error building event : %s", eventType, err.Error())
+		return
+	}
+	ctx := tx.Statement.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := c.sink.Publish(ctx, event); err != nil {
+		log.Errorf(
+			"[CloudEvent] Type : %s, Subject : %s, Error publishing event : %s",
+			eventType,
+			subject,
+			err.Error(),
+		)
+	}
+}
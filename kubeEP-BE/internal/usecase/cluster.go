@@ -9,6 +9,7 @@ import (
 	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant"
 	errorConstant "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant/errors"
 	UCEntity "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/usecase"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/pkg/util"
 	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository"
 	"golang.org/x/sync/errgroup"
 	"gorm.io/gorm"
@@ -18,8 +19,11 @@ import (
 	"k8s.io/api/autoscaling/v2beta2"
 	v1Core "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/client-go/kubernetes"
+	appsListers "k8s.io/client-go/listers/apps/v1"
 	"sync"
+	"time"
 )
 
 type Cluster interface {
@@ -28,6 +32,7 @@ type Cluster interface {
 		datacenterID uuid.UUID,
 	) ([]UCEntity.ClusterData, error)
 	GetAllClustersInLocal(tx *gorm.DB) ([]UCEntity.ClusterData, error)
+	GetClusterByName(tx *gorm.DB, name, datacenterName string) (*UCEntity.ClusterData, error)
 	GetAllHPAInCluster(
 		ctx context.Context,
 		client kubernetes.Interface,
@@ -47,6 +52,13 @@ type Cluster interface {
 		clusterID uuid.UUID,
 		latestHPAVersion constant.HPAVersion,
 	) (output []UCEntity.K8sHPAObjectData, err error)
+	GetAllK8sHPAObjectInList(
+		ctx context.Context,
+		client kubernetes.Interface,
+		clusterID uuid.UUID,
+		hpaList []UCEntity.SimpleHPAData,
+		latestHPAVersion constant.HPAVersion,
+	) ([]interface{}, error)
 	UpdateHPAK8sObjectBatch(
 		ctx context.Context,
 		client kubernetes.Interface,
@@ -75,16 +87,50 @@ type Cluster interface {
 		client kubernetes.Interface,
 		namespace string,
 	) (*UCEntity.K8sDaemonSetListData, error)
+	GetRuntimeClassOverheads(
+		ctx context.Context,
+		client kubernetes.Interface,
+	) (map[string]v1Core.ResourceList, error)
+	GetHPAStatusSnapshot(hpa interface{}) (*UCEntity.HPAStatusSnapshotData, error)
+	GetNodesRequestedResources(
+		ctx context.Context,
+		client kubernetes.Interface,
+	) (map[string]v1Core.ResourceList, error)
+	GetNodesResourceUsage(
+		ctx context.Context,
+		client kubernetes.Interface,
+	) (map[string]v1Core.ResourceList, error)
+	GetDeploymentPodStatistic(
+		ctx context.Context,
+		client kubernetes.Interface,
+		deployment *v1Apps.Deployment,
+		since time.Time,
+	) (*UCEntity.PodStatisticData, error)
+	ResolveScaleTargetRefByLister(
+		scaleTargetRef interface{},
+		namespace string,
+		deploymentLister appsListers.DeploymentLister,
+	) (*v1Apps.Deployment, error)
+	CalculateDeploymentPodStatistic(
+		pods []*v1Core.Pod,
+		failedSchedulingEvents []*v1Core.Event,
+		since time.Time,
+	) *UCEntity.PodStatisticData
+	CalculateNodesRequestedResources(pods []*v1Core.Pod) map[string]v1Core.ResourceList
 }
 
 type cluster struct {
-	validatorInst  *validator.Validate
-	clusterRepo    repository.Cluster
-	hpaRepo        repository.K8sHPA
-	namespaceRepo  repository.K8sNamespace
-	deploymentRepo repository.K8sDeployment
-	discoveryRepo  repository.K8SDiscovery
-	daemonSetRepo  repository.K8sDaemonSets
+	validatorInst    *validator.Validate
+	clusterRepo      repository.Cluster
+	hpaRepo          repository.K8sHPA
+	namespaceRepo    repository.K8sNamespace
+	deploymentRepo   repository.K8sDeployment
+	discoveryRepo    repository.K8SDiscovery
+	daemonSetRepo    repository.K8sDaemonSets
+	runtimeClassRepo repository.K8sRuntimeClass
+	podRepo          repository.K8sPod
+	eventRepo        repository.K8sEvent
+	metricsRepo      repository.K8sMetrics
 }
 
 func newCluster(
@@ -95,15 +141,23 @@ func newCluster(
 	discoveryRepo repository.K8SDiscovery,
 	deploymentRepo repository.K8sDeployment,
 	daemonSetRepo repository.K8sDaemonSets,
+	runtimeClassRepo repository.K8sRuntimeClass,
+	podRepo repository.K8sPod,
+	eventRepo repository.K8sEvent,
+	metricsRepo repository.K8sMetrics,
 ) Cluster {
 	return &cluster{
-		validatorInst:  validatorInst,
-		clusterRepo:    clusterRepo,
-		hpaRepo:        hpaRepo,
-		namespaceRepo:  namespaceRepo,
-		discoveryRepo:  discoveryRepo,
-		deploymentRepo: deploymentRepo,
-		daemonSetRepo:  daemonSetRepo,
+		validatorInst:    validatorInst,
+		clusterRepo:      clusterRepo,
+		hpaRepo:          hpaRepo,
+		namespaceRepo:    namespaceRepo,
+		discoveryRepo:    discoveryRepo,
+		deploymentRepo:   deploymentRepo,
+		daemonSetRepo:    daemonSetRepo,
+		runtimeClassRepo: runtimeClassRepo,
+		podRepo:          podRepo,
+		eventRepo:        eventRepo,
+		metricsRepo:      metricsRepo,
 	}
 }
 
@@ -177,6 +231,43 @@ func (c *cluster) GetAllClustersInLocal(tx *gorm.DB) ([]UCEntity.ClusterData, er
 	return output, nil
 }
 
+// GetClusterByName resolves a cluster referenced by name, the datacenter name is only needed when
+// the cluster name is registered in several datacenters
+func (c *cluster) GetClusterByName(
+	tx *gorm.DB,
+	name, datacenterName string,
+) (*UCEntity.ClusterData, error) {
+	clusters, err := c.clusterRepo.ListClusterWithDatacenterByName(tx, name)
+	if err != nil {
+		return nil, err
+	}
+	var output *UCEntity.ClusterData
+	for _, cluster := range clusters {
+		if datacenterName != "" && cluster.Datacenter.Name != datacenterName {
+			continue
+		}
+		if output != nil {
+			return nil, fmt.Errorf(errorConstant.ClusterAmbiguous, name)
+		}
+		output = &UCEntity.ClusterData{
+			ID:             cluster.ID.GetUUID(),
+			Name:           cluster.Name,
+			Certificate:    cluster.Certificate,
+			ServerEndpoint: cluster.ServerEndpoint,
+			Datacenter: UCEntity.DatacenterDetailedData{
+				ID:         cluster.Datacenter.ID.GetUUID(),
+				Datacenter: cluster.Datacenter.Datacenter,
+				Name:       cluster.Datacenter.Name,
+			},
+			LatestHPAAPIVersion: cluster.LatestHPAAPIVersion,
+		}
+	}
+	if output == nil {
+		return nil, fmt.Errorf(errorConstant.ClusterNotFound, name)
+	}
+	return output, nil
+}
+
 func (c *cluster) GetClusterAndDatacenterDataByClusterID(
 	tx *gorm.DB,
 	id uuid.UUID,
@@ -263,8 +354,9 @@ func (c *cluster) GetAllHPAInCluster(
 						MaxReplicas:     hpa.Spec.MaxReplicas,
 						CurrentReplicas: hpa.Status.CurrentReplicas,
 						ScaleTargetRef: UCEntity.HPAScaleTargetRef{
-							Name: hpa.Spec.ScaleTargetRef.Name,
-							Kind: hpa.Spec.ScaleTargetRef.Kind,
+							Name:       hpa.Spec.ScaleTargetRef.Name,
+							Kind:       hpa.Spec.ScaleTargetRef.Kind,
+							APIVersion: hpa.Spec.ScaleTargetRef.APIVersion,
 						},
 					},
 				)
@@ -279,8 +371,9 @@ func (c *cluster) GetAllHPAInCluster(
 						MaxReplicas:     hpa.Spec.MaxReplicas,
 						CurrentReplicas: hpa.Status.CurrentReplicas,
 						ScaleTargetRef: UCEntity.HPAScaleTargetRef{
-							Name: hpa.Spec.ScaleTargetRef.Name,
-							Kind: hpa.Spec.ScaleTargetRef.Kind,
+							Name:       hpa.Spec.ScaleTargetRef.Name,
+							Kind:       hpa.Spec.ScaleTargetRef.Kind,
+							APIVersion: hpa.Spec.ScaleTargetRef.APIVersion,
 						},
 					},
 				)
@@ -295,8 +388,9 @@ func (c *cluster) GetAllHPAInCluster(
 						MaxReplicas:     hpa.Spec.MaxReplicas,
 						CurrentReplicas: hpa.Status.CurrentReplicas,
 						ScaleTargetRef: UCEntity.HPAScaleTargetRef{
-							Name: hpa.Spec.ScaleTargetRef.Name,
-							Kind: hpa.Spec.ScaleTargetRef.Kind,
+							Name:       hpa.Spec.ScaleTargetRef.Name,
+							Kind:       hpa.Spec.ScaleTargetRef.Kind,
+							APIVersion: hpa.Spec.ScaleTargetRef.APIVersion,
 						},
 					},
 				)
@@ -619,6 +713,45 @@ func (c *cluster) ResolveScaleTargetRef(
 	return
 }
 
+// ResolveScaleTargetRefByLister resolves the scale target ref from an informer cache
+func (c *cluster) ResolveScaleTargetRefByLister(
+	scaleTargetRef interface{},
+	namespace string,
+	deploymentLister appsListers.DeploymentLister,
+) (*v1Apps.Deployment, error) {
+	var apiVersion, kind, name string
+
+	switch ref := scaleTargetRef.(type) {
+	case v1hpa.CrossVersionObjectReference:
+		apiVersion = ref.APIVersion
+		kind = ref.Kind
+		name = ref.Name
+	case v2beta1.CrossVersionObjectReference:
+		apiVersion = ref.APIVersion
+		kind = ref.Kind
+		name = ref.Name
+	case v2beta2.CrossVersionObjectReference:
+		apiVersion = ref.APIVersion
+		kind = ref.Kind
+		name = ref.Name
+	default:
+		return nil, errors.New(errorConstant.HPAVersionUnknown)
+	}
+
+	switch apiVersion {
+	case constant.AppsV1:
+	default:
+		return nil, errors.New(errorConstant.TargetRefResolveError)
+	}
+
+	switch kind {
+	case constant.Deployment:
+		return deploymentLister.Deployments(namespace).Get(name)
+	default:
+		return nil, errors.New(errorConstant.TargetRefResolveError)
+	}
+}
+
 func (c *cluster) GetAllDeployments(
 	ctx context.Context,
 	client kubernetes.Interface,
@@ -642,3 +775,251 @@ func (c *cluster) GetAllDaemonSetsInNamespace(
 	}
 	return &UCEntity.K8sDaemonSetListData{DaemonSetListObject: data}, nil
 }
+
+// GetRuntimeClassOverheads returns the fixed pod overhead of each runtime class, the overhead is
+// only set on the pods at admission so the pod templates don't have it
+func (c *cluster) GetRuntimeClassOverheads(
+	ctx context.Context,
+	client kubernetes.Interface,
+) (map[string]v1Core.ResourceList, error) {
+	data, err := c.runtimeClassRepo.GetRuntimeClassList(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	res := map[string]v1Core.ResourceList{}
+	for _, runtimeClass := range data.Items {
+		if runtimeClass.Overhead != nil {
+			res[runtimeClass.Name] = runtimeClass.Overhead.PodFixed
+		}
+	}
+	return res, nil
+}
+
+// GetDeploymentPodStatistic summarizes the pods of the deployment. Restart count is cumulative,
+// failed scheduling events, OOM kills and startup latencies only count what happened after since
+func (c *cluster) GetDeploymentPodStatistic(
+	ctx context.Context,
+	client kubernetes.Interface,
+	deployment *v1Apps.Deployment,
+	since time.Time,
+) (*UCEntity.PodStatisticData, error) {
+	selector, err := v1.LabelSelectorAsSelector(deployment.Spec.Selector)
+	if err != nil {
+		return nil, err
+	}
+	pods, err := c.podRepo.GetPodList(
+		ctx,
+		client,
+		deployment.Namespace,
+		v1.ListOptions{LabelSelector: selector.String()},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := c.eventRepo.GetEventList(
+		ctx,
+		client,
+		deployment.Namespace,
+		v1.ListOptions{
+			FieldSelector: fields.Set{
+				"involvedObject.kind": "Pod",
+				"reason":              constant.K8sFailedSchedulingReason,
+			}.String(),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	podList := make([]*v1Core.Pod, len(pods.Items))
+	for idx := range pods.Items {
+		podList[idx] = &pods.Items[idx]
+	}
+	eventList := make([]*v1Core.Event, len(events.Items))
+	for idx := range events.Items {
+		eventList[idx] = &events.Items[idx]
+	}
+	return c.CalculateDeploymentPodStatistic(podList, eventList, since), nil
+}
+
+// CalculateDeploymentPodStatistic summarizes the pods of a deployment and the failed scheduling
+// events of its namespace
+func (c *cluster) CalculateDeploymentPodStatistic(
+	pods []*v1Core.Pod,
+	failedSchedulingEvents []*v1Core.Event,
+	since time.Time,
+) *UCEntity.PodStatisticData {
+	output := &UCEntity.PodStatisticData{}
+	podNames := map[string]bool{}
+	totalStartupLatency := float64(0)
+	for _, pod := range pods {
+		podNames[pod.Name] = true
+		if pod.Status.Phase == v1Core.PodPending {
+			output.PendingPods += 1
+		}
+		for _, condition := range pod.Status.Conditions {
+			switch condition.Type {
+			case v1Core.PodScheduled:
+				if condition.Status == v1Core.ConditionFalse &&
+					condition.Reason == v1Core.PodReasonUnschedulable {
+					output.UnschedulablePods += 1
+				}
+			case v1Core.PodReady:
+				if condition.Status == v1Core.ConditionTrue &&
+					condition.LastTransitionTime.Time.After(since) {
+					latency := condition.LastTransitionTime.Sub(pod.CreationTimestamp.Time).Seconds()
+					output.StartedPods += 1
+					totalStartupLatency += latency
+					if latency > output.MaxStartupLatencySeconds {
+						output.MaxStartupLatencySeconds = latency
+					}
+				}
+			}
+		}
+		for _, containerStatus := range pod.Status.ContainerStatuses {
+			output.RestartCount += containerStatus.RestartCount
+			terminated := containerStatus.LastTerminationState.Terminated
+			if terminated != nil && terminated.Reason == constant.K8sOOMKilledReason &&
+				terminated.FinishedAt.Time.After(since) {
+				output.OOMKilledContainers += 1
+			}
+		}
+	}
+	if output.StartedPods > 0 {
+		output.AvgStartupLatencySeconds = totalStartupLatency / float64(output.StartedPods)
+	}
+
+	for _, event := range failedSchedulingEvents {
+		lastSeen := event.LastTimestamp.Time
+		if event.Series != nil {
+			lastSeen = event.Series.LastObservedTime.Time
+		}
+		if lastSeen.IsZero() {
+			lastSeen = event.EventTime.Time
+		}
+		if podNames[event.InvolvedObject.Name] && lastSeen.After(since) {
+			output.FailedSchedulingEvents += 1
+		}
+	}
+
+	return output
+}
+
+func (c *cluster) GetHPAStatusSnapshot(hpa interface{}) (*UCEntity.HPAStatusSnapshotData, error) {
+	output := &UCEntity.HPAStatusSnapshotData{}
+	var metricValues []util.HPAMetricValue
+	switch h := hpa.(type) {
+	case *v1hpa.HorizontalPodAutoscaler:
+		if h.Spec.MinReplicas != nil {
+			output.MinReplicas = *h.Spec.MinReplicas
+		}
+		output.MaxReplicas = h.Spec.MaxReplicas
+		output.CurrentReplicas = h.Status.CurrentReplicas
+		output.DesiredReplicas = h.Status.DesiredReplicas
+		if h.Spec.TargetCPUUtilizationPercentage != nil || h.Status.CurrentCPUUtilizationPercentage != nil {
+			metricValue := util.HPAMetricValue{Name: fmt.Sprintf("resource/%s", v1Core.ResourceCPU)}
+			if h.Spec.TargetCPUUtilizationPercentage != nil {
+				metricValue.Target = fmt.Sprintf("%d%%", *h.Spec.TargetCPUUtilizationPercentage)
+			}
+			if h.Status.CurrentCPUUtilizationPercentage != nil {
+				metricValue.Current = fmt.Sprintf("%d%%", *h.Status.CurrentCPUUtilizationPercentage)
+			}
+			metricValues = append(metricValues, metricValue)
+		}
+	case *v2beta1.HorizontalPodAutoscaler:
+		if h.Spec.MinReplicas != nil {
+			output.MinReplicas = *h.Spec.MinReplicas
+		}
+		output.MaxReplicas = h.Spec.MaxReplicas
+		output.CurrentReplicas = h.Status.CurrentReplicas
+		output.DesiredReplicas = h.Status.DesiredReplicas
+		for _, condition := range h.Status.Conditions {
+			if condition.Type == v2beta1.ScalingLimited && condition.Status == v1Core.ConditionTrue {
+				output.ScalingLimited = true
+				output.ScalingLimitedReason = condition.Reason
+			}
+		}
+		metricValues = util.GetV2beta1HPAMetricValues(h)
+	case *v2beta2.HorizontalPodAutoscaler:
+		if h.Spec.MinReplicas != nil {
+			output.MinReplicas = *h.Spec.MinReplicas
+		}
+		output.MaxReplicas = h.Spec.MaxReplicas
+		output.CurrentReplicas = h.Status.CurrentReplicas
+		output.DesiredReplicas = h.Status.DesiredReplicas
+		for _, condition := range h.Status.Conditions {
+			if condition.Type == v2beta2.ScalingLimited && condition.Status == v1Core.ConditionTrue {
+				output.ScalingLimited = true
+				output.ScalingLimitedReason = condition.Reason
+			}
+		}
+		metricValues = util.GetV2beta2HPAMetricValues(h)
+	default:
+		return nil, errors.New(errorConstant.HPAVersionUnknown)
+	}
+	for _, metricValue := range metricValues {
+		output.Metrics = append(
+			output.Metrics, UCEntity.HPAMetricValueData{
+				Name:    metricValue.Name,
+				Current: metricValue.Current,
+				Target:  metricValue.Target,
+			},
+		)
+	}
+	return output, nil
+}
+
+// GetNodesRequestedResources sums the requests of the scheduled, not yet finished pods on each node
+func (c *cluster) GetNodesRequestedResources(
+	ctx context.Context,
+	client kubernetes.Interface,
+) (map[string]v1Core.ResourceList, error) {
+	pods, err := c.podRepo.GetPodList(
+		ctx,
+		client,
+		"",
+		v1.ListOptions{
+			FieldSelector: fields.AndSelectors(
+				fields.OneTermNotEqualSelector("spec.nodeName", ""),
+				fields.OneTermNotEqualSelector("status.phase", string(v1Core.PodSucceeded)),
+				fields.OneTermNotEqualSelector("status.phase", string(v1Core.PodFailed)),
+			).String(),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	podList := make([]*v1Core.Pod, len(pods.Items))
+	for idx := range pods.Items {
+		podList[idx] = &pods.Items[idx]
+	}
+	return c.CalculateNodesRequestedResources(podList), nil
+}
+
+// CalculateNodesRequestedResources sums the requests of the scheduled, not yet finished pods on
+// each node
+func (c *cluster) CalculateNodesRequestedResources(pods []*v1Core.Pod) map[string]v1Core.ResourceList {
+	output := map[string]v1Core.ResourceList{}
+	for _, pod := range pods {
+		if pod.Spec.NodeName == "" ||
+			pod.Status.Phase == v1Core.PodSucceeded ||
+			pod.Status.Phase == v1Core.PodFailed {
+			continue
+		}
+		requested, ok := output[pod.Spec.NodeName]
+		if !ok {
+			requested = v1Core.ResourceList{}
+			output[pod.Spec.NodeName] = requested
+		}
+		util.AddResourceList(requested, util.CalculatePodRequests(&pod.Spec, nil))
+	}
+	return output
+}
+
+func (c *cluster) GetNodesResourceUsage(
+	ctx context.Context,
+	client kubernetes.Interface,
+) (map[string]v1Core.ResourceList, error) {
+	return c.metricsRepo.GetNodesUsage(ctx, client)
+}
@@ -14,7 +14,9 @@ type Event interface {
 	RegisterEvents(tx *gorm.DB, eventData *UCEntity.Event) (uuid.UUID, error)
 	GetEventByName(tx *gorm.DB, eventName string) (*UCEntity.Event, error)
 	ListEventByClusterID(tx *gorm.DB, clusterID uuid.UUID) ([]UCEntity.Event, error)
-	UpdateEvent(tx *gorm.DB, eventData *UCEntity.Event) error
+	CountEventByStatus(tx *gorm.DB) (map[model.EventStatus]int64, error)
+	UpdateEvent(tx *gorm.DB, eventData *UCEntity.Event) (*UCEntity.EventStatusTransition, error)
+	PublishEventStatusTransition(db *gorm.DB, transition *UCEntity.EventStatusTransition)
 	GetEventByID(tx *gorm.DB, eventID uuid.UUID) (*UCEntity.Event, error)
 	GetDetailedEventData(tx *gorm.DB, eventID uuid.UUID) (
 		*UCEntity.DetailedEvent,
@@ -29,7 +31,15 @@ type Event interface {
 		[]*UCEntity.Event,
 		error,
 	)
-	FinishAllWatchedEvent(tx *gorm.DB, now time.Time) error
+	FinishAllWatchedEvent(tx *gorm.DB, now time.Time) ([]uuid.UUID, error)
+	GetAllFinishedWatchedEventWithScaleDown(tx *gorm.DB, now time.Time) (
+		[]*UCEntity.Event,
+		error,
+	)
+	GetAllScalingDownEvent(tx *gorm.DB, now time.Time) (
+		[]*UCEntity.Event,
+		error,
+	)
 	GetAllPrescaledEvent(tx *gorm.DB, now time.Time) (
 		[]*UCEntity.Event,
 		error,
@@ -41,6 +51,8 @@ type event struct {
 	eventRepository              repository.Event
 	scheduledHPAConfigRepository repository.ScheduledHPAConfig
 	clusterRepository            repository.Cluster
+	cloudEventUC                 CloudEvent
+	eventStreamUC                EventStream
 }
 
 func newEvent(
@@ -48,23 +60,33 @@ func newEvent(
 	eventRepository repository.Event,
 	scheduledHPAConfigRepository repository.ScheduledHPAConfig,
 	clusterRepository repository.Cluster,
+	cloudEventUC CloudEvent,
+	eventStreamUC EventStream,
 ) Event {
 	return &event{
 		validatorInst:                validatorInst,
 		eventRepository:              eventRepository,
 		scheduledHPAConfigRepository: scheduledHPAConfigRepository,
 		clusterRepository:            clusterRepository,
+		cloudEventUC:                 cloudEventUC,
+		eventStreamUC:                eventStreamUC,
 	}
 }
 
 func (e *event) RegisterEvents(tx *gorm.DB, eventData *UCEntity.Event) (uuid.UUID, error) {
 	data := &model.Event{
-		Name:              eventData.Name,
-		StartTime:         eventData.StartTime,
-		EndTime:           eventData.EndTime,
-		CalculateNodePool: eventData.CalculateNodePool,
-		ExecuteConfigAt:   eventData.ExecuteConfigAt,
-		WatchingAt:        eventData.WatchingAt,
+		Name:                    eventData.Name,
+		StartTime:               eventData.StartTime,
+		EndTime:                 eventData.EndTime,
+		CalculateNodePool:       eventData.CalculateNodePool,
+		ScaleDownWindowMinutes:  eventData.ScaleDown.WindowMinutes,
+		ScaleDownSteps:          eventData.ScaleDown.Steps,
+		ScaleDownGate:           eventData.ScaleDown.Gate,
+		WatchIntervalSeconds:    eventData.Watch.IntervalSeconds,
+		WatchMaxIntervalSeconds: eventData.Watch.MaxIntervalSeconds,
+		ExecuteConfigAt:         eventData.ExecuteConfigAt,
+		WatchingAt:              eventData.WatchingAt,
+		Owner:                   eventData.Owner,
 	}
 	data.ClusterID.SetUUID(eventData.Cluster.ID)
 
@@ -92,6 +114,16 @@ func (e *event) GetEventByName(tx *gorm.DB, eventName string) (*UCEntity.Event,
 		Status:            data.Status,
 		Message:           data.Message,
 		CalculateNodePool: data.CalculateNodePool,
+		ScaleDown: UCEntity.EventScaleDownConfig{
+			WindowMinutes: data.ScaleDownWindowMinutes,
+			Steps:         data.ScaleDownSteps,
+			Gate:          data.ScaleDownGate,
+		},
+		Watch: UCEntity.EventWatchConfig{
+			IntervalSeconds:    data.WatchIntervalSeconds,
+			MaxIntervalSeconds: data.WatchMaxIntervalSeconds,
+		},
+		Owner: data.Owner,
 	}, nil
 }
 
@@ -112,7 +144,17 @@ func (e *event) GetEventByID(tx *gorm.DB, eventID uuid.UUID) (*UCEntity.Event, e
 		Status:            data.Status,
 		Message:           data.Message,
 		CalculateNodePool: data.CalculateNodePool,
-		Cluster:           UCEntity.ClusterData{ID: data.ClusterID.GetUUID()},
+		ScaleDown: UCEntity.EventScaleDownConfig{
+			WindowMinutes: data.ScaleDownWindowMinutes,
+			Steps:         data.ScaleDownSteps,
+			Gate:          data.ScaleDownGate,
+		},
+		Watch: UCEntity.EventWatchConfig{
+			IntervalSeconds:    data.WatchIntervalSeconds,
+			MaxIntervalSeconds: data.WatchMaxIntervalSeconds,
+		},
+		Cluster: UCEntity.ClusterData{ID: data.ClusterID.GetUUID()},
+		Owner:   data.Owner,
 	}, nil
 }
 
@@ -136,28 +178,74 @@ func (e *event) ListEventByClusterID(tx *gorm.DB, clusterID uuid.UUID) ([]UCEnti
 				Status:            event.Status,
 				Message:           event.Message,
 				CalculateNodePool: event.CalculateNodePool,
+				ScaleDown: UCEntity.EventScaleDownConfig{
+					WindowMinutes: event.ScaleDownWindowMinutes,
+					Steps:         event.ScaleDownSteps,
+					Gate:          event.ScaleDownGate,
+				},
+				Watch: UCEntity.EventWatchConfig{
+					IntervalSeconds:    event.WatchIntervalSeconds,
+					MaxIntervalSeconds: event.WatchMaxIntervalSeconds,
+				},
+				Owner: event.Owner,
 			},
 		)
 	}
 	return output, nil
 }
 
-func (e *event) UpdateEvent(tx *gorm.DB, eventData *UCEntity.Event) error {
+func (e *event) CountEventByStatus(tx *gorm.DB) (map[model.EventStatus]int64, error) {
+	return e.eventRepository.CountEventByStatus(tx)
+}
+
+// UpdateEvent returns the status change of the event instead of publishing it, the caller publishes
+// it after its transaction is committed
+func (e *event) UpdateEvent(tx *gorm.DB, eventData *UCEntity.Event) (
+	*UCEntity.EventStatusTransition,
+	error,
+) {
 	data := &model.Event{
-		Name:              eventData.Name,
-		StartTime:         eventData.StartTime,
-		EndTime:           eventData.EndTime,
-		Status:            eventData.Status,
-		Message:           eventData.Message,
-		CalculateNodePool: eventData.CalculateNodePool,
-		ExecuteConfigAt:   eventData.ExecuteConfigAt,
-		WatchingAt:        eventData.WatchingAt,
+		Name:                    eventData.Name,
+		StartTime:               eventData.StartTime,
+		EndTime:                 eventData.EndTime,
+		Status:                  eventData.Status,
+		Message:                 eventData.Message,
+		CalculateNodePool:       eventData.CalculateNodePool,
+		ScaleDownWindowMinutes:  eventData.ScaleDown.WindowMinutes,
+		ScaleDownSteps:          eventData.ScaleDown.Steps,
+		ScaleDownGate:           eventData.ScaleDown.Gate,
+		WatchIntervalSeconds:    eventData.Watch.IntervalSeconds,
+		WatchMaxIntervalSeconds: eventData.Watch.MaxIntervalSeconds,
+		ExecuteConfigAt:         eventData.ExecuteConfigAt,
+		WatchingAt:              eventData.WatchingAt,
 	}
 	data.CreatedAt = eventData.CreatedAt
 	data.UpdatedAt = eventData.UpdatedAt
 	data.ID.SetUUID(eventData.ID)
 	data.ClusterID.SetUUID(eventData.Cluster.ID)
-	return e.eventRepository.SaveEvent(tx, data)
+
+	var previousStatus model.EventStatus
+	if previousData, err := e.eventRepository.GetEventByID(tx, eventData.ID); err == nil {
+		previousStatus = previousData.Status
+	}
+	if err := e.eventRepository.SaveEvent(tx, data); err != nil {
+		return nil, err
+	}
+	if previousStatus == "" || previousStatus == eventData.Status {
+		return nil, nil
+	}
+	return &UCEntity.EventStatusTransition{Event: *eventData, PreviousStatus: previousStatus}, nil
+}
+
+func (e *event) PublishEventStatusTransition(
+	db *gorm.DB,
+	transition *UCEntity.EventStatusTransition,
+) {
+	if transition == nil {
+		return
+	}
+	e.cloudEventUC.PublishEventStatusChange(db, &transition.Event, transition.PreviousStatus)
+	e.eventStreamUC.PublishEventStatus(db, &transition.Event, transition.PreviousStatus)
 }
 
 func (e *event) GetDetailedEventData(tx *gorm.DB, eventID uuid.UUID) (
@@ -198,6 +286,15 @@ func (e *event) GetDetailedEventData(tx *gorm.DB, eventID uuid.UUID) (
 			Message:           eventData.Message,
 			EndTime:           eventData.EndTime,
 			CalculateNodePool: eventData.CalculateNodePool,
+			ScaleDown: UCEntity.EventScaleDownConfig{
+				WindowMinutes: eventData.ScaleDownWindowMinutes,
+				Steps:         eventData.ScaleDownSteps,
+				Gate:          eventData.ScaleDownGate,
+			},
+			Watch: UCEntity.EventWatchConfig{
+				IntervalSeconds:    eventData.WatchIntervalSeconds,
+				MaxIntervalSeconds: eventData.WatchMaxIntervalSeconds,
+			},
 			Cluster: UCEntity.ClusterData{
 				ID:   eventData.ClusterID.GetUUID(),
 				Name: clusterData.Name,
@@ -206,6 +303,7 @@ func (e *event) GetDetailedEventData(tx *gorm.DB, eventID uuid.UUID) (
 					Name:       clusterData.Datacenter.Name,
 				},
 			},
+			Owner: eventData.Owner,
 		},
 	}
 
@@ -220,6 +318,11 @@ func (e *event) GetDetailedEventData(tx *gorm.DB, eventID uuid.UUID) (
 				Message:     hpa.Message,
 				MinReplicas: hpa.MinPods,
 				MaxReplicas: hpa.MaxPods,
+
+				OriginalMinReplicas:  hpa.OriginalMinPods,
+				OriginalMaxReplicas:  hpa.OriginalMaxPods,
+				PostEventMinReplicas: hpa.PostEventMinPods,
+				PostEventMaxReplicas: hpa.PostEventMaxPods,
 			},
 		)
 	}
@@ -255,7 +358,16 @@ func (e *event) GetAllPrescaledEvent(tx *gorm.DB, now time.Time) (
 				StartTime:         event.StartTime,
 				EndTime:           event.EndTime,
 				CalculateNodePool: event.CalculateNodePool,
-				Cluster:           UCEntity.ClusterData{Name: event.Cluster.Name, ID: event.ClusterID.GetUUID(), Datacenter: UCEntity.DatacenterDetailedData{Datacenter: event.Cluster.Datacenter.Datacenter}},
+				ScaleDown: UCEntity.EventScaleDownConfig{
+					WindowMinutes: event.ScaleDownWindowMinutes,
+					Steps:         event.ScaleDownSteps,
+					Gate:          event.ScaleDownGate,
+				},
+				Watch: UCEntity.EventWatchConfig{
+					IntervalSeconds:    event.WatchIntervalSeconds,
+					MaxIntervalSeconds: event.WatchMaxIntervalSeconds,
+				},
+				Cluster: UCEntity.ClusterData{Name: event.Cluster.Name, ID: event.ClusterID.GetUUID(), Datacenter: UCEntity.DatacenterDetailedData{Datacenter: event.Cluster.Datacenter.Datacenter}},
 			},
 		)
 	}
@@ -286,7 +398,16 @@ func (e *event) GetAllPendingExecutableEvent(tx *gorm.DB, now time.Time) (
 				StartTime:         event.StartTime,
 				EndTime:           event.EndTime,
 				CalculateNodePool: event.CalculateNodePool,
-				Cluster:           UCEntity.ClusterData{Name: event.Cluster.Name, ID: event.ClusterID.GetUUID(), Datacenter: UCEntity.DatacenterDetailedData{Datacenter: event.Cluster.Datacenter.Datacenter}},
+				ScaleDown: UCEntity.EventScaleDownConfig{
+					WindowMinutes: event.ScaleDownWindowMinutes,
+					Steps:         event.ScaleDownSteps,
+					Gate:          event.ScaleDownGate,
+				},
+				Watch: UCEntity.EventWatchConfig{
+					IntervalSeconds:    event.WatchIntervalSeconds,
+					MaxIntervalSeconds: event.WatchMaxIntervalSeconds,
+				},
+				Cluster: UCEntity.ClusterData{Name: event.Cluster.Name, ID: event.ClusterID.GetUUID(), Datacenter: UCEntity.DatacenterDetailedData{Datacenter: event.Cluster.Datacenter.Datacenter}},
 			},
 		)
 	}
@@ -322,7 +443,16 @@ func (e *event) GetAllPrescaledEvent10MinBeforeStart(tx *gorm.DB, now time.Time)
 				StartTime:         event.StartTime,
 				EndTime:           event.EndTime,
 				CalculateNodePool: event.CalculateNodePool,
-				Cluster:           UCEntity.ClusterData{Name: event.Cluster.Name, ID: event.ClusterID.GetUUID(), Datacenter: UCEntity.DatacenterDetailedData{Datacenter: event.Cluster.Datacenter.Datacenter}},
+				ScaleDown: UCEntity.EventScaleDownConfig{
+					WindowMinutes: event.ScaleDownWindowMinutes,
+					Steps:         event.ScaleDownSteps,
+					Gate:          event.ScaleDownGate,
+				},
+				Watch: UCEntity.EventWatchConfig{
+					IntervalSeconds:    event.WatchIntervalSeconds,
+					MaxIntervalSeconds: event.WatchMaxIntervalSeconds,
+				},
+				Cluster: UCEntity.ClusterData{Name: event.Cluster.Name, ID: event.ClusterID.GetUUID(), Datacenter: UCEntity.DatacenterDetailedData{Datacenter: event.Cluster.Datacenter.Datacenter}},
 			},
 		)
 	}
@@ -330,6 +460,101 @@ func (e *event) GetAllPrescaledEvent10MinBeforeStart(tx *gorm.DB, now time.Time)
 	return eventsData, nil
 }
 
-func (e *event) FinishAllWatchedEvent(tx *gorm.DB, now time.Time) error {
-	return e.eventRepository.FinishWatchedEvent(tx, now)
+func (e *event) FinishAllWatchedEvent(tx *gorm.DB, now time.Time) ([]uuid.UUID, error) {
+	events, err := e.eventRepository.FinishWatchedEvent(tx, now)
+	if err != nil {
+		return nil, err
+	}
+	var eventIDs []uuid.UUID
+	for _, eventData := range e.buildEventsWithCluster(events) {
+		eventIDs = append(eventIDs, eventData.ID)
+		e.cloudEventUC.PublishEventStatusChange(tx, eventData, model.EventWatching)
+		e.eventStreamUC.PublishEventStatus(tx, eventData, model.EventWatching)
+	}
+	return eventIDs, nil
+}
+
+func (e *event) GetAllFinishedWatchedEventWithScaleDown(tx *gorm.DB, now time.Time) (
+	[]*UCEntity.Event,
+	error,
+) {
+	events, err := e.eventRepository.FindEventByEndTime(tx, model.EventWatching, true, now)
+	if err != nil {
+		return nil, err
+	}
+	return e.buildEventsWithCluster(events), nil
+}
+
+func (e *event) GetAllScalingDownEvent(tx *gorm.DB, now time.Time) (
+	[]*UCEntity.Event,
+	error,
+) {
+	events, err := e.eventRepository.FindEventByEndTime(tx, model.EventScalingDown, true, now)
+	if err != nil {
+		return nil, err
+	}
+	return e.buildEventsWithCluster(events), nil
+}
+
+func (e *event) buildEventsWithCluster(events []*model.Event) []*UCEntity.Event {
+	var eventsData []*UCEntity.Event
+	for _, event := range events {
+		eventsData = append(
+			eventsData, &UCEntity.Event{
+				CreatedAt:         event.CreatedAt,
+				UpdatedAt:         event.UpdatedAt,
+				ID:                event.ID.GetUUID(),
+				Status:            event.Status,
+				Name:              event.Name,
+				ExecuteConfigAt:   event.ExecuteConfigAt,
+				WatchingAt:        event.WatchingAt,
+				Message:           event.Message,
+				StartTime:         event.StartTime,
+				EndTime:           event.EndTime,
+				CalculateNodePool: event.CalculateNodePool,
+				ScaleDown: UCEntity.EventScaleDownConfig{
+					WindowMinutes: event.ScaleDownWindowMinutes,
+					Steps:         event.ScaleDownSteps,
+					Gate:          event.ScaleDownGate,
+				},
+				Watch: UCEntity.EventWatchConfig{
+					IntervalSeconds:    event.WatchIntervalSeconds,
+					MaxIntervalSeconds: event.WatchMaxIntervalSeconds,
+				},
+				Cluster: UCEntity.ClusterData{
+					Name: event.Cluster.Name,
+					ID:   event.ClusterID.GetUUID(),
+					Datacenter: UCEntity.DatacenterDetailedData{
+						Datacenter: event.Cluster.Datacenter.Datacenter,
+					},
+				},
+			},
+		)
+	}
+	return eventsData
+}
+
+// buildEventSummary resolves the cluster name when the event data only has the cluster id
+func buildEventSummary(
+	tx *gorm.DB,
+	clusterRepository repository.Cluster,
+	eventData *UCEntity.Event,
+) UCEntity.EventSummary {
+	clusterName := eventData.Cluster.Name
+	if clusterName == "" {
+		if clusterData, err := clusterRepository.GetClusterByID(tx, eventData.Cluster.ID); err == nil {
+			clusterName = clusterData.Name
+		}
+	}
+	return UCEntity.EventSummary{
+		ID:              eventData.ID,
+		Name:            eventData.Name,
+		ClusterID:       eventData.Cluster.ID,
+		ClusterName:     clusterName,
+		Status:          eventData.Status,
+		StartTime:       eventData.StartTime,
+		EndTime:         eventData.EndTime,
+		ExecuteConfigAt: eventData.ExecuteConfigAt,
+		WatchingAt:      eventData.WatchingAt,
+	}
 }
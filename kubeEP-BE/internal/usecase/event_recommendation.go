@@ -0,0 +1,224 @@
+package useCase
+
+import (
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	errorConstant "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant/errors"
+	UCEntity "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/usecase"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/pkg/util"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository/model"
+	"gorm.io/gorm"
+	"math"
+	"sort"
+)
+
+const (
+	// Number of the latest successful events with the recommended hpa the recommendation is based on
+	recommendationEventLimit = 5
+	// Max replicas and node count recommended above the p95 of the observed samples
+	recommendationSafetyMargin = 1.2
+)
+
+type EventRecommendation interface {
+	RecommendEventConfig(
+		tx *gorm.DB,
+		clusterID uuid.UUID,
+		hpas []UCEntity.SimpleHPAData,
+	) (*UCEntity.EventRecommendationData, error)
+}
+
+type eventRecommendation struct {
+	eventUC     Event
+	statisticUC Statistic
+}
+
+func newEventRecommendation(eventUC Event, statisticUC Statistic) EventRecommendation {
+	return &eventRecommendation{
+		eventUC:     eventUC,
+		statisticUC: statisticUC,
+	}
+}
+
+type hpaRecommendationSamples struct {
+	name           string
+	namespace      string
+	latestEventHPA *UCEntity.EventModifiedHPAConfigData
+	replicas       []float64
+}
+
+type nodePoolRecommendationSamples struct {
+	name   string
+	counts []float64
+}
+
+// RecommendEventConfig computes the hpa and node pool config of a new event from the recorded
+// statuses of the latest successful events of the cluster. The hpa of the latest event are used
+// when hpas is empty
+func (e *eventRecommendation) RecommendEventConfig(
+	tx *gorm.DB,
+	clusterID uuid.UUID,
+	hpas []UCEntity.SimpleHPAData,
+) (*UCEntity.EventRecommendationData, error) {
+	events, err := e.eventUC.ListEventByClusterID(tx, clusterID)
+	if err != nil {
+		return nil, err
+	}
+	var pastEvents []UCEntity.Event
+	for _, eventData := range events {
+		if eventData.Status == model.EventSuccess {
+			pastEvents = append(pastEvents, eventData)
+		}
+	}
+	if len(pastEvents) == 0 {
+		return nil, errors.New(errorConstant.EventRecommendationNoHistory)
+	}
+	sort.Slice(
+		pastEvents, func(i, j int) bool {
+			return pastEvents[i].StartTime.After(pastEvents[j].StartTime)
+		},
+	)
+
+	output := &UCEntity.EventRecommendationData{
+		BasedOnEvents: []uuid.UUID{},
+		HPAs:          []UCEntity.HPARecommendationData{},
+		NodePools:     []UCEntity.NodePoolRecommendationData{},
+	}
+	hpaSamples := map[string]*hpaRecommendationSamples{}
+	var hpaKeys []string
+	for _, hpa := range hpas {
+		key := fmt.Sprintf("%s/%s", hpa.Namespace, hpa.Name)
+		if _, ok := hpaSamples[key]; ok {
+			continue
+		}
+		hpaSamples[key] = &hpaRecommendationSamples{name: hpa.Name, namespace: hpa.Namespace}
+		hpaKeys = append(hpaKeys, key)
+	}
+	nodePoolSamples := map[string]*nodePoolRecommendationSamples{}
+	var nodePoolNames []string
+
+	// Only the events with one of the hpa count toward the limit, an hpa missing from the latest
+	// events still gets the samples of the older events
+	for i, pastEvent := range pastEvents {
+		if len(output.BasedOnEvents) >= recommendationEventLimit {
+			break
+		}
+		eventData, err := e.eventUC.GetDetailedEventData(tx, pastEvent.ID)
+		if err != nil {
+			return nil, err
+		}
+		if i == 0 {
+			output.LatestEvent = &eventData.Event
+			if len(hpas) == 0 {
+				for _, hpa := range eventData.EventModifiedHPAConfigData {
+					key := fmt.Sprintf("%s/%s", hpa.Namespace, hpa.Name)
+					hpaSamples[key] = &hpaRecommendationSamples{name: hpa.Name, namespace: hpa.Namespace}
+					hpaKeys = append(hpaKeys, key)
+				}
+			}
+		}
+
+		used := false
+		for _, hpa := range eventData.EventModifiedHPAConfigData {
+			samples, ok := hpaSamples[fmt.Sprintf("%s/%s", hpa.Namespace, hpa.Name)]
+			if !ok {
+				continue
+			}
+			used = true
+			if samples.latestEventHPA == nil {
+				latestEventHPA := hpa
+				samples.latestEventHPA = &latestEventHPA
+			}
+			statuses, err := e.statisticUC.GetAllHPAStatusByScheduledHPAConfigID(tx, hpa.ID)
+			if err != nil {
+				return nil, err
+			}
+			for _, status := range statuses {
+				samples.replicas = append(samples.replicas, float64(status.Replicas))
+			}
+		}
+		if !used {
+			continue
+		}
+		output.BasedOnEvents = append(output.BasedOnEvents, eventData.ID)
+
+		updatedNodePools, err := e.statisticUC.GetAllUpdatedNodePoolByEvent(tx, eventData.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, updatedNodePool := range updatedNodePools {
+			samples, ok := nodePoolSamples[updatedNodePool.NodePoolName]
+			if !ok {
+				samples = &nodePoolRecommendationSamples{name: updatedNodePool.NodePoolName}
+				nodePoolSamples[updatedNodePool.NodePoolName] = samples
+				nodePoolNames = append(nodePoolNames, updatedNodePool.NodePoolName)
+			}
+			statuses, err := e.statisticUC.GetAllNodePoolStatusByUpdatedNodePoolID(
+				tx,
+				updatedNodePool.ID,
+			)
+			if err != nil {
+				return nil, err
+			}
+			for _, status := range statuses {
+				samples.counts = append(samples.counts, float64(status.Count))
+			}
+		}
+	}
+
+	for _, key := range hpaKeys {
+		output.HPAs = append(output.HPAs, buildHPARecommendation(hpaSamples[key]))
+	}
+	for _, name := range nodePoolNames {
+		output.NodePools = append(output.NodePools, buildNodePoolRecommendation(nodePoolSamples[name]))
+	}
+	return output, nil
+}
+
+func buildHPARecommendation(samples *hpaRecommendationSamples) UCEntity.HPARecommendationData {
+	output := UCEntity.HPARecommendationData{
+		Name:         samples.name,
+		Namespace:    samples.namespace,
+		SampleCount:  len(samples.replicas),
+		P50Replicas:  util.Percentile(samples.replicas, 50),
+		P95Replicas:  util.Percentile(samples.replicas, 95),
+		PeakReplicas: int32(util.Percentile(samples.replicas, 100)),
+	}
+	if samples.latestEventHPA != nil {
+		output.PostEventMinReplicas = samples.latestEventHPA.PostEventMinReplicas
+		output.PostEventMaxReplicas = samples.latestEventHPA.PostEventMaxReplicas
+	}
+
+	output.MaxReplicas = int32(math.Ceil(output.P95Replicas * recommendationSafetyMargin))
+	output.MinReplicas = int32(math.Ceil(output.P50Replicas))
+	// Without samples the config of the latest event is kept
+	if output.SampleCount == 0 && samples.latestEventHPA != nil {
+		output.MaxReplicas = samples.latestEventHPA.MaxReplicas
+		if samples.latestEventHPA.MinReplicas != nil {
+			output.MinReplicas = *samples.latestEventHPA.MinReplicas
+		}
+	}
+	if output.MaxReplicas < 1 {
+		output.MaxReplicas = 1
+	}
+	if output.MinReplicas < 1 {
+		output.MinReplicas = 1
+	}
+	if output.MinReplicas > output.MaxReplicas {
+		output.MinReplicas = output.MaxReplicas
+	}
+	return output
+}
+
+func buildNodePoolRecommendation(
+	samples *nodePoolRecommendationSamples,
+) UCEntity.NodePoolRecommendationData {
+	output := UCEntity.NodePoolRecommendationData{
+		NodePoolName:  samples.name,
+		SampleCount:   len(samples.counts),
+		P95NodeCount:  util.Percentile(samples.counts, 95),
+		PeakNodeCount: int32(util.Percentile(samples.counts, 100)),
+	}
+	output.ExpectedNodeCount = int32(math.Ceil(output.P95NodeCount * recommendationSafetyMargin))
+	return output
+}
@@ -0,0 +1,248 @@
+package useCase
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/google/uuid"
+	errorConstant "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant/errors"
+	UCEntity "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/usecase"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository/model"
+	"gorm.io/gorm"
+	"math"
+	"time"
+)
+
+const (
+	// Replicas and nodes recommended above the observed peak
+	reportHeadroomRatio = 1.2
+	// Max replicas recommended above the configured max when the HPA was pinned at max
+	reportHitMaxRatio = 1.5
+	// The replicas needed right after the start time are recommended as min replicas
+	reportStartWindow = 15 * time.Minute
+)
+
+type EventReport interface {
+	GenerateEventReport(tx *gorm.DB, eventID uuid.UUID) (*UCEntity.EventReportData, error)
+	GetEventReport(tx *gorm.DB, eventID uuid.UUID) (*UCEntity.EventReportData, error)
+}
+
+type eventReport struct {
+	eventUC         Event
+	statisticUC     Statistic
+	eventReportRepo repository.EventReport
+}
+
+func newEventReport(
+	eventUC Event,
+	statisticUC Statistic,
+	eventReportRepo repository.EventReport,
+) EventReport {
+	return &eventReport{
+		eventUC:         eventUC,
+		statisticUC:     statisticUC,
+		eventReportRepo: eventReportRepo,
+	}
+}
+
+// GenerateEventReport summarizes the recorded statuses of the event and stores the report
+func (e *eventReport) GenerateEventReport(
+	tx *gorm.DB,
+	eventID uuid.UUID,
+) (*UCEntity.EventReportData, error) {
+	eventData, err := e.eventUC.GetDetailedEventData(tx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &UCEntity.EventReportData{
+		EventID:     eventData.ID,
+		EventName:   eventData.Name,
+		StartTime:   eventData.StartTime,
+		EndTime:     eventData.EndTime,
+		GeneratedAt: time.Now(),
+		HPAs:        []UCEntity.EventReportHPAData{},
+		NodePools:   []UCEntity.EventReportNodePoolData{},
+	}
+
+	for _, hpa := range eventData.EventModifiedHPAConfigData {
+		hpaReport, err := e.buildHPAReport(tx, &eventData.Event, hpa)
+		if err != nil {
+			return nil, err
+		}
+		report.HPAs = append(report.HPAs, *hpaReport)
+	}
+
+	updatedNodePools, err := e.statisticUC.GetAllUpdatedNodePoolByEvent(tx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	for _, updatedNodePool := range updatedNodePools {
+		nodePoolReport, err := e.buildNodePoolReport(tx, updatedNodePool)
+		if err != nil {
+			return nil, err
+		}
+		report.NodePools = append(report.NodePools, *nodePoolReport)
+	}
+
+	reportJSON, err := json.Marshal(report)
+	if err != nil {
+		return nil, err
+	}
+	data := &model.EventReport{}
+	data.EventID.SetUUID(eventID)
+	data.Report.SetRawMessage(reportJSON)
+	if err := e.eventReportRepo.SaveEventReport(tx, data); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// GetEventReport returns the stored report, the report of a successful event without one is
+// generated
+func (e *eventReport) GetEventReport(
+	tx *gorm.DB,
+	eventID uuid.UUID,
+) (*UCEntity.EventReportData, error) {
+	data, err := e.eventReportRepo.GetEventReportByEventID(tx, eventID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		eventData, err := e.eventUC.GetEventByID(tx, eventID)
+		if err != nil {
+			return nil, err
+		}
+		if eventData.Status != model.EventSuccess {
+			return nil, errors.New(errorConstant.EventReportNotExist)
+		}
+		return e.GenerateEventReport(tx, eventID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	report := &UCEntity.EventReportData{}
+	if err := json.Unmarshal(data.Report.GetRawMessage(), report); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+func (e *eventReport) buildHPAReport(
+	tx *gorm.DB,
+	eventData *UCEntity.Event,
+	hpa UCEntity.EventModifiedHPAConfigData,
+) (*UCEntity.EventReportHPAData, error) {
+	output := &UCEntity.EventReportHPAData{
+		Name:                 hpa.Name,
+		Namespace:            hpa.Namespace,
+		MinReplicas:          hpa.MinReplicas,
+		MaxReplicas:          hpa.MaxReplicas,
+		HitMaxIntervals:      []UCEntity.EventReportIntervalData{},
+		UnavailableIntervals: []UCEntity.EventReportIntervalData{},
+	}
+
+	statuses, err := e.statisticUC.GetAllHPAStatusByScheduledHPAConfigID(tx, hpa.ID)
+	if err != nil {
+		return nil, err
+	}
+	hitMaxIntervals, err := e.statisticUC.GetHPAHitMaxIntervals(tx, hpa.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, interval := range hitMaxIntervals {
+		output.HitMaxIntervals = append(
+			output.HitMaxIntervals, UCEntity.EventReportIntervalData{
+				StartTime: interval.StartTime,
+				EndTime:   interval.EndTime,
+			},
+		)
+	}
+
+	readyTarget := int32(1)
+	if hpa.MinReplicas != nil && *hpa.MinReplicas > readyTarget {
+		readyTarget = *hpa.MinReplicas
+	}
+	startPeakReplicas := int32(0)
+	var currentUnavailable *UCEntity.EventReportIntervalData
+	for _, status := range statuses {
+		if status.Replicas > output.PeakReplicas {
+			output.PeakReplicas = status.Replicas
+			peakAt := status.CreatedAt
+			output.PeakReplicasAt = &peakAt
+		}
+		if status.DesiredReplicas > output.PeakDesiredReplicas {
+			output.PeakDesiredReplicas = status.DesiredReplicas
+		}
+		if !status.CreatedAt.Before(eventData.StartTime) &&
+			status.CreatedAt.Before(eventData.StartTime.Add(reportStartWindow)) &&
+			status.Replicas > startPeakReplicas {
+			startPeakReplicas = status.Replicas
+		}
+		if output.TimeToReadySeconds == nil && status.ReadyReplicas >= readyTarget {
+			timeToReady := status.CreatedAt.Sub(eventData.ExecuteConfigAt).Seconds()
+			output.TimeToReadySeconds = &timeToReady
+		}
+
+		if status.UnavailableReplicas <= 0 {
+			currentUnavailable = nil
+			continue
+		}
+		if currentUnavailable == nil {
+			output.UnavailableIntervals = append(
+				output.UnavailableIntervals,
+				UCEntity.EventReportIntervalData{StartTime: status.CreatedAt},
+			)
+			currentUnavailable = &output.UnavailableIntervals[len(output.UnavailableIntervals)-1]
+		}
+		currentUnavailable.EndTime = status.CreatedAt
+	}
+
+	recommendedMax := int32(math.Ceil(float64(output.PeakReplicas) * reportHeadroomRatio))
+	if len(output.HitMaxIntervals) > 0 {
+		hitMaxRecommendation := int32(math.Ceil(float64(hpa.MaxReplicas) * reportHitMaxRatio))
+		if hitMaxRecommendation > recommendedMax {
+			recommendedMax = hitMaxRecommendation
+		}
+	}
+	if recommendedMax < 1 {
+		recommendedMax = hpa.MaxReplicas
+	}
+
+	recommendedMin := readyTarget
+	if startPeakReplicas > 0 {
+		recommendedMin = startPeakReplicas
+	}
+	if recommendedMin > recommendedMax {
+		recommendedMin = recommendedMax
+	}
+	output.RecommendedMinReplicas = recommendedMin
+	output.RecommendedMaxReplicas = recommendedMax
+	return output, nil
+}
+
+func (e *eventReport) buildNodePoolReport(
+	tx *gorm.DB,
+	updatedNodePool *UCEntity.UpdatedNodePoolData,
+) (*UCEntity.EventReportNodePoolData, error) {
+	output := &UCEntity.EventReportNodePoolData{
+		Name:    updatedNodePool.NodePoolName,
+		MaxNode: updatedNodePool.MaxNode,
+	}
+	statuses, err := e.statisticUC.GetAllNodePoolStatusByUpdatedNodePoolID(tx, updatedNodePool.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, status := range statuses {
+		if status.Count > output.PeakNodeCount {
+			output.PeakNodeCount = status.Count
+			peakAt := status.CreatedAt
+			output.PeakNodeCountAt = &peakAt
+		}
+		if status.RequestedCPU > output.PeakRequestedCPU {
+			output.PeakRequestedCPU = status.RequestedCPU
+		}
+		if status.RequestedMemory > output.PeakRequestedMemory {
+			output.PeakRequestedMemory = status.RequestedMemory
+		}
+	}
+	output.RecommendedNodeCount = int32(math.Ceil(float64(output.PeakNodeCount) * reportHeadroomRatio))
+	return output, nil
+}
@@ -0,0 +1,172 @@
+package useCase
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/google/uuid"
+	UCEntity "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/usecase"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository/model"
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// EventStream pushes the samples and status transitions of an event to the live subscribers,
+// publish errors are only logged since the samples are already stored
+type EventStream interface {
+	PublishHPAStatus(
+		tx *gorm.DB,
+		eventID uuid.UUID,
+		scheduledHPAConfigs []*UCEntity.EventModifiedHPAConfigData,
+		hpaStatuses []model.HPAStatus,
+	)
+	PublishNodePoolStatus(
+		tx *gorm.DB,
+		eventID uuid.UUID,
+		updatedNodePoolMap map[string]uuid.UUID,
+		nodePoolStatuses []model.NodePoolStatus,
+	)
+	PublishEventStatus(tx *gorm.DB, eventData *UCEntity.Event, previousStatus model.EventStatus)
+	// Subscribe returns the live messages of the event until ctx is done
+	Subscribe(ctx context.Context, eventID uuid.UUID) (<-chan *UCEntity.EventStreamMessage, error)
+}
+
+type eventStream struct {
+	eventStreamRepo repository.EventStream
+}
+
+func newEventStream(eventStreamRepo repository.EventStream) EventStream {
+	return &eventStream{eventStreamRepo: eventStreamRepo}
+}
+
+func (e *eventStream) PublishHPAStatus(
+	tx *gorm.DB,
+	eventID uuid.UUID,
+	scheduledHPAConfigs []*UCEntity.EventModifiedHPAConfigData,
+	hpaStatuses []model.HPAStatus,
+) {
+	scheduledHPAConfigMap := map[uuid.UUID]*UCEntity.EventModifiedHPAConfigData{}
+	for _, scheduledHPAConfig := range scheduledHPAConfigs {
+		scheduledHPAConfigMap[scheduledHPAConfig.ID] = scheduledHPAConfig
+	}
+	for i := range hpaStatuses {
+		hpaStatusData, err := buildHPAStatusData(&hpaStatuses[i])
+		if err != nil {
+			log.Errorf("[EventStream] Event ID : %s, Error building hpa status : %s", eventID, err.Error())
+			continue
+		}
+		data := &UCEntity.EventStreamHPAStatusData{
+			ScheduledHPAConfigID: hpaStatuses[i].ScheduledHPAConfigID.GetUUID(),
+			HPAStatusData:        *hpaStatusData,
+		}
+		if scheduledHPAConfig, ok := scheduledHPAConfigMap[data.ScheduledHPAConfigID]; ok {
+			data.Name = scheduledHPAConfig.Name
+			data.Namespace = scheduledHPAConfig.Namespace
+		}
+		e.publish(
+			tx, eventID, &UCEntity.EventStreamMessage{
+				Type:      UCEntity.EventStreamHPAStatus,
+				HPAStatus: data,
+			},
+		)
+	}
+}
+
+func (e *eventStream) PublishNodePoolStatus(
+	tx *gorm.DB,
+	eventID uuid.UUID,
+	updatedNodePoolMap map[string]uuid.UUID,
+	nodePoolStatuses []model.NodePoolStatus,
+) {
+	nodePoolNameMap := map[uuid.UUID]string{}
+	for nodePoolName, updatedNodePoolID := range updatedNodePoolMap {
+		nodePoolNameMap[updatedNodePoolID] = nodePoolName
+	}
+	for i := range nodePoolStatuses {
+		updatedNodePoolID := nodePoolStatuses[i].UpdatedNodePoolID.GetUUID()
+		e.publish(
+			tx, eventID, &UCEntity.EventStreamMessage{
+				Type: UCEntity.EventStreamNodePoolStatus,
+				NodePoolStatus: &UCEntity.EventStreamNodePoolStatusData{
+					UpdatedNodePoolID:  updatedNodePoolID,
+					NodePoolName:       nodePoolNameMap[updatedNodePoolID],
+					NodePoolStatusData: *buildNodePoolStatusData(&nodePoolStatuses[i]),
+				},
+			},
+		)
+	}
+}
+
+func (e *eventStream) PublishEventStatus(
+	tx *gorm.DB,
+	eventData *UCEntity.Event,
+	previousStatus model.EventStatus,
+) {
+	e.publish(
+		tx, eventData.ID, &UCEntity.EventStreamMessage{
+			Type: UCEntity.EventStreamEventStatus,
+			EventStatus: &UCEntity.EventStreamEventStatusData{
+				Status:         eventData.Status,
+				PreviousStatus: previousStatus,
+				Message:        eventData.Message,
+			},
+		},
+	)
+}
+
+func (e *eventStream) Subscribe(
+	ctx context.Context,
+	eventID uuid.UUID,
+) (<-chan *UCEntity.EventStreamMessage, error) {
+	pubSub, err := e.eventStreamRepo.SubscribeEventStream(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	output := make(chan *UCEntity.EventStreamMessage)
+	go func() {
+		defer close(output)
+		defer pubSub.Close()
+		redisMessages := pubSub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case redisMessage, ok := <-redisMessages:
+				if !ok {
+					return
+				}
+				message := &UCEntity.EventStreamMessage{}
+				if err := json.Unmarshal([]byte(redisMessage.Payload), message); err != nil {
+					log.Errorf(
+						"[EventStream] Event ID : %s, Error decoding message : %s",
+						eventID,
+						err.Error(),
+					)
+					continue
+				}
+				select {
+				case output <- message:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return output, nil
+}
+
+func (e *eventStream) publish(tx *gorm.DB, eventID uuid.UUID, message *UCEntity.EventStreamMessage) {
+	payload, err := json.Marshal(message)
+	if err != nil {
+		log.Errorf("[EventStream] Event ID : %s, Error encoding message : %s", eventID, err.Error())
+		return
+	}
+	ctx := tx.Statement.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := e.eventStreamRepo.PublishEventStreamMessage(ctx, eventID, payload); err != nil {
+		log.Errorf("[EventStream] Event ID : %s, Error publishing message : %s", eventID, err.Error())
+	}
+}
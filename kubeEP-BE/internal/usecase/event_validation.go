@@ -0,0 +1,262 @@
+package useCase
+
+import (
+	"fmt"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant"
+	UCEntity "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/usecase"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/pkg/util"
+	v1Core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+type EventValidation interface {
+	ValidateEventConfig(input *UCEntity.EventValidationInput) *UCEntity.EventValidationResult
+}
+
+type eventValidation struct {
+}
+
+func newEventValidation() EventValidation {
+	return &eventValidation{}
+}
+
+func (v *eventValidation) ValidateEventConfig(
+	input *UCEntity.EventValidationInput,
+) *UCEntity.EventValidationResult {
+	result := &UCEntity.EventValidationResult{Valid: true}
+
+	existingHPAMap := map[string]UCEntity.SimpleHPAData{}
+	for _, hpa := range input.ExistingHPAs {
+		existingHPAMap[fmt.Sprintf(constant.NameNSKeyFormat, hpa.Name, hpa.Namespace)] = hpa
+	}
+	deploymentMap := map[string]*v1Core.PodSpec{}
+	for i := range input.Deployments {
+		deployment := &input.Deployments[i]
+		deploymentMap[fmt.Sprintf(
+			constant.NameNSKeyFormat,
+			deployment.Name,
+			deployment.Namespace,
+		)] = &deployment.Spec.Template.Spec
+	}
+	// The pod specs of the selected workloads, the node pool issues are errors only for the node
+	// pools these pods can be scheduled on
+	var targetPodSpecs []*v1Core.PodSpec
+
+	// Target resolution only matters when the node pool calculation needs the pods
+	targetIssueLevel := constant.ValidationWarning
+	if input.CalculateNodePool {
+		targetIssueLevel = constant.ValidationError
+	}
+
+	seenHPA := map[string]bool{}
+	for _, hpaConfig := range input.ModifiedHPAConfigs {
+		hpaResult := UCEntity.HPAValidationResult{
+			Name:      hpaConfig.Name,
+			Namespace: hpaConfig.Namespace,
+			Issues:    []UCEntity.ValidationIssue{},
+		}
+		key := fmt.Sprintf(constant.NameNSKeyFormat, hpaConfig.Name, hpaConfig.Namespace)
+
+		if seenHPA[key] {
+			hpaResult.Issues = append(
+				hpaResult.Issues, UCEntity.ValidationIssue{
+					Level:   constant.ValidationError,
+					Code:    constant.ValidationDuplicateHPA,
+					Message: "hpa is configured more than once",
+				},
+			)
+		}
+		seenHPA[key] = true
+
+		if hpaConfig.MinReplicas != nil {
+			if *hpaConfig.MinReplicas < 1 {
+				hpaResult.Issues = append(
+					hpaResult.Issues, UCEntity.ValidationIssue{
+						Level:   constant.ValidationError,
+						Code:    constant.ValidationInvalidMinReplicas,
+						Message: "min replicas must be at least 1",
+					},
+				)
+			}
+			if *hpaConfig.MinReplicas > hpaConfig.MaxReplicas {
+				hpaResult.Issues = append(
+					hpaResult.Issues, UCEntity.ValidationIssue{
+						Level: constant.ValidationError,
+						Code:  constant.ValidationInvalidReplicaRange,
+						Message: fmt.Sprintf(
+							"min replicas %d is greater than max replicas %d",
+							*hpaConfig.MinReplicas,
+							hpaConfig.MaxReplicas,
+						),
+					},
+				)
+			}
+		}
+		if hpaConfig.MaxReplicas < 1 {
+			hpaResult.Issues = append(
+				hpaResult.Issues, UCEntity.ValidationIssue{
+					Level:   constant.ValidationError,
+					Code:    constant.ValidationInvalidReplicaRange,
+					Message: "max replicas must be at least 1",
+				},
+			)
+		}
+		if hpaConfig.PostEventMinReplicas != nil && hpaConfig.PostEventMaxReplicas != nil &&
+			*hpaConfig.PostEventMinReplicas > *hpaConfig.PostEventMaxReplicas {
+			hpaResult.Issues = append(
+				hpaResult.Issues, UCEntity.ValidationIssue{
+					Level: constant.ValidationError,
+					Code:  constant.ValidationInvalidPostEventReplicaRange,
+					Message: fmt.Sprintf(
+						"post event min replicas %d is greater than post event max replicas %d",
+						*hpaConfig.PostEventMinReplicas,
+						*hpaConfig.PostEventMaxReplicas,
+					),
+				},
+			)
+		}
+
+		existingHPA, ok := existingHPAMap[key]
+		if !ok {
+			hpaResult.Issues = append(
+				hpaResult.Issues, UCEntity.ValidationIssue{
+					Level:   constant.ValidationError,
+					Code:    constant.ValidationHPANotFound,
+					Message: "hpa not found in cluster",
+				},
+			)
+			result.HPAs = append(result.HPAs, hpaResult)
+			continue
+		}
+
+		targetRef := existingHPA.ScaleTargetRef
+		if targetRef.APIVersion != constant.AppsV1 || targetRef.Kind != constant.Deployment {
+			hpaResult.Issues = append(
+				hpaResult.Issues, UCEntity.ValidationIssue{
+					Level: targetIssueLevel,
+					Code:  constant.ValidationUnsupportedTarget,
+					Message: fmt.Sprintf(
+						"scale target %s %s is not supported, only %s %s is supported",
+						targetRef.APIVersion,
+						targetRef.Kind,
+						constant.AppsV1,
+						constant.Deployment,
+					),
+				},
+			)
+		} else if podSpec, ok := deploymentMap[fmt.Sprintf(
+			constant.NameNSKeyFormat,
+			targetRef.Name,
+			hpaConfig.Namespace,
+		)]; ok {
+			targetPodSpecs = append(targetPodSpecs, podSpec)
+		} else {
+			hpaResult.Issues = append(
+				hpaResult.Issues, UCEntity.ValidationIssue{
+					Level:   targetIssueLevel,
+					Code:    constant.ValidationTargetNotFound,
+					Message: fmt.Sprintf("deployment %s not found", targetRef.Name),
+				},
+			)
+		}
+
+		if hpaConfig.MaxReplicas < existingHPA.CurrentReplicas {
+			hpaResult.Issues = append(
+				hpaResult.Issues, UCEntity.ValidationIssue{
+					Level: constant.ValidationWarning,
+					Code:  constant.ValidationMaxReplicasBelowCurrent,
+					Message: fmt.Sprintf(
+						"max replicas %d is below current replicas %d",
+						hpaConfig.MaxReplicas,
+						existingHPA.CurrentReplicas,
+					),
+				},
+			)
+		}
+
+		result.HPAs = append(result.HPAs, hpaResult)
+	}
+
+	if input.CalculateNodePool {
+		for _, nodePool := range input.NodePools {
+			nodePoolResult := UCEntity.NodePoolValidationResult{
+				Name:   nodePool.Name,
+				Issues: []UCEntity.ValidationIssue{},
+			}
+			// A node pool the selected workloads can't use is skipped by the calculation
+			nodePoolIssueLevel, skippedMessage := constant.ValidationError, ""
+			nodeData, ok := input.NodePoolNodes[nodePool.Name]
+			if ok && !nodePoolSchedulable(nodeData, targetPodSpecs) {
+				nodePoolIssueLevel = constant.ValidationWarning
+				skippedMessage = ", no selected workload can be scheduled on it"
+			}
+			if nodePool.Autoscaling == nil || !nodePool.Autoscaling.Enabled {
+				nodePoolResult.Issues = append(
+					nodePoolResult.Issues, UCEntity.ValidationIssue{
+						Level:   nodePoolIssueLevel,
+						Code:    constant.ValidationAutoscalingDisabled,
+						Message: "node pool autoscaling is not enabled" + skippedMessage,
+					},
+				)
+			}
+			if nodePool.MaxPodsConstraint == nil {
+				nodePoolResult.Issues = append(
+					nodePoolResult.Issues, UCEntity.ValidationIssue{
+						Level:   nodePoolIssueLevel,
+						Code:    constant.ValidationMaxPodsConstraintMissing,
+						Message: "node pool has no max pods constraint" + skippedMessage,
+					},
+				)
+			}
+			if input.NodePoolNodeCounts[nodePool.Name] == 0 {
+				nodePoolResult.Issues = append(
+					nodePoolResult.Issues, UCEntity.ValidationIssue{
+						Level:   constant.ValidationWarning,
+						Code:    constant.ValidationNoExistingNode,
+						Message: "node pool has no existing node, capacity is derived from its instance template",
+					},
+				)
+			}
+			result.NodePools = append(result.NodePools, nodePoolResult)
+		}
+	}
+
+	for _, hpaResult := range result.HPAs {
+		for _, issue := range hpaResult.Issues {
+			if issue.Level == constant.ValidationError {
+				result.Valid = false
+			}
+		}
+	}
+	for _, nodePoolResult := range result.NodePools {
+		for _, issue := range nodePoolResult.Issues {
+			if issue.Level == constant.ValidationError {
+				result.Valid = false
+			}
+		}
+	}
+
+	return result
+}
+
+// nodePoolSchedulable tells whether a pod of the given specs can be scheduled on the node pool nodes,
+// a failing match counts as schedulable
+func nodePoolSchedulable(nodeData UCEntity.NodePoolNodeData, podSpecs []*v1Core.PodSpec) bool {
+	for _, podSpec := range podSpecs {
+		var nodeAffinity *v1Core.NodeAffinity
+		if podSpec.Affinity != nil {
+			nodeAffinity = podSpec.Affinity.NodeAffinity
+		}
+		match, err := util.CheckPodNodePoolMatch(
+			nodeData.Labels,
+			nodeData.Taints,
+			nodeAffinity,
+			labels.Set(podSpec.NodeSelector).AsSelector(),
+			podSpec.Tolerations,
+		)
+		if err != nil || match {
+			return true
+		}
+	}
+	return false
+}
@@ -14,15 +14,19 @@ import (
 	UCEntity "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/usecase"
 	gcpCustomAuth "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/pkg/k8s/auth/gcp_custom"
 	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/pkg/k8s/client"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/pkg/util"
 	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository"
 	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository/model"
 	"golang.org/x/oauth2/google"
 	"google.golang.org/api/option"
 	containerEntity "google.golang.org/genproto/googleapis/container/v1"
 	"gorm.io/gorm"
+	v1Core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	v1Option "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/clientcmd/api"
+	"strings"
 )
 
 type GCPCluster interface {
@@ -53,6 +57,27 @@ type GCPCluster interface {
 		ctx context.Context,
 		googleCredential *google.Credentials,
 	) (*compute.InstanceTemplatesClient, error)
+	GetGoogleMachineTypesClient(
+		ctx context.Context,
+		googleCredential *google.Credentials,
+	) (*compute.MachineTypesClient, error)
+	GetGoogleRegionsClient(
+		ctx context.Context,
+		googleCredential *google.Credentials,
+	) (*compute.RegionsClient, error)
+	GetRegionQuotas(
+		ctx context.Context,
+		regionsClient *compute.RegionsClient,
+		project, region string,
+	) (map[string]*UCEntity.GCPQuotaData, error)
+	GetNodePoolTemplateData(
+		ctx context.Context,
+		igmClient *compute.InstanceGroupManagersClient,
+		instanceTemplatesClient *compute.InstanceTemplatesClient,
+		machineTypesClient *compute.MachineTypesClient,
+		project string,
+		nodePool *containerEntity.NodePool,
+	) (*UCEntity.GCPNodePoolTemplateData, error)
 	GetGCPClusterObject(
 		ctx context.Context,
 		clusterClient *container.ClusterManagerClient,
@@ -127,6 +152,40 @@ func (c *gcpCluster) GetGoogleInstanceTemplatesClient(
 	return compute.NewInstanceTemplatesRESTClient(ctx, option.WithCredentials(googleCredential))
 }
 
+func (c *gcpCluster) GetGoogleMachineTypesClient(
+	ctx context.Context,
+	googleCredential *google.Credentials,
+) (*compute.MachineTypesClient, error) {
+	return compute.NewMachineTypesRESTClient(ctx, option.WithCredentials(googleCredential))
+}
+
+func (c *gcpCluster) GetGoogleRegionsClient(
+	ctx context.Context,
+	googleCredential *google.Credentials,
+) (*compute.RegionsClient, error) {
+	return compute.NewRegionsRESTClient(ctx, option.WithCredentials(googleCredential))
+}
+
+func (c *gcpCluster) GetRegionQuotas(
+	ctx context.Context,
+	regionsClient *compute.RegionsClient,
+	project, region string,
+) (map[string]*UCEntity.GCPQuotaData, error) {
+	regionData, err := c.gcpClusterRepo.GetRegion(ctx, regionsClient, project, region)
+	if err != nil {
+		return nil, err
+	}
+	quotas := map[string]*UCEntity.GCPQuotaData{}
+	for _, quota := range regionData.GetQuotas() {
+		quotas[quota.GetMetric()] = &UCEntity.GCPQuotaData{
+			Metric: quota.GetMetric(),
+			Limit:  quota.GetLimit(),
+			Usage:  quota.GetUsage(),
+		}
+	}
+	return quotas, nil
+}
+
 func (c *gcpCluster) GetAllClustersInGCPProject(
 	ctx context.Context,
 	projectID string,
@@ -290,3 +349,109 @@ func (c *gcpCluster) GetOperation(
 	}
 	return &UCEntity.GCPClusterOperationData{OperationData: op}, nil
 }
+
+func (c *gcpCluster) GetNodePoolTemplateData(
+	ctx context.Context,
+	igmClient *compute.InstanceGroupManagersClient,
+	instanceTemplatesClient *compute.InstanceTemplatesClient,
+	machineTypesClient *compute.MachineTypesClient,
+	project string,
+	nodePool *containerEntity.NodePool,
+) (*UCEntity.GCPNodePoolTemplateData, error) {
+	if len(nodePool.InstanceGroupUrls) == 0 {
+		return nil, errors.New(errorConstant.NoInstanceGroup)
+	}
+
+	igmURL := util.ParseGCPResourceURL(nodePool.InstanceGroupUrls[0])
+	zone := igmURL["zones"]
+	igm, err := c.gcpClusterRepo.GetInstanceGroupManager(
+		ctx,
+		igmClient,
+		project,
+		zone,
+		igmURL["instanceGroupManagers"],
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	templateURL := util.ParseGCPResourceURL(igm.GetInstanceTemplate())
+	template, err := c.gcpClusterRepo.GetInstanceTemplate(
+		ctx,
+		instanceTemplatesClient,
+		project,
+		templateURL["instanceTemplates"],
+	)
+	if err != nil {
+		return nil, err
+	}
+	properties := template.GetProperties()
+
+	machineTypeName := properties.GetMachineType()
+	if nodePool.Config != nil && nodePool.Config.MachineType != "" {
+		machineTypeName = nodePool.Config.MachineType
+	}
+	machineType, err := c.gcpClusterRepo.GetMachineType(
+		ctx,
+		machineTypesClient,
+		project,
+		zone,
+		machineTypeName,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	allocatableCPU, allocatableMemory := util.CalculateGKEAllocatable(
+		machineType.GetGuestCpus(),
+		machineType.GetMemoryMb(),
+	)
+
+	nodeLabels := map[string]string{}
+	for _, item := range properties.GetMetadata().GetItems() {
+		if item.GetKey() != constant.GCPKubeLabelsMetadataKey {
+			continue
+		}
+		for _, label := range strings.Split(item.GetValue(), ",") {
+			keyValue := strings.SplitN(label, "=", 2)
+			if len(keyValue) == 2 {
+				nodeLabels[keyValue[0]] = keyValue[1]
+			}
+		}
+	}
+
+	configLabels, taints := util.GKENodePoolConfigNode(nodePool)
+	for key, value := range configLabels {
+		nodeLabels[key] = value
+	}
+	nodeLabels[constant.K8sInstanceTypeLabel] = machineTypeName
+	nodeLabels[constant.K8sTopologyZoneLabel] = zone
+
+	allocatable := v1Core.ResourceList{
+		v1Core.ResourceCPU: *resource.NewMilliQuantity(
+			int64(allocatableCPU*1000),
+			resource.DecimalSI,
+		),
+		v1Core.ResourceMemory: *resource.NewQuantity(
+			int64(allocatableMemory),
+			resource.BinarySI,
+		),
+	}
+	if nodePool.Config != nil {
+		for _, accelerator := range nodePool.Config.Accelerators {
+			allocatable[constant.GPUResourceName] = *resource.NewQuantity(
+				accelerator.AcceleratorCount,
+				resource.DecimalSI,
+			)
+		}
+	}
+
+	return &UCEntity.GCPNodePoolTemplateData{
+		MachineType: machineTypeName,
+		GuestCPUs:   machineType.GetGuestCpus(),
+		Zone:        zone,
+		Labels:      nodeLabels,
+		Taints:      taints,
+		Allocatable: allocatable,
+	}, nil
+}
@@ -0,0 +1,214 @@
+package useCase
+
+import (
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	errorConstant "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant/errors"
+	UCEntity "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/usecase"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository/model"
+	"gorm.io/gorm"
+)
+
+type Guard interface {
+	ValidateGuardRules(
+		rules []UCEntity.GuardRuleData,
+		hpaConfigs []UCEntity.EventModifiedHPAConfigData,
+	) error
+	RegisterGuardRules(tx *gorm.DB, rules []UCEntity.GuardRuleData, eventID uuid.UUID) error
+	DeleteEventGuardRules(tx *gorm.DB, eventID uuid.UUID) error
+	ListGuardRuleByEventID(tx *gorm.DB, eventID uuid.UUID) ([]*UCEntity.GuardRuleData, error)
+	IsGuardRuleBreached(rule *UCEntity.GuardRuleData, sample *UCEntity.GuardSampleData) bool
+	RecordGuardAction(tx *gorm.DB, action *UCEntity.GuardActionData) error
+	ListGuardActionByEventID(tx *gorm.DB, eventID uuid.UUID) ([]*UCEntity.GuardActionData, error)
+}
+
+type guard struct {
+	guardRuleRepo   repository.GuardRule
+	guardActionRepo repository.GuardAction
+}
+
+func newGuard(guardRuleRepo repository.GuardRule, guardActionRepo repository.GuardAction) Guard {
+	return &guard{
+		guardRuleRepo:   guardRuleRepo,
+		guardActionRepo: guardActionRepo,
+	}
+}
+
+// ValidateGuardRules checks the rules of an event, a rule with an hpa must watch one of the hpa
+// modified by the event
+func (g *guard) ValidateGuardRules(
+	rules []UCEntity.GuardRuleData,
+	hpaConfigs []UCEntity.EventModifiedHPAConfigData,
+) error {
+	for _, rule := range rules {
+		if rule.HPAName != "" && !guardRuleHPAModified(rule, hpaConfigs) {
+			return fmt.Errorf(errorConstant.GuardHPANotModified, rule.HPAName, rule.HPANamespace)
+		}
+		switch rule.Condition {
+		case model.GuardUnavailableReplicas, model.GuardHPAAtMaxReplicas, model.GuardUnschedulablePods:
+		default:
+			return errors.New(errorConstant.GuardConditionUnknown)
+		}
+		switch rule.Action {
+		case model.GuardRaiseMaxReplicas:
+			if rule.ActionValue <= 0 {
+				return errors.New(errorConstant.GuardActionValueRequired)
+			}
+		case model.GuardRaiseNodePoolMax:
+			if rule.ActionValue <= 0 {
+				return errors.New(errorConstant.GuardActionValueRequired)
+			}
+			if rule.NodePoolName == "" {
+				return errors.New(errorConstant.GuardNodePoolRequired)
+			}
+		case model.GuardNotify:
+		default:
+			return errors.New(errorConstant.GuardActionUnknown)
+		}
+	}
+	return nil
+}
+
+func guardRuleHPAModified(rule UCEntity.GuardRuleData, hpaConfigs []UCEntity.EventModifiedHPAConfigData) bool {
+	for _, hpaConfig := range hpaConfigs {
+		if rule.AppliesTo(hpaConfig.Name, hpaConfig.Namespace) {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *guard) RegisterGuardRules(
+	tx *gorm.DB,
+	rules []UCEntity.GuardRuleData,
+	eventID uuid.UUID,
+) error {
+	if len(rules) == 0 {
+		return nil
+	}
+	var data []*model.GuardRule
+	for _, rule := range rules {
+		guardRule := &model.GuardRule{
+			HPAName:         rule.HPAName,
+			HPANamespace:    rule.HPANamespace,
+			Condition:       rule.Condition,
+			Threshold:       rule.Threshold,
+			DurationSeconds: rule.DurationSeconds,
+			Action:          rule.Action,
+			ActionValue:     rule.ActionValue,
+			NodePoolName:    rule.NodePoolName,
+			CooldownSeconds: rule.CooldownSeconds,
+		}
+		guardRule.EventID.SetUUID(eventID)
+		data = append(data, guardRule)
+	}
+	return g.guardRuleRepo.InsertBatchGuardRule(tx, data)
+}
+
+func (g *guard) DeleteEventGuardRules(tx *gorm.DB, eventID uuid.UUID) error {
+	return g.guardRuleRepo.DeletePermanentGuardRuleByEventID(tx, eventID)
+}
+
+func (g *guard) ListGuardRuleByEventID(
+	tx *gorm.DB,
+	eventID uuid.UUID,
+) ([]*UCEntity.GuardRuleData, error) {
+	data, err := g.guardRuleRepo.ListGuardRuleByEventID(tx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	var output []*UCEntity.GuardRuleData
+	for _, d := range data {
+		output = append(
+			output, &UCEntity.GuardRuleData{
+				ID:              d.ID.GetUUID(),
+				HPAName:         d.HPAName,
+				HPANamespace:    d.HPANamespace,
+				Condition:       d.Condition,
+				Threshold:       d.Threshold,
+				DurationSeconds: d.DurationSeconds,
+				Action:          d.Action,
+				ActionValue:     d.ActionValue,
+				NodePoolName:    d.NodePoolName,
+				CooldownSeconds: d.CooldownSeconds,
+			},
+		)
+	}
+	return output, nil
+}
+
+func (g *guard) IsGuardRuleBreached(
+	rule *UCEntity.GuardRuleData,
+	sample *UCEntity.GuardSampleData,
+) bool {
+	switch rule.Condition {
+	case model.GuardUnavailableReplicas:
+		if sample.Replicas <= 0 {
+			return false
+		}
+		unavailablePercentage := float64(sample.UnavailableReplicas) * 100 / float64(sample.Replicas)
+		return unavailablePercentage > rule.Threshold
+	case model.GuardHPAAtMaxReplicas:
+		if sample.HPAStatus == nil || sample.HPAStatus.MaxReplicas <= 0 {
+			return false
+		}
+		return sample.HPAStatus.CurrentReplicas >= sample.HPAStatus.MaxReplicas
+	case model.GuardUnschedulablePods:
+		if sample.PodStatistic == nil {
+			return false
+		}
+		return float64(sample.PodStatistic.UnschedulablePods) > rule.Threshold
+	}
+	return false
+}
+
+func (g *guard) RecordGuardAction(tx *gorm.DB, action *UCEntity.GuardActionData) error {
+	data := &model.GuardAction{
+		Action:        action.Action,
+		Status:        action.Status,
+		Target:        action.Target,
+		PreviousValue: action.PreviousValue,
+		NewValue:      action.NewValue,
+		Message:       action.Message,
+		BreachedSince: action.BreachedSince,
+	}
+	data.EventID.SetUUID(action.EventID)
+	data.GuardRuleID.SetUUID(action.GuardRuleID)
+	if err := g.guardActionRepo.InsertGuardAction(tx, data); err != nil {
+		return err
+	}
+	action.ID = data.ID.GetUUID()
+	action.CreatedAt = data.CreatedAt
+	return nil
+}
+
+func (g *guard) ListGuardActionByEventID(
+	tx *gorm.DB,
+	eventID uuid.UUID,
+) ([]*UCEntity.GuardActionData, error) {
+	data, err := g.guardActionRepo.ListGuardActionByEventID(tx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	var output []*UCEntity.GuardActionData
+	for _, d := range data {
+		output = append(
+			output, &UCEntity.GuardActionData{
+				ID:            d.ID.GetUUID(),
+				CreatedAt:     d.CreatedAt,
+				EventID:       d.EventID.GetUUID(),
+				GuardRuleID:   d.GuardRuleID.GetUUID(),
+				Action:        d.Action,
+				Status:        d.Status,
+				Target:        d.Target,
+				PreviousValue: d.PreviousValue,
+				NewValue:      d.NewValue,
+				Message:       d.Message,
+				BreachedSince: d.BreachedSince,
+			},
+		)
+	}
+	return output, nil
+}
@@ -3,29 +3,69 @@ package useCase
 import (
 	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/config"
 	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository"
+	"time"
 )
 
 type UseCases struct {
-	GcpDatacenter      GCPDatacenter
-	GcpCluster         GCPCluster
-	Cluster            Cluster
-	Datacenter         Datacenter
-	Event              Event
-	ScheduledHPAConfig ScheduledHPAConfig
-	UpdatedNodePool    Statistic
+	GcpDatacenter       GCPDatacenter
+	GcpCluster          GCPCluster
+	Cluster             Cluster
+	Datacenter          Datacenter
+	Event               Event
+	ScheduledHPAConfig  ScheduledHPAConfig
+	UpdatedNodePool     Statistic
+	ScaleDown           ScaleDown
+	EventValidation     EventValidation
+	ClientManager       ClientManager
+	EventReport         EventReport
+	EventRecommendation EventRecommendation
+	Notification        Notification
+	CloudEvent          CloudEvent
+	EventStream         EventStream
+	Guard               Guard
 }
 
 func BuildUseCases(
 	resources *config.KubeEPResources,
 	repositories *repository.Repositories,
 ) *UseCases {
+	gcpCluster := newGCPCluster(
+		resources.ValidatorInst, repositories.Cluster,
+		repositories.GCPCluster, repositories.K8SDiscovery,
+		repositories.K8sNode,
+	)
+	gcpDatacenter := newGCPDatacenter(repositories.Datacenter, resources.ValidatorInst)
+	var clientCacheConfig config.ClientCacheConfig
+	var notificationConfig config.NotificationConfig
+	var cloudEventsConfig config.CloudEventsConfig
+	if resources.Config != nil {
+		clientCacheConfig = resources.Config.ClientCache
+		notificationConfig = resources.Config.Notification
+		cloudEventsConfig = resources.Config.CloudEvents
+	}
+	cloudEventUC := newCloudEvent(
+		buildCloudEventSink(resources.Redis, cloudEventsConfig),
+		cloudEventsConfig.Source,
+		repositories.Cluster,
+	)
+	eventStreamUC := newEventStream(repositories.EventStream)
+	eventUC := newEvent(
+		resources.ValidatorInst,
+		repositories.Event,
+		repositories.ScheduledHPAConfig,
+		repositories.Cluster,
+		cloudEventUC,
+		eventStreamUC,
+	)
+	statisticUC := newStatistic(
+		repositories.UpdatedNodePool,
+		repositories.HPAStatus,
+		repositories.NodePoolStatus,
+		repositories.PodStatus,
+	)
 	return &UseCases{
-		GcpCluster: newGCPCluster(
-			resources.ValidatorInst, repositories.Cluster,
-			repositories.GCPCluster, repositories.K8SDiscovery,
-			repositories.K8sNode,
-		),
-		GcpDatacenter: newGCPDatacenter(repositories.Datacenter, resources.ValidatorInst),
+		GcpCluster:    gcpCluster,
+		GcpDatacenter: gcpDatacenter,
 		Cluster: newCluster(
 			resources.ValidatorInst,
 			repositories.Cluster,
@@ -34,19 +74,33 @@ func BuildUseCases(
 			repositories.K8SDiscovery,
 			repositories.K8sDeployment,
 			repositories.K8sDaemonSets,
+			repositories.K8sRuntimeClass,
+			repositories.K8sPod,
+			repositories.K8sEvent,
+			repositories.K8sMetrics,
 		),
-		Datacenter: newDatacenter(resources.ValidatorInst, repositories.Datacenter),
-		Event: newEvent(
-			resources.ValidatorInst,
-			repositories.Event,
-			repositories.ScheduledHPAConfig,
-			repositories.Cluster,
+		Datacenter:         newDatacenter(resources.ValidatorInst, repositories.Datacenter),
+		Event:              eventUC,
+		ScheduledHPAConfig: newScheduledHPAConfig(repositories.ScheduledHPAConfig, cloudEventUC),
+		UpdatedNodePool:    statisticUC,
+		ScaleDown:          newScaleDown(repositories.ScaleDownStep),
+		EventValidation:    newEventValidation(),
+		ClientManager: newClientManager(
+			gcpDatacenter,
+			gcpCluster,
+			time.Duration(clientCacheConfig.TTLMinutes)*time.Minute,
 		),
-		ScheduledHPAConfig: newScheduledHPAConfig(repositories.ScheduledHPAConfig),
-		UpdatedNodePool: newStatistic(
-			repositories.UpdatedNodePool,
-			repositories.HPAStatus,
-			repositories.NodePoolStatus,
+		EventReport:         newEventReport(eventUC, statisticUC, repositories.EventReport),
+		EventRecommendation: newEventRecommendation(eventUC, statisticUC),
+		Notification: newNotification(
+			repositories.NotificationChannel,
+			repositories.NotificationSubscription,
+			repositories.NotificationDelivery,
+			repositories.Cluster,
+			notificationConfig,
 		),
+		CloudEvent:  cloudEventUC,
+		EventStream: eventStreamUC,
+		Guard:       newGuard(repositories.GuardRule, repositories.GuardAction),
 	}
 }
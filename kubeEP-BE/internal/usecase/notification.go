@@ -0,0 +1,443 @@
+package useCase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/config"
+	errorConstant "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant/errors"
+	UCEntity "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/usecase"
+	gormDatatype "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/pkg/gorm/datatype"
+	notificationSender "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/pkg/notification"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository/model"
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	defaultNotificationMaxAttempts    = 5
+	defaultNotificationInitialBackoff = 5 * time.Second
+	defaultNotificationMaxBackoff     = 5 * time.Minute
+	defaultNotificationTimeout        = 10 * time.Second
+)
+
+type Notification interface {
+	RegisterChannel(tx *gorm.DB, data *UCEntity.NotificationChannelData) (uuid.UUID, error)
+	ListChannel(tx *gorm.DB) ([]UCEntity.NotificationChannelData, error)
+	DeleteChannel(tx *gorm.DB, id uuid.UUID) error
+	RegisterSubscription(tx *gorm.DB, data *UCEntity.NotificationSubscriptionData) (uuid.UUID, error)
+	ListSubscription(tx *gorm.DB, clusterID *uuid.UUID) ([]UCEntity.NotificationSubscriptionData, error)
+	DeleteSubscription(tx *gorm.DB, id uuid.UUID) error
+	ListDeliveryByEventID(tx *gorm.DB, eventID uuid.UUID) ([]UCEntity.NotificationDeliveryData, error)
+	NotifyEventTransition(
+		tx *gorm.DB,
+		eventData *UCEntity.Event,
+		transition model.NotificationTransition,
+		message string,
+	)
+	ResumePendingDelivery(tx *gorm.DB)
+}
+
+type notification struct {
+	channelRepo        repository.NotificationChannel
+	subscriptionRepo   repository.NotificationSubscription
+	deliveryRepo       repository.NotificationDelivery
+	clusterRepo        repository.Cluster
+	notificationConfig config.NotificationConfig
+	httpClient         *http.Client
+	maxAttempts        int32
+	initialBackoff     time.Duration
+	maxBackoff         time.Duration
+}
+
+func newNotification(
+	channelRepo repository.NotificationChannel,
+	subscriptionRepo repository.NotificationSubscription,
+	deliveryRepo repository.NotificationDelivery,
+	clusterRepo repository.Cluster,
+	notificationConfig config.NotificationConfig,
+) Notification {
+	n := &notification{
+		channelRepo:        channelRepo,
+		subscriptionRepo:   subscriptionRepo,
+		deliveryRepo:       deliveryRepo,
+		clusterRepo:        clusterRepo,
+		notificationConfig: notificationConfig,
+		maxAttempts:        notificationConfig.MaxAttempts,
+		initialBackoff:     time.Duration(notificationConfig.InitialBackoffSeconds) * time.Second,
+		maxBackoff:         time.Duration(notificationConfig.MaxBackoffSeconds) * time.Second,
+	}
+	if n.maxAttempts <= 0 {
+		n.maxAttempts = defaultNotificationMaxAttempts
+	}
+	if n.initialBackoff <= 0 {
+		n.initialBackoff = defaultNotificationInitialBackoff
+	}
+	if n.maxBackoff < n.initialBackoff {
+		n.maxBackoff = defaultNotificationMaxBackoff
+	}
+	timeout := time.Duration(notificationConfig.TimeoutSeconds) * time.Second
+	if timeout <= 0 {
+		timeout = defaultNotificationTimeout
+	}
+	n.httpClient = &http.Client{Timeout: timeout}
+	return n
+}
+
+func (n *notification) RegisterChannel(
+	tx *gorm.DB,
+	data *UCEntity.NotificationChannelData,
+) (uuid.UUID, error) {
+	switch data.Type {
+	case model.NotificationWebhook, model.NotificationSlack:
+		if data.Config.URL == "" {
+			return uuid.UUID{}, errors.New(errorConstant.NotificationChannelURLRequired)
+		}
+	case model.NotificationEmail:
+		if len(data.Config.Recipients) == 0 {
+			return uuid.UUID{}, errors.New(errorConstant.NotificationChannelRecipientsRequired)
+		}
+	default:
+		return uuid.UUID{}, errors.New(errorConstant.NotificationChannelTypeUnknown)
+	}
+
+	channelConfig, err := json.Marshal(data.Config)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+	channel := &model.NotificationChannel{
+		Name: data.Name,
+		Type: data.Type,
+	}
+	channel.Config.SetRawMessage(channelConfig)
+	if err := n.channelRepo.InsertNotificationChannel(tx, channel); err != nil {
+		return uuid.UUID{}, err
+	}
+	return channel.ID.GetUUID(), nil
+}
+
+func (n *notification) ListChannel(tx *gorm.DB) ([]UCEntity.NotificationChannelData, error) {
+	channels, err := n.channelRepo.ListNotificationChannel(tx)
+	if err != nil {
+		return nil, err
+	}
+	output := []UCEntity.NotificationChannelData{}
+	for _, channel := range channels {
+		output = append(output, buildNotificationChannelData(channel))
+	}
+	return output, nil
+}
+
+// DeleteChannel deletes the channel with its subscriptions
+func (n *notification) DeleteChannel(tx *gorm.DB, id uuid.UUID) error {
+	if _, err := n.channelRepo.GetNotificationChannelByID(tx, id); err != nil {
+		return errors.New(errorConstant.NotificationChannelNotExist)
+	}
+	if err := n.subscriptionRepo.DeleteNotificationSubscriptionByChannelID(tx, id); err != nil {
+		return err
+	}
+	return n.channelRepo.DeleteNotificationChannel(tx, id)
+}
+
+func (n *notification) RegisterSubscription(
+	tx *gorm.DB,
+	data *UCEntity.NotificationSubscriptionData,
+) (uuid.UUID, error) {
+	if _, err := n.channelRepo.GetNotificationChannelByID(tx, data.Channel.ID); err != nil {
+		return uuid.UUID{}, errors.New(errorConstant.NotificationChannelNotExist)
+	}
+	for _, transition := range data.Transitions {
+		switch transition {
+		case model.TransitionPrescaled, model.TransitionWatching, model.TransitionFailed,
+			model.TransitionFinished, model.TransitionRolledBack, model.TransitionGuardBreached:
+		default:
+			return uuid.UUID{}, errors.New(errorConstant.NotificationTransitionUnknown)
+		}
+	}
+
+	transitions, err := json.Marshal(data.Transitions)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+	subscription := &model.NotificationSubscription{}
+	subscription.ChannelID.SetUUID(data.Channel.ID)
+	if data.ClusterID != nil {
+		clusterID := gormDatatype.UUID(*data.ClusterID)
+		subscription.ClusterID = &clusterID
+	}
+	if data.EventID != nil {
+		eventID := gormDatatype.UUID(*data.EventID)
+		subscription.EventID = &eventID
+	}
+	subscription.Transitions.SetRawMessage(transitions)
+	if err := n.subscriptionRepo.InsertNotificationSubscription(tx, subscription); err != nil {
+		return uuid.UUID{}, err
+	}
+	return subscription.ID.GetUUID(), nil
+}
+
+func (n *notification) ListSubscription(
+	tx *gorm.DB,
+	clusterID *uuid.UUID,
+) ([]UCEntity.NotificationSubscriptionData, error) {
+	subscriptions, err := n.subscriptionRepo.ListNotificationSubscription(tx, clusterID)
+	if err != nil {
+		return nil, err
+	}
+	output := []UCEntity.NotificationSubscriptionData{}
+	for _, subscription := range subscriptions {
+		output = append(output, buildNotificationSubscriptionData(subscription))
+	}
+	return output, nil
+}
+
+func (n *notification) DeleteSubscription(tx *gorm.DB, id uuid.UUID) error {
+	return n.subscriptionRepo.DeleteNotificationSubscription(tx, id)
+}
+
+func (n *notification) ListDeliveryByEventID(
+	tx *gorm.DB,
+	eventID uuid.UUID,
+) ([]UCEntity.NotificationDeliveryData, error) {
+	deliveries, err := n.deliveryRepo.ListNotificationDeliveryByEventID(tx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	output := []UCEntity.NotificationDeliveryData{}
+	for _, delivery := range deliveries {
+		output = append(
+			output, UCEntity.NotificationDeliveryData{
+				ID:          delivery.ID.GetUUID(),
+				CreatedAt:   delivery.CreatedAt,
+				ChannelID:   delivery.ChannelID.GetUUID(),
+				ChannelName: delivery.Channel.Name,
+				EventID:     delivery.EventID.GetUUID(),
+				Transition:  delivery.Transition,
+				Status:      delivery.Status,
+				Attempts:    delivery.Attempts,
+				LastError:   delivery.LastError,
+				DeliveredAt: delivery.DeliveredAt,
+			},
+		)
+	}
+	return output, nil
+}
+
+// NotifyEventTransition logs a delivery for every subscription matching the transition and sends
+// them in the background, errors are only logged so the event lifecycle never waits on a channel
+func (n *notification) NotifyEventTransition(
+	tx *gorm.DB,
+	eventData *UCEntity.Event,
+	transition model.NotificationTransition,
+	message string,
+) {
+	subscriptions, err := n.subscriptionRepo.FindMatchingNotificationSubscription(
+		tx,
+		eventData.Cluster.ID,
+		eventData.ID,
+	)
+	if err != nil {
+		log.Errorf(
+			"[Notification] Event : %s, Error finding subscriptions : %s",
+			eventData.Name,
+			err.Error(),
+		)
+		return
+	}
+	if len(subscriptions) == 0 {
+		return
+	}
+
+	payload, err := json.Marshal(
+		UCEntity.NotificationPayload{
+			Transition: transition,
+			Message:    message,
+			CreatedAt:  time.Now(),
+			Event:      buildEventSummary(tx, n.clusterRepo, eventData),
+		},
+	)
+	if err != nil {
+		log.Errorf("[Notification] Event : %s, Error building payload : %s", eventData.Name, err.Error())
+		return
+	}
+
+	for _, subscription := range subscriptions {
+		if !buildNotificationSubscriptionData(subscription).HasTransition(transition) {
+			continue
+		}
+		delivery := &model.NotificationDelivery{
+			SubscriptionID: subscription.ID,
+			ChannelID:      subscription.ChannelID,
+			Transition:     transition,
+			Status:         model.NotificationDeliveryPending,
+		}
+		delivery.EventID.SetUUID(eventData.ID)
+		delivery.Payload.SetRawMessage(payload)
+		if err := n.deliveryRepo.InsertNotificationDelivery(tx, delivery); err != nil {
+			log.Errorf(
+				"[Notification] Event : %s, Error logging delivery : %s",
+				eventData.Name,
+				err.Error(),
+			)
+			continue
+		}
+		delivery.Channel = subscription.Channel
+		go n.deliver(tx, delivery)
+	}
+}
+
+// ResumePendingDelivery resends the deliveries interrupted by a restart
+func (n *notification) ResumePendingDelivery(tx *gorm.DB) {
+	deliveries, err := n.deliveryRepo.ListPendingNotificationDelivery(tx)
+	if err != nil {
+		log.Errorf("[Notification] Error listing pending deliveries : %s", err.Error())
+		return
+	}
+	for _, delivery := range deliveries {
+		go n.deliver(tx, delivery)
+	}
+}
+
+func (n *notification) deliver(tx *gorm.DB, delivery *model.NotificationDelivery) {
+	ctx := tx.Statement.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	backoff := n.initialBackoff
+	for {
+		delivery.Attempts += 1
+		err := n.send(ctx, delivery)
+		if err == nil {
+			now := time.Now()
+			delivery.Status = model.NotificationDeliverySuccess
+			delivery.DeliveredAt = &now
+			delivery.LastError = ""
+		} else {
+			delivery.LastError = err.Error()
+			if delivery.Attempts >= n.maxAttempts {
+				delivery.Status = model.NotificationDeliveryFailed
+			}
+		}
+		if saveErr := n.deliveryRepo.SaveNotificationDelivery(tx, delivery); saveErr != nil {
+			log.Errorf(
+				"[Notification] Delivery %s, Error saving delivery : %s",
+				delivery.ID.GetUUID(),
+				saveErr.Error(),
+			)
+		}
+		if err == nil {
+			return
+		}
+		log.Warnf(
+			"[Notification] Delivery %s, Attempt %d of %d failed : %s",
+			delivery.ID.GetUUID(),
+			delivery.Attempts,
+			n.maxAttempts,
+			err.Error(),
+		)
+		if delivery.Status == model.NotificationDeliveryFailed {
+			return
+		}
+
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return
+		}
+		backoff *= 2
+		if backoff > n.maxBackoff {
+			backoff = n.maxBackoff
+		}
+	}
+}
+
+func (n *notification) send(ctx context.Context, delivery *model.NotificationDelivery) error {
+	if delivery.Channel.ID.GetUUID() == uuid.Nil {
+		return errors.New(errorConstant.NotificationChannelNotExist)
+	}
+	channel := buildNotificationChannelData(&delivery.Channel)
+	payload := delivery.Payload.GetRawMessage()
+	switch channel.Type {
+	case model.NotificationWebhook:
+		return notificationSender.SendWebhook(ctx, n.httpClient, channel.Config.URL, channel.Config.Secret, payload)
+	case model.NotificationSlack:
+		subject, body, err := buildNotificationText(payload)
+		if err != nil {
+			return err
+		}
+		return notificationSender.SendSlack(ctx, n.httpClient, channel.Config.URL, subject+"\n"+body)
+	case model.NotificationEmail:
+		subject, body, err := buildNotificationText(payload)
+		if err != nil {
+			return err
+		}
+		return notificationSender.SendEmail(n.notificationConfig.SMTP, channel.Config.Recipients, subject, body)
+	default:
+		return errors.New(errorConstant.NotificationChannelTypeUnknown)
+	}
+}
+
+func buildNotificationText(payload []byte) (string, string, error) {
+	data := UCEntity.NotificationPayload{}
+	if err := json.Unmarshal(payload, &data); err != nil {
+		return "", "", err
+	}
+	subject := fmt.Sprintf(
+		"[kubeEP] Event %s on cluster %s : %s",
+		data.Event.Name,
+		data.Event.ClusterName,
+		strings.ToLower(strings.ReplaceAll(string(data.Transition), "_", " ")),
+	)
+	lines := []string{
+		fmt.Sprintf("Status : %s", data.Event.Status),
+		fmt.Sprintf("Start time : %s", data.Event.StartTime.UTC().Format(time.RFC3339)),
+		fmt.Sprintf("End time : %s", data.Event.EndTime.UTC().Format(time.RFC3339)),
+	}
+	if data.Message != "" {
+		lines = append(lines, fmt.Sprintf("Message : %s", data.Message))
+	}
+	return subject, strings.Join(lines, "\n"), nil
+}
+
+func buildNotificationChannelData(channel *model.NotificationChannel) UCEntity.NotificationChannelData {
+	output := UCEntity.NotificationChannelData{
+		ID:   channel.ID.GetUUID(),
+		Name: channel.Name,
+		Type: channel.Type,
+	}
+	if err := json.Unmarshal(channel.Config.GetRawMessage(), &output.Config); err != nil {
+		log.Warnf("[Notification] Channel %s, Invalid config : %s", channel.Name, err.Error())
+	}
+	return output
+}
+
+func buildNotificationSubscriptionData(
+	subscription *model.NotificationSubscription,
+) UCEntity.NotificationSubscriptionData {
+	output := UCEntity.NotificationSubscriptionData{
+		ID:      subscription.ID.GetUUID(),
+		Channel: buildNotificationChannelData(&subscription.Channel),
+	}
+	if subscription.ClusterID != nil {
+		clusterID := subscription.ClusterID.GetUUID()
+		output.ClusterID = &clusterID
+	}
+	if subscription.EventID != nil {
+		eventID := subscription.EventID.GetUUID()
+		output.EventID = &eventID
+	}
+	if err := json.Unmarshal(subscription.Transitions.GetRawMessage(), &output.Transitions); err != nil {
+		log.Warnf(
+			"[Notification] Subscription %s, Invalid transitions : %s",
+			output.ID,
+			err.Error(),
+		)
+	}
+	return output
+}
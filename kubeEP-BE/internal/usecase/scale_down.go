@@ -0,0 +1,206 @@
+package useCase
+
+import (
+	"github.com/google/uuid"
+	UCEntity "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/usecase"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository/model"
+	"gorm.io/gorm"
+	"time"
+)
+
+type ScaleDown interface {
+	PlanScaleDownSteps(
+		event *UCEntity.Event,
+		hpaConfigs []*UCEntity.EventModifiedHPAConfigData,
+	) []*UCEntity.ScaleDownStepData
+	RegisterScaleDownSteps(tx *gorm.DB, steps []*UCEntity.ScaleDownStepData) error
+	ListScaleDownStepByEventID(
+		tx *gorm.DB,
+		eventID uuid.UUID,
+	) ([]*UCEntity.ScaleDownStepData, error)
+	ListDueScaleDownStepByEventID(
+		tx *gorm.DB,
+		eventID uuid.UUID,
+		now time.Time,
+	) ([]*UCEntity.ScaleDownStepData, error)
+	CountPendingScaleDownStepByEventID(tx *gorm.DB, eventID uuid.UUID) (int64, error)
+	UpdateScaleDownStep(tx *gorm.DB, step *UCEntity.ScaleDownStepData) error
+}
+
+type scaleDown struct {
+	scaleDownStepRepo repository.ScaleDownStep
+}
+
+func newScaleDown(scaleDownStepRepo repository.ScaleDownStep) ScaleDown {
+	return &scaleDown{scaleDownStepRepo: scaleDownStepRepo}
+}
+
+// PlanScaleDownSteps spreads the reduction from the event replicas down to the post-event
+// replicas evenly over the window. Max replicas are kept at the event value until the last step
+// so the HPA can still react to lingering traffic.
+func (s *scaleDown) PlanScaleDownSteps(
+	event *UCEntity.Event,
+	hpaConfigs []*UCEntity.EventModifiedHPAConfigData,
+) []*UCEntity.ScaleDownStepData {
+	stepCount := event.ScaleDown.Steps
+	if stepCount <= 0 {
+		return nil
+	}
+	window := time.Duration(event.ScaleDown.WindowMinutes) * time.Minute
+
+	var steps []*UCEntity.ScaleDownStepData
+	for _, hpaConfig := range hpaConfigs {
+		if hpaConfig.Status != model.HPAUpdateSuccess {
+			continue
+		}
+
+		targetMaxReplicas := hpaConfig.MaxReplicas
+		if hpaConfig.OriginalMaxReplicas != nil {
+			targetMaxReplicas = *hpaConfig.OriginalMaxReplicas
+		}
+		if hpaConfig.PostEventMaxReplicas != nil {
+			targetMaxReplicas = *hpaConfig.PostEventMaxReplicas
+		}
+
+		targetMinReplicas := int32(1)
+		if hpaConfig.OriginalMinReplicas != nil {
+			targetMinReplicas = *hpaConfig.OriginalMinReplicas
+		}
+		if hpaConfig.PostEventMinReplicas != nil {
+			targetMinReplicas = *hpaConfig.PostEventMinReplicas
+		}
+		if targetMinReplicas > targetMaxReplicas {
+			targetMinReplicas = targetMaxReplicas
+		}
+
+		eventMinReplicas := int32(1)
+		if hpaConfig.MinReplicas != nil {
+			eventMinReplicas = *hpaConfig.MinReplicas
+		}
+
+		for i := int32(1); i <= stepCount; i++ {
+			minReplicas := eventMinReplicas - (eventMinReplicas-targetMinReplicas)*i/stepCount
+			maxReplicas := hpaConfig.MaxReplicas
+			if i == stepCount {
+				maxReplicas = targetMaxReplicas
+			}
+			if maxReplicas < minReplicas {
+				maxReplicas = minReplicas
+			}
+			steps = append(
+				steps, &UCEntity.ScaleDownStepData{
+					EventID:              event.ID,
+					ScheduledHPAConfigID: hpaConfig.ID,
+					StepNumber:           i,
+					ScheduledAt:          event.EndTime.Add(window * time.Duration(i) / time.Duration(stepCount)),
+					MinReplicas:          minReplicas,
+					MaxReplicas:          maxReplicas,
+					Status:               model.ScaleDownStepPending,
+				},
+			)
+		}
+	}
+	return steps
+}
+
+func (s *scaleDown) RegisterScaleDownSteps(
+	tx *gorm.DB,
+	steps []*UCEntity.ScaleDownStepData,
+) error {
+	if len(steps) == 0 {
+		return nil
+	}
+	var data []*model.ScaleDownStep
+	for _, step := range steps {
+		modelData := &model.ScaleDownStep{
+			StepNumber:  step.StepNumber,
+			ScheduledAt: step.ScheduledAt,
+			MinPods:     step.MinReplicas,
+			MaxPods:     step.MaxReplicas,
+			Status:      step.Status,
+		}
+		modelData.EventID.SetUUID(step.EventID)
+		modelData.ScheduledHPAConfigID.SetUUID(step.ScheduledHPAConfigID)
+		data = append(data, modelData)
+	}
+	err := s.scaleDownStepRepo.InsertBatchScaleDownStep(tx, data)
+	if err != nil {
+		return err
+	}
+	for idx, datum := range data {
+		steps[idx].ID = datum.ID.GetUUID()
+	}
+	return nil
+}
+
+func (s *scaleDown) ListScaleDownStepByEventID(
+	tx *gorm.DB,
+	eventID uuid.UUID,
+) ([]*UCEntity.ScaleDownStepData, error) {
+	data, err := s.scaleDownStepRepo.ListScaleDownStepByEventID(tx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	return s.buildScaleDownSteps(data), nil
+}
+
+func (s *scaleDown) ListDueScaleDownStepByEventID(
+	tx *gorm.DB,
+	eventID uuid.UUID,
+	now time.Time,
+) ([]*UCEntity.ScaleDownStepData, error) {
+	data, err := s.scaleDownStepRepo.ListDueScaleDownStepByEventID(tx, eventID, now)
+	if err != nil {
+		return nil, err
+	}
+	return s.buildScaleDownSteps(data), nil
+}
+
+func (s *scaleDown) CountPendingScaleDownStepByEventID(
+	tx *gorm.DB,
+	eventID uuid.UUID,
+) (int64, error) {
+	return s.scaleDownStepRepo.CountPendingScaleDownStepByEventID(tx, eventID)
+}
+
+func (s *scaleDown) UpdateScaleDownStep(tx *gorm.DB, step *UCEntity.ScaleDownStepData) error {
+	modelData := &model.ScaleDownStep{
+		StepNumber:  step.StepNumber,
+		ScheduledAt: step.ScheduledAt,
+		ExecutedAt:  step.ExecutedAt,
+		MinPods:     step.MinReplicas,
+		MaxPods:     step.MaxReplicas,
+		Status:      step.Status,
+		Message:     step.Message,
+	}
+	modelData.ID.SetUUID(step.ID)
+	modelData.EventID.SetUUID(step.EventID)
+	modelData.ScheduledHPAConfigID.SetUUID(step.ScheduledHPAConfigID)
+	return tx.Model(modelData).Select(
+		"executed_at",
+		"status",
+		"message",
+	).Updates(modelData).Error
+}
+
+func (s *scaleDown) buildScaleDownSteps(data []*model.ScaleDownStep) []*UCEntity.ScaleDownStepData {
+	var output []*UCEntity.ScaleDownStepData
+	for _, d := range data {
+		output = append(
+			output, &UCEntity.ScaleDownStepData{
+				ID:                   d.ID.GetUUID(),
+				EventID:              d.EventID.GetUUID(),
+				ScheduledHPAConfigID: d.ScheduledHPAConfigID.GetUUID(),
+				StepNumber:           d.StepNumber,
+				ScheduledAt:          d.ScheduledAt,
+				ExecutedAt:           d.ExecutedAt,
+				MinReplicas:          d.MinPods,
+				MaxReplicas:          d.MaxPods,
+				Status:               d.Status,
+				Message:              d.Message,
+			},
+		)
+	}
+	return output
+}
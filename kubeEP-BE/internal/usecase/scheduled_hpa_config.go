@@ -29,14 +29,32 @@ type ScheduledHPAConfig interface {
 		status model.HPAUpdateStatus,
 		msg string,
 	) error
+	UpdateScheduledHPAConfigOriginalReplicas(
+		tx *gorm.DB,
+		id uuid.UUID,
+		minReplicas *int32,
+		maxReplicas int32,
+	) error
+	UpdateScheduledHPAConfigMaxReplicas(
+		tx *gorm.DB,
+		id uuid.UUID,
+		maxReplicas int32,
+	) error
 }
 
 type scheduledHPAConfig struct {
 	scheduledHPAConfigRepo repository.ScheduledHPAConfig
+	cloudEventUC           CloudEvent
 }
 
-func newScheduledHPAConfig(scheduledHPAConfigRepo repository.ScheduledHPAConfig) ScheduledHPAConfig {
-	return &scheduledHPAConfig{scheduledHPAConfigRepo: scheduledHPAConfigRepo}
+func newScheduledHPAConfig(
+	scheduledHPAConfigRepo repository.ScheduledHPAConfig,
+	cloudEventUC CloudEvent,
+) ScheduledHPAConfig {
+	return &scheduledHPAConfig{
+		scheduledHPAConfigRepo: scheduledHPAConfigRepo,
+		cloudEventUC:           cloudEventUC,
+	}
 }
 
 func (s *scheduledHPAConfig) RegisterModifiedHPAConfigs(
@@ -47,10 +65,12 @@ func (s *scheduledHPAConfig) RegisterModifiedHPAConfigs(
 	var data []*model.ScheduledHPAConfig
 	for _, modifiedHPA := range modifiedHPAs {
 		modelData := &model.ScheduledHPAConfig{
-			Name:      modifiedHPA.Name,
-			MinPods:   modifiedHPA.MinReplicas,
-			MaxPods:   modifiedHPA.MaxReplicas,
-			Namespace: modifiedHPA.Namespace,
+			Name:             modifiedHPA.Name,
+			MinPods:          modifiedHPA.MinReplicas,
+			MaxPods:          modifiedHPA.MaxReplicas,
+			Namespace:        modifiedHPA.Namespace,
+			PostEventMinPods: modifiedHPA.PostEventMinReplicas,
+			PostEventMaxPods: modifiedHPA.PostEventMaxReplicas,
 		}
 		modelData.EventID.SetUUID(eventID)
 		data = append(
@@ -95,6 +115,11 @@ func (s *scheduledHPAConfig) ListScheduledHPAConfigByEventID(
 				Namespace:   hpa.Namespace,
 				MinReplicas: hpa.MinPods,
 				MaxReplicas: hpa.MaxPods,
+
+				OriginalMinReplicas:  hpa.OriginalMinPods,
+				OriginalMaxReplicas:  hpa.OriginalMaxPods,
+				PostEventMinReplicas: hpa.PostEventMinPods,
+				PostEventMaxReplicas: hpa.PostEventMaxPods,
 			},
 		)
 	}
@@ -120,8 +145,60 @@ func (s *scheduledHPAConfig) UpdateScheduledHPAConfigStatusMessage(
 		return err
 	}
 
+	previousStatus := scheduledHPAConfigData.Status
 	scheduledHPAConfigData.Status = status
 	scheduledHPAConfigData.Message = msg
 
+	err = s.scheduledHPAConfigRepo.SaveScheduledHPAConfig(tx, scheduledHPAConfigData)
+	if err != nil {
+		return err
+	}
+	if previousStatus != status {
+		s.cloudEventUC.PublishHPAStatusChange(
+			tx, &UCEntity.HPAStatusChangedData{
+				EventID:              scheduledHPAConfigData.EventID.GetUUID(),
+				ScheduledHPAConfigID: id,
+				Name:                 scheduledHPAConfigData.Name,
+				Namespace:            scheduledHPAConfigData.Namespace,
+				PreviousStatus:       previousStatus,
+				Status:               status,
+				Message:              msg,
+			},
+		)
+	}
+	return nil
+}
+
+func (s *scheduledHPAConfig) UpdateScheduledHPAConfigOriginalReplicas(
+	tx *gorm.DB,
+	id uuid.UUID,
+	minReplicas *int32,
+	maxReplicas int32,
+) error {
+	scheduledHPAConfigData, err := s.scheduledHPAConfigRepo.GetScheduledHPAConfigByID(tx, id)
+	if err != nil {
+		return err
+	}
+
+	scheduledHPAConfigData.OriginalMinPods = minReplicas
+	scheduledHPAConfigData.OriginalMaxPods = &maxReplicas
+
+	return s.scheduledHPAConfigRepo.SaveScheduledHPAConfig(tx, scheduledHPAConfigData)
+}
+
+// UpdateScheduledHPAConfigMaxReplicas keeps the event max replicas in sync when it is raised
+// during the event, so the scale down steps start from the raised value
+func (s *scheduledHPAConfig) UpdateScheduledHPAConfigMaxReplicas(
+	tx *gorm.DB,
+	id uuid.UUID,
+	maxReplicas int32,
+) error {
+	scheduledHPAConfigData, err := s.scheduledHPAConfigRepo.GetScheduledHPAConfigByID(tx, id)
+	if err != nil {
+		return err
+	}
+
+	scheduledHPAConfigData.MaxPods = maxReplicas
+
 	return s.scheduledHPAConfigRepo.SaveScheduledHPAConfig(tx, scheduledHPAConfigData)
 }
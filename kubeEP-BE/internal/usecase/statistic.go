@@ -1,9 +1,12 @@
 package useCase
 
 import (
+	"encoding/json"
 	"github.com/google/uuid"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant"
 	UCEntity "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/usecase"
 	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository/model"
 	"gorm.io/gorm"
 )
 
@@ -12,6 +15,12 @@ type Statistic interface {
 		tx *gorm.DB,
 		eventID uuid.UUID,
 	) ([]*UCEntity.UpdatedNodePoolData, error)
+	UpdateUpdatedNodePoolMaxNode(
+		tx *gorm.DB,
+		eventID uuid.UUID,
+		nodePoolName string,
+		maxNode int32,
+	) error
 	GetAllNodePoolStatusByUpdatedNodePoolID(
 		tx *gorm.DB,
 		updatedNodePoolID uuid.UUID,
@@ -20,23 +29,34 @@ type Statistic interface {
 		tx *gorm.DB,
 		scheduledHPAConfigID uuid.UUID,
 	) ([]*UCEntity.HPAStatusData, error)
+	GetHPAHitMaxIntervals(
+		tx *gorm.DB,
+		scheduledHPAConfigID uuid.UUID,
+	) ([]*UCEntity.HPAHitMaxIntervalData, error)
+	GetAllPodStatusByScheduledHPAConfigID(
+		tx *gorm.DB,
+		scheduledHPAConfigID uuid.UUID,
+	) ([]*UCEntity.PodStatusData, error)
 }
 
 type statistic struct {
 	updatedNodePoolRepo repository.UpdatedNodePool
 	hpaStatusRepo       repository.HPAStatus
 	nodePoolStatusRepo  repository.NodePoolStatus
+	podStatusRepo       repository.PodStatus
 }
 
 func newStatistic(
 	updatedNodePoolRepo repository.UpdatedNodePool,
 	hpaStatusRepo repository.HPAStatus,
 	nodePoolStatusRepo repository.NodePoolStatus,
+	podStatusRepo repository.PodStatus,
 ) Statistic {
 	return &statistic{
 		updatedNodePoolRepo: updatedNodePoolRepo,
 		hpaStatusRepo:       hpaStatusRepo,
 		nodePoolStatusRepo:  nodePoolStatusRepo,
+		podStatusRepo:       podStatusRepo,
 	}
 }
 
@@ -55,12 +75,22 @@ func (u *statistic) GetAllUpdatedNodePoolByEvent(
 				ID:           d.ID.GetUUID(),
 				NodePoolName: d.NodePoolName,
 				MaxNode:      d.MaxNode,
+				Message:      d.Message,
 			},
 		)
 	}
 	return output, nil
 }
 
+func (u *statistic) UpdateUpdatedNodePoolMaxNode(
+	tx *gorm.DB,
+	eventID uuid.UUID,
+	nodePoolName string,
+	maxNode int32,
+) error {
+	return u.updatedNodePoolRepo.UpdateUpdatedNodePoolMaxNode(tx, eventID, nodePoolName, maxNode)
+}
+
 func (u *statistic) GetAllNodePoolStatusByUpdatedNodePoolID(
 	tx *gorm.DB,
 	updatedNodePoolID uuid.UUID,
@@ -71,12 +101,7 @@ func (u *statistic) GetAllNodePoolStatusByUpdatedNodePoolID(
 		return nil, err
 	}
 	for _, d := range data {
-		output = append(
-			output, &UCEntity.NodePoolStatusData{
-				CreatedAt: d.CreatedAt,
-				Count:     d.NodeCount,
-			},
-		)
+		output = append(output, buildNodePoolStatusData(d))
 	}
 	return output, nil
 }
@@ -93,16 +118,111 @@ func (u *statistic) GetAllHPAStatusByScheduledHPAConfigID(
 	if err != nil {
 		return nil, err
 	}
+	for _, d := range data {
+		hpaStatusData, err := buildHPAStatusData(d)
+		if err != nil {
+			return nil, err
+		}
+		output = append(output, hpaStatusData)
+	}
+	return output, nil
+}
+
+// GetHPAHitMaxIntervals groups consecutive samples where the HPA was pinned at its max replicas,
+// either running max replicas or wanting more than max replicas
+func (u *statistic) GetHPAHitMaxIntervals(
+	tx *gorm.DB,
+	scheduledHPAConfigID uuid.UUID,
+) ([]*UCEntity.HPAHitMaxIntervalData, error) {
+	statuses, err := u.GetAllHPAStatusByScheduledHPAConfigID(tx, scheduledHPAConfigID)
+	if err != nil {
+		return nil, err
+	}
+	var output []*UCEntity.HPAHitMaxIntervalData
+	var current *UCEntity.HPAHitMaxIntervalData
+	for _, status := range statuses {
+		hitMax := status.MaxReplicas > 0 &&
+			(status.CurrentReplicas >= status.MaxReplicas ||
+				(status.ScalingLimited && status.ScalingLimitedReason == constant.HPATooManyReplicasReason))
+		if !hitMax {
+			current = nil
+			continue
+		}
+		if current == nil {
+			current = &UCEntity.HPAHitMaxIntervalData{StartTime: status.CreatedAt}
+			output = append(output, current)
+		}
+		current.EndTime = status.CreatedAt
+	}
+	return output, nil
+}
+
+func (u *statistic) GetAllPodStatusByScheduledHPAConfigID(
+	tx *gorm.DB,
+	scheduledHPAConfigID uuid.UUID,
+) ([]*UCEntity.PodStatusData, error) {
+	var output []*UCEntity.PodStatusData
+	data, err := u.podStatusRepo.GetAllPodStatusByScheduledHPAConfigID(
+		tx,
+		scheduledHPAConfigID,
+	)
+	if err != nil {
+		return nil, err
+	}
 	for _, d := range data {
 		output = append(
-			output, &UCEntity.HPAStatusData{
-				CreatedAt:           d.CreatedAt,
-				Replicas:            d.Replicas,
-				ReadyReplicas:       d.ReadyReplicas,
-				AvailableReplicas:   d.AvailableReplicas,
-				UnavailableReplicas: d.UnavailableReplicas,
+			output, &UCEntity.PodStatusData{
+				CreatedAt: d.CreatedAt,
+				PodStatisticData: UCEntity.PodStatisticData{
+					PendingPods:              d.PendingPods,
+					UnschedulablePods:        d.UnschedulablePods,
+					FailedSchedulingEvents:   d.FailedSchedulingEvents,
+					RestartCount:             d.RestartCount,
+					OOMKilledContainers:      d.OOMKilledContainers,
+					StartedPods:              d.StartedPods,
+					AvgStartupLatencySeconds: d.AvgStartupLatencySeconds,
+					MaxStartupLatencySeconds: d.MaxStartupLatencySeconds,
+				},
 			},
 		)
 	}
 	return output, nil
 }
+
+func buildNodePoolStatusData(d *model.NodePoolStatus) *UCEntity.NodePoolStatusData {
+	return &UCEntity.NodePoolStatusData{
+		CreatedAt:         d.CreatedAt,
+		Count:             d.NodeCount,
+		AllocatableCPU:    d.AllocatableCPU,
+		AllocatableMemory: d.AllocatableMemory,
+		RequestedCPU:      d.RequestedCPU,
+		RequestedMemory:   d.RequestedMemory,
+		UsageCPU:          d.UsageCPU,
+		UsageMemory:       d.UsageMemory,
+	}
+}
+
+func buildHPAStatusData(d *model.HPAStatus) (*UCEntity.HPAStatusData, error) {
+	var metrics []UCEntity.HPAMetricValueData
+	if len(d.Metrics) != 0 {
+		if err := json.Unmarshal(d.Metrics.GetRawMessage(), &metrics); err != nil {
+			return nil, err
+		}
+	}
+	return &UCEntity.HPAStatusData{
+		CreatedAt:           d.CreatedAt,
+		Replicas:            d.Replicas,
+		ReadyReplicas:       d.ReadyReplicas,
+		AvailableReplicas:   d.AvailableReplicas,
+		UnavailableReplicas: d.UnavailableReplicas,
+		HPAStatusSnapshotData: UCEntity.HPAStatusSnapshotData{
+			MinReplicas:          d.HPAMinReplicas,
+			MaxReplicas:          d.HPAMaxReplicas,
+			CurrentReplicas:      d.HPACurrentReplicas,
+			DesiredReplicas:      d.HPADesiredReplicas,
+			ScalingLimited:       d.ScalingLimited,
+			ScalingLimitedReason: d.ScalingLimitedReason,
+			Metrics:              metrics,
+		},
+	}, nil
+}
@@ -0,0 +1,180 @@
+// Package client is a typed client of the kubeEP api, the requests and responses mirror the entities
+// of the api handlers
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Error is an answer of the api with a non success status, Data holds the error message or the
+// structured result of the failed request
+type Error struct {
+	StatusCode int
+	Data       json.RawMessage
+}
+
+func (e *Error) Error() string {
+	var message string
+	if err := json.Unmarshal(e.Data, &message); err != nil {
+		message = string(e.Data)
+	}
+	return message
+}
+
+// statusSuccess is the status of the successful answers, the failed ones are "fail" or "error"
+const statusSuccess = "success"
+
+type baseResponse struct {
+	Status string          `json:"status"`
+	Data   json.RawMessage `json:"data"`
+}
+
+type Client struct {
+	server     string
+	token      string
+	httpClient *http.Client
+}
+
+type Option func(*Client)
+
+// WithToken sends the token as bearer token, required when the api has auth tokens
+func WithToken(token string) Option {
+	return func(c *Client) {
+		c.token = token
+	}
+}
+
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// New builds a client of the api served at server (e.g. http://localhost:8000), the requests are
+// bounded by their context unless the http client has a timeout
+func New(server string, options ...Option) *Client {
+	c := &Client{
+		server:     strings.TrimRight(server, "/"),
+		httpClient: &http.Client{},
+	}
+	for _, option := range options {
+		option(c)
+	}
+	return c
+}
+
+func (c *Client) newRequest(
+	ctx context.Context,
+	method, path string,
+	query url.Values,
+	body io.Reader,
+	contentType string,
+) (*http.Request, error) {
+	target := c.server + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, body)
+	if err != nil {
+		return nil, err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	return req, nil
+}
+
+// do sends body as json and decodes the data of the base response into output
+func (c *Client) do(
+	ctx context.Context,
+	method, path string,
+	query url.Values,
+	body interface{},
+	output interface{},
+) error {
+	var reqBody io.Reader
+	contentType := ""
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(data)
+		contentType = "application/json"
+	}
+	return c.doRaw(ctx, method, path, query, reqBody, contentType, output)
+}
+
+func (c *Client) doRaw(
+	ctx context.Context,
+	method, path string,
+	query url.Values,
+	body io.Reader,
+	contentType string,
+	output interface{},
+) error {
+	req, err := c.newRequest(ctx, method, path, query, body, contentType)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	base := &baseResponse{}
+	if err := json.NewDecoder(res.Body).Decode(base); err != nil {
+		return fmt.Errorf("%s %s : status %d : %s", method, path, res.StatusCode, err.Error())
+	}
+	if base.Status != statusSuccess {
+		return &Error{StatusCode: res.StatusCode, Data: base.Data}
+	}
+	if output == nil {
+		return nil
+	}
+	return json.Unmarshal(base.Data, output)
+}
+
+// download returns the raw body of the documents served outside of the base response
+func (c *Client) download(ctx context.Context, path string, query url.Values) ([]byte, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, path, query, nil, "")
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		base := &baseResponse{}
+		if err := json.Unmarshal(data, base); err != nil {
+			return nil, fmt.Errorf("GET %s : status %d", path, res.StatusCode)
+		}
+		return nil, &Error{StatusCode: res.StatusCode, Data: base.Data}
+	}
+	return data, nil
+}
+
+func idPath(format string, id fmt.Stringer) string {
+	return fmt.Sprintf(format, url.PathEscape(id.String()))
+}
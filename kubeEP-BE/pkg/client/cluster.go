@@ -0,0 +1,31 @@
+package client
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"net/http"
+)
+
+func (c *Client) ListClusters(ctx context.Context) ([]Cluster, error) {
+	var res []Cluster
+	if err := c.do(ctx, http.MethodGet, "/cluster/list", nil, nil, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (c *Client) GetCluster(ctx context.Context, clusterID uuid.UUID) (*Cluster, error) {
+	res := &Cluster{}
+	if err := c.do(ctx, http.MethodGet, idPath("/cluster/%s", clusterID), nil, nil, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (c *Client) ListClusterHPAs(ctx context.Context, clusterID uuid.UUID) ([]SimpleHPA, error) {
+	var res []SimpleHPA
+	if err := c.do(ctx, http.MethodGet, idPath("/cluster/%s/hpa", clusterID), nil, nil, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
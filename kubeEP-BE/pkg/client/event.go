@@ -0,0 +1,95 @@
+package client
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"net/http"
+	"net/url"
+)
+
+func (c *Client) RegisterEvent(ctx context.Context, req *EventDataRequest) (uuid.UUID, error) {
+	res := &eventCreationResponse{}
+	if err := c.do(ctx, http.MethodPost, "/event/register", nil, req, res); err != nil {
+		return uuid.UUID{}, err
+	}
+	return res.EventID, nil
+}
+
+func (c *Client) ValidateEvent(
+	ctx context.Context,
+	req *EventValidationRequest,
+) (*EventValidation, error) {
+	res := &EventValidation{}
+	if err := c.do(ctx, http.MethodPost, "/event/validate", nil, req, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// UpdateEvent updates a pending event, its guard rules are replaced only when GuardRules is set
+func (c *Client) UpdateEvent(ctx context.Context, req *UpdateEventDataRequest) error {
+	return c.do(ctx, http.MethodPut, "/event/update", nil, req, &eventCreationResponse{})
+}
+
+func (c *Client) ListEvents(ctx context.Context, clusterID uuid.UUID) ([]Event, error) {
+	var res []Event
+	query := url.Values{"cluster_id": {clusterID.String()}}
+	if err := c.do(ctx, http.MethodGet, "/event/list", query, nil, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (c *Client) GetEvent(ctx context.Context, eventID uuid.UUID) (*EventDetail, error) {
+	res := &EventDetail{}
+	if err := c.do(ctx, http.MethodGet, idPath("/event/%s", eventID), nil, nil, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (c *Client) DeleteEvent(ctx context.Context, eventID uuid.UUID) error {
+	return c.do(ctx, http.MethodDelete, idPath("/event/%s", eventID), nil, nil, nil)
+}
+
+func (c *Client) GetEventRecommendation(
+	ctx context.Context,
+	req *EventRecommendationRequest,
+) (*EventRecommendation, error) {
+	query := url.Values{}
+	if req.ClusterID != nil {
+		query.Set("cluster_id", req.ClusterID.String())
+	}
+	for _, hpa := range req.HPAs {
+		query.Add("hpa", hpa)
+	}
+	if req.StartTime != nil {
+		query.Set("start_time", *req.StartTime)
+	}
+	if req.EndTime != nil {
+		query.Set("end_time", *req.EndTime)
+	}
+	res := &EventRecommendation{}
+	if err := c.do(ctx, http.MethodGet, "/event/recommendation", query, nil, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (c *Client) GetEventReport(ctx context.Context, eventID uuid.UUID) (*EventReport, error) {
+	res := &EventReport{}
+	query := url.Values{"format": {"json"}}
+	if err := c.do(ctx, http.MethodGet, idPath("/event/%s/report", eventID), query, nil, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetEventReportDocument returns the report rendered in ReportFormatMarkdown or ReportFormatHTML
+func (c *Client) GetEventReportDocument(
+	ctx context.Context,
+	eventID uuid.UUID,
+	format string,
+) ([]byte, error) {
+	return c.download(ctx, idPath("/event/%s/report", eventID), url.Values{"format": {format}})
+}
@@ -0,0 +1,55 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/google/uuid"
+	"net/http"
+	"net/url"
+)
+
+// ExportEvent returns the event document of an event in DocumentFormatYAML or DocumentFormatJSON
+func (c *Client) ExportEvent(ctx context.Context, eventID uuid.UUID, format string) ([]byte, error) {
+	return c.download(ctx, idPath("/event/%s/export", eventID), url.Values{"format": {format}})
+}
+
+func (c *Client) ExportClusterEvents(
+	ctx context.Context,
+	clusterID uuid.UUID,
+	format string,
+) ([]byte, error) {
+	query := url.Values{"cluster_id": {clusterID.String()}, "format": {format}}
+	return c.download(ctx, "/event/export", query)
+}
+
+// ImportEvents registers every event of a yaml or json document, or none of them. An invalid
+// document returns the validation of every event along with the error
+func (c *Client) ImportEvents(ctx context.Context, document []byte, dryRun bool) (*EventImport, error) {
+	query := url.Values{}
+	if dryRun {
+		query.Set("dry_run", "true")
+	}
+	res := &EventImport{}
+	err := c.doRaw(
+		ctx,
+		http.MethodPost,
+		"/event/import",
+		query,
+		bytes.NewReader(document),
+		"application/yaml",
+		res,
+	)
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		if decodeErr := json.Unmarshal(apiErr.Data, res); decodeErr != nil || res.Events == nil {
+			return nil, err
+		}
+		return res, err
+	}
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
@@ -0,0 +1,42 @@
+package client
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"net/http"
+	"net/url"
+)
+
+func (c *Client) RegisterGCPDatacenter(
+	ctx context.Context,
+	req *GCPDatacenterRequest,
+) (*GCPDatacenter, error) {
+	res := &GCPDatacenter{}
+	if err := c.do(ctx, http.MethodPost, "/gcp/register/datacenter", nil, req, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (c *Client) RegisterGCPClusters(
+	ctx context.Context,
+	req *GCPRegisterClusterRequest,
+) ([]GCPCluster, error) {
+	var res []GCPCluster
+	if err := c.do(ctx, http.MethodPost, "/gcp/register/clusters", nil, req, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (c *Client) ListGCPClusters(
+	ctx context.Context,
+	datacenterID uuid.UUID,
+) (*GCPDatacenterClusters, error) {
+	res := &GCPDatacenterClusters{}
+	query := url.Values{"datacenter_id": {datacenterID.String()}}
+	if err := c.do(ctx, http.MethodGet, "/gcp/clusters", query, nil, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
@@ -0,0 +1,76 @@
+package client
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"net/http"
+	"net/url"
+)
+
+func (c *Client) RegisterNotificationChannel(
+	ctx context.Context,
+	req *NotificationChannelRequest,
+) (uuid.UUID, error) {
+	res := &notificationCreationResponse{}
+	if err := c.do(ctx, http.MethodPost, "/notification/channel", nil, req, res); err != nil {
+		return uuid.UUID{}, err
+	}
+	return res.ID, nil
+}
+
+func (c *Client) ListNotificationChannels(ctx context.Context) ([]NotificationChannel, error) {
+	var res []NotificationChannel
+	if err := c.do(ctx, http.MethodGet, "/notification/channel/list", nil, nil, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (c *Client) DeleteNotificationChannel(ctx context.Context, channelID uuid.UUID) error {
+	path := idPath("/notification/channel/%s", channelID)
+	return c.do(ctx, http.MethodDelete, path, nil, nil, nil)
+}
+
+func (c *Client) RegisterNotificationSubscription(
+	ctx context.Context,
+	req *NotificationSubscriptionRequest,
+) (uuid.UUID, error) {
+	res := &notificationCreationResponse{}
+	if err := c.do(ctx, http.MethodPost, "/notification/subscription", nil, req, res); err != nil {
+		return uuid.UUID{}, err
+	}
+	return res.ID, nil
+}
+
+// ListNotificationSubscriptions lists every subscription when clusterID is nil
+func (c *Client) ListNotificationSubscriptions(
+	ctx context.Context,
+	clusterID *uuid.UUID,
+) ([]NotificationSubscription, error) {
+	query := url.Values{}
+	if clusterID != nil {
+		query.Set("cluster_id", clusterID.String())
+	}
+	var res []NotificationSubscription
+	if err := c.do(ctx, http.MethodGet, "/notification/subscription/list", query, nil, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (c *Client) DeleteNotificationSubscription(ctx context.Context, subscriptionID uuid.UUID) error {
+	path := idPath("/notification/subscription/%s", subscriptionID)
+	return c.do(ctx, http.MethodDelete, path, nil, nil, nil)
+}
+
+func (c *Client) ListNotificationDeliveries(
+	ctx context.Context,
+	eventID uuid.UUID,
+) ([]NotificationDelivery, error) {
+	var res []NotificationDelivery
+	query := url.Values{"event_id": {eventID.String()}}
+	if err := c.do(ctx, http.MethodGet, "/notification/delivery/list", query, nil, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
@@ -0,0 +1,43 @@
+package client
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"net/http"
+)
+
+func (c *Client) ListNodePoolStatus(
+	ctx context.Context,
+	updatedNodePoolID uuid.UUID,
+) ([]NodePoolStatus, error) {
+	var res []NodePoolStatus
+	path := idPath("/event/status/node-pool/%s", updatedNodePoolID)
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (c *Client) ListHPAStatus(
+	ctx context.Context,
+	scheduledHPAConfigID uuid.UUID,
+) ([]HPAStatus, error) {
+	var res []HPAStatus
+	path := idPath("/event/status/hpa/%s", scheduledHPAConfigID)
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (c *Client) ListPodStatus(
+	ctx context.Context,
+	scheduledHPAConfigID uuid.UUID,
+) ([]PodStatus, error) {
+	var res []PodStatus
+	path := idPath("/event/status/pod/%s", scheduledHPAConfigID)
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
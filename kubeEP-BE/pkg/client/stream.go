@@ -0,0 +1,110 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"net/http"
+	"strings"
+)
+
+// StreamMessage is a live update of an event, only the field of its type is set
+type StreamMessage struct {
+	Type           StreamMessageType
+	HPAStatus      *EventStreamHPAStatus
+	NodePoolStatus *EventStreamNodePoolStatus
+	EventStatus    *EventStreamEventStatus
+}
+
+// StreamEvent calls handle with the samples and status transitions of an event until the event
+// succeeds or fails, an error of handle stops the stream. The stream is not bounded by the timeout
+// of the http client, only by ctx
+func (c *Client) StreamEvent(
+	ctx context.Context,
+	eventID uuid.UUID,
+	handle func(message *StreamMessage) error,
+) error {
+	path := idPath("/event/%s/stream", eventID)
+	req, err := c.newRequest(ctx, http.MethodGet, path, nil, nil, "")
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+
+	streamClient := *c.httpClient
+	streamClient.Timeout = 0
+	res, err := streamClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if !strings.HasPrefix(res.Header.Get("Content-Type"), "text/event-stream") {
+		base := &baseResponse{}
+		if err := json.NewDecoder(res.Body).Decode(base); err != nil {
+			return fmt.Errorf("GET %s : status %d", path, res.StatusCode)
+		}
+		return &Error{StatusCode: res.StatusCode, Data: base.Data}
+	}
+
+	scanner := bufio.NewScanner(res.Body)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	var messageType string
+	var data []byte
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			if data != nil {
+				message, err := decodeStreamMessage(StreamMessageType(messageType), data)
+				if err != nil {
+					return err
+				}
+				if message != nil {
+					if err := handle(message); err != nil {
+						return err
+					}
+				}
+			}
+			messageType, data = "", nil
+		case strings.HasPrefix(line, ":"):
+			// Heartbeat
+		case strings.HasPrefix(line, "event:"):
+			messageType = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			if data != nil {
+				data = append(data, '\n')
+			}
+			data = append(data, strings.TrimSpace(strings.TrimPrefix(line, "data:"))...)
+		}
+	}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return scanner.Err()
+}
+
+// decodeStreamMessage skips the message types unknown by this client
+func decodeStreamMessage(messageType StreamMessageType, data []byte) (*StreamMessage, error) {
+	message := &StreamMessage{Type: messageType}
+	var target interface{}
+	switch messageType {
+	case StreamHPAStatus:
+		message.HPAStatus = &EventStreamHPAStatus{}
+		target = message.HPAStatus
+	case StreamNodePoolStatus:
+		message.NodePoolStatus = &EventStreamNodePoolStatus{}
+		target = message.NodePoolStatus
+	case StreamEventStatus:
+		message.EventStatus = &EventStreamEventStatus{}
+		target = message.EventStatus
+	default:
+		return nil, nil
+	}
+	if err := json.Unmarshal(data, target); err != nil {
+		return nil, err
+	}
+	return message, nil
+}
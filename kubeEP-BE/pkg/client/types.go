@@ -0,0 +1,476 @@
+package client
+
+import (
+	"encoding/json"
+	"github.com/google/uuid"
+	"time"
+)
+
+// The wire types mirror the request and response entities of the api handlers, the client only
+// depends on the standard library and uuid so the packages outside of the module can import it
+
+type EventStatus string
+
+const (
+	EventPending     EventStatus = "PENDING"
+	EventExecuting   EventStatus = "EXECUTING"
+	EventPrescaled   EventStatus = "PRESCALED"
+	EventWatching    EventStatus = "WATCHING"
+	EventScalingDown EventStatus = "SCALING_DOWN"
+	EventSuccess     EventStatus = "SUCCESS"
+	EventFailed      EventStatus = "FAILED"
+)
+
+type StreamMessageType string
+
+const (
+	StreamHPAStatus      StreamMessageType = "hpa_status"
+	StreamNodePoolStatus StreamMessageType = "node_pool_status"
+	StreamEventStatus    StreamMessageType = "event_status"
+)
+
+const (
+	DocumentFormatYAML   = "yaml"
+	DocumentFormatJSON   = "json"
+	ReportFormatMarkdown = "markdown"
+	ReportFormatHTML     = "html"
+)
+
+type GCPDatacenterRequest struct {
+	Name             *string          `json:"name"`
+	SAKeyCredentials *json.RawMessage `json:"sa_key_credentials"`
+	IsTemporary      *bool            `json:"is_temporary"`
+}
+
+type GCPRegisterClusterRequest struct {
+	ClustersName          []string   `json:"clusters_name"`
+	DatacenterID          *uuid.UUID `json:"datacenter_id"`
+	IsDatacenterTemporary *bool      `json:"is_datacenter_temporary"`
+}
+
+type EventDataRequest struct {
+	Name               *string                      `json:"name"`
+	StartTime          *time.Time                   `json:"start_time"`
+	EndTime            *time.Time                   `json:"end_time"`
+	ClusterID          *uuid.UUID                   `json:"cluster_id"`
+	CalculateNodePool  *bool                        `json:"calculate_node_pool"`
+	ExecuteConfigAt    *time.Time                   `json:"execute_config_at"`
+	WatchingAt         *time.Time                   `json:"watching_at"`
+	ModifiedHPAConfigs []EventModifiedHPAConfigData `json:"modified_hpa_configs"`
+	ScaleDown          *EventScaleDownConfig        `json:"scale_down"`
+	Watch              *EventWatchConfig            `json:"watch"`
+	GuardRules         []EventGuardRuleData         `json:"guard_rules"`
+	// Owner marks an event managed by another system, such as the kubeEP controller
+	Owner *string `json:"owner,omitempty"`
+}
+
+type UpdateEventDataRequest struct {
+	Name               *string                      `json:"name"`
+	StartTime          *time.Time                   `json:"start_time"`
+	EndTime            *time.Time                   `json:"end_time"`
+	ModifiedHPAConfigs []EventModifiedHPAConfigData `json:"modified_hpa_configs"`
+	CalculateNodePool  *bool                        `json:"calculate_node_pool"`
+	ExecuteConfigAt    *time.Time                   `json:"execute_config_at"`
+	WatchingAt         *time.Time                   `json:"watching_at"`
+	EventID            *uuid.UUID                   `json:"event_id"`
+	ScaleDown          *EventScaleDownConfig        `json:"scale_down"`
+	Watch              *EventWatchConfig            `json:"watch"`
+	GuardRules         []EventGuardRuleData         `json:"guard_rules"`
+}
+
+type EventValidationRequest struct {
+	ClusterID          *uuid.UUID                   `json:"cluster_id"`
+	CalculateNodePool  *bool                        `json:"calculate_node_pool"`
+	ModifiedHPAConfigs []EventModifiedHPAConfigData `json:"modified_hpa_configs"`
+}
+
+// EventRecommendationRequest selects the hpa to recommend with "namespace/name" values, the start
+// and end time are RFC3339 strings
+type EventRecommendationRequest struct {
+	ClusterID *uuid.UUID
+	HPAs      []string
+	StartTime *string
+	EndTime   *string
+}
+
+type EventModifiedHPAConfigData struct {
+	Name        *string `json:"name"`
+	Namespace   *string `json:"namespace"`
+	MinReplicas *int32  `json:"min_replicas"`
+	MaxReplicas *int32  `json:"max_replicas"`
+
+	PostEventMinReplicas *int32 `json:"post_event_min_replicas"`
+	PostEventMaxReplicas *int32 `json:"post_event_max_replicas"`
+}
+
+type EventScaleDownConfig struct {
+	WindowMinutes *int64  `json:"window_minutes"`
+	Steps         *int32  `json:"steps"`
+	Gate          *string `json:"gate"`
+}
+
+type EventWatchConfig struct {
+	IntervalSeconds    *int64 `json:"interval_seconds"`
+	MaxIntervalSeconds *int64 `json:"max_interval_seconds"`
+}
+
+type EventGuardRuleData struct {
+	HPAName         *string  `json:"hpa_name"`
+	HPANamespace    *string  `json:"hpa_namespace"`
+	Condition       *string  `json:"condition"`
+	Threshold       *float64 `json:"threshold"`
+	DurationSeconds *int64   `json:"duration_seconds"`
+	Action          *string  `json:"action"`
+	ActionValue     *int32   `json:"action_value"`
+	NodePoolName    *string  `json:"node_pool_name"`
+	CooldownSeconds *int64   `json:"cooldown_seconds"`
+}
+
+type NotificationChannelRequest struct {
+	Name       *string  `json:"name"`
+	Type       *string  `json:"type"`
+	URL        *string  `json:"url"`
+	Secret     *string  `json:"secret"`
+	Recipients []string `json:"recipients"`
+}
+
+type NotificationSubscriptionRequest struct {
+	ChannelID   *uuid.UUID `json:"channel_id"`
+	ClusterID   *uuid.UUID `json:"cluster_id"`
+	EventID     *uuid.UUID `json:"event_id"`
+	Transitions []string   `json:"transitions"`
+}
+
+type eventCreationResponse struct {
+	EventID uuid.UUID `json:"event_id"`
+}
+
+type notificationCreationResponse struct {
+	ID uuid.UUID `json:"id"`
+}
+
+type GCPDatacenter struct {
+	DatacenterID uuid.UUID `json:"datacenter_id"`
+	IsTemporary  bool      `json:"is_temporary"`
+}
+
+type GCPCluster struct {
+	Cluster
+	Location string `json:"location"`
+}
+
+type GCPDatacenterClusters struct {
+	Clusters              []GCPCluster `json:"clusters"`
+	IsTemporaryDatacenter bool         `json:"is_temporary_datacenter"`
+}
+
+type Cluster struct {
+	ID             *uuid.UUID `json:"id,omitempty"`
+	Name           string     `json:"name"`
+	Datacenter     string     `json:"datacenter"`
+	DatacenterName string     `json:"datacenter_name,omitempty"`
+}
+
+type SimpleHPA struct {
+	Name            string `json:"name"`
+	Namespace       string `json:"namespace"`
+	MinReplicas     *int32 `json:"min_replicas,omitempty"`
+	MaxReplicas     int32  `json:"max_replicas"`
+	CurrentReplicas int32  `json:"current_replicas"`
+}
+
+type Event struct {
+	ID        uuid.UUID   `json:"id"`
+	Name      string      `json:"name"`
+	StartTime time.Time   `json:"start_time"`
+	EndTime   time.Time   `json:"end_time"`
+	Status    EventStatus `json:"status"`
+	Owner     string      `json:"owner,omitempty"`
+}
+
+type EventDetail struct {
+	Event
+	CreatedAt          time.Time           `json:"created_at"`
+	UpdatedAt          time.Time           `json:"updated_at"`
+	CalculateNodePool  bool                `json:"calculate_node_pool"`
+	ExecuteConfigAt    time.Time           `json:"execute_config_at"`
+	WatchingAt         time.Time           `json:"watching_at"`
+	Cluster            Cluster             `json:"cluster"`
+	ModifiedHPAConfigs []ModifiedHPAConfig `json:"modified_hpa_configs"`
+	UpdatedNodePools   []UpdatedNodePool   `json:"updated_node_pools"`
+	ScaleDown          ScaleDownConfig     `json:"scale_down"`
+	Watch              WatchConfig         `json:"watch"`
+	ScaleDownSteps     []ScaleDownStep     `json:"scale_down_steps"`
+	GuardRules         []GuardRule         `json:"guard_rules"`
+	GuardActions       []GuardAction       `json:"guard_actions"`
+}
+
+type ModifiedHPAConfig struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	Namespace   string    `json:"namespace"`
+	MinReplicas *int32    `json:"min_replicas,omitempty"`
+	MaxReplicas int32     `json:"max_replicas"`
+
+	OriginalMinReplicas  *int32 `json:"original_min_replicas,omitempty"`
+	OriginalMaxReplicas  *int32 `json:"original_max_replicas,omitempty"`
+	PostEventMinReplicas *int32 `json:"post_event_min_replicas,omitempty"`
+	PostEventMaxReplicas *int32 `json:"post_event_max_replicas,omitempty"`
+
+	HitMaxIntervals []Interval `json:"hit_max_intervals"`
+}
+
+type UpdatedNodePool struct {
+	ID           uuid.UUID `json:"id"`
+	NodePoolName string    `json:"node_pool_name"`
+	MaxNode      int32     `json:"max_node"`
+	Message      string    `json:"message,omitempty"`
+}
+
+type ScaleDownConfig struct {
+	WindowMinutes int64  `json:"window_minutes"`
+	Steps         int32  `json:"steps"`
+	Gate          string `json:"gate"`
+}
+
+type WatchConfig struct {
+	IntervalSeconds    int64 `json:"interval_seconds,omitempty"`
+	MaxIntervalSeconds int64 `json:"max_interval_seconds,omitempty"`
+}
+
+type ScaleDownStep struct {
+	ID                   uuid.UUID  `json:"id"`
+	ScheduledHPAConfigID uuid.UUID  `json:"scheduled_hpa_config_id"`
+	StepNumber           int32      `json:"step_number"`
+	ScheduledAt          time.Time  `json:"scheduled_at"`
+	ExecutedAt           *time.Time `json:"executed_at,omitempty"`
+	MinReplicas          int32      `json:"min_replicas"`
+	MaxReplicas          int32      `json:"max_replicas"`
+	Status               string     `json:"status"`
+	Message              string     `json:"message"`
+}
+
+type GuardRule struct {
+	ID              uuid.UUID `json:"id"`
+	HPAName         string    `json:"hpa_name,omitempty"`
+	HPANamespace    string    `json:"hpa_namespace,omitempty"`
+	Condition       string    `json:"condition"`
+	Threshold       float64   `json:"threshold"`
+	DurationSeconds int64     `json:"duration_seconds"`
+	Action          string    `json:"action"`
+	ActionValue     int32     `json:"action_value,omitempty"`
+	NodePoolName    string    `json:"node_pool_name,omitempty"`
+	CooldownSeconds int64     `json:"cooldown_seconds"`
+}
+
+type GuardAction struct {
+	ID            uuid.UUID `json:"id"`
+	CreatedAt     time.Time `json:"created_at"`
+	GuardRuleID   uuid.UUID `json:"guard_rule_id"`
+	Action        string    `json:"action"`
+	Status        string    `json:"status"`
+	Target        string    `json:"target"`
+	PreviousValue int32     `json:"previous_value"`
+	NewValue      int32     `json:"new_value"`
+	Message       string    `json:"message"`
+	BreachedSince time.Time `json:"breached_since"`
+}
+
+type Interval struct {
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+}
+
+type ValidationIssue struct {
+	Level   string `json:"level"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type HPAValidation struct {
+	Name      string            `json:"name"`
+	Namespace string            `json:"namespace"`
+	Issues    []ValidationIssue `json:"issues"`
+}
+
+type NodePoolValidation struct {
+	Name   string            `json:"name"`
+	Issues []ValidationIssue `json:"issues"`
+}
+
+type EventValidation struct {
+	Valid     bool                 `json:"valid"`
+	HPAs      []HPAValidation      `json:"hpas"`
+	NodePools []NodePoolValidation `json:"node_pools"`
+}
+
+type EventRecommendation struct {
+	Event           EventDataRequest         `json:"event"`
+	BasedOnEventIDs []uuid.UUID              `json:"based_on_event_ids"`
+	HPAs            []HPARecommendation      `json:"hpas"`
+	NodePools       []NodePoolRecommendation `json:"node_pools"`
+}
+
+type HPARecommendation struct {
+	Name         string  `json:"name"`
+	Namespace    string  `json:"namespace"`
+	MinReplicas  int32   `json:"min_replicas"`
+	MaxReplicas  int32   `json:"max_replicas"`
+	SampleCount  int     `json:"sample_count"`
+	P50Replicas  float64 `json:"p50_replicas"`
+	P95Replicas  float64 `json:"p95_replicas"`
+	PeakReplicas int32   `json:"peak_replicas"`
+}
+
+type NodePoolRecommendation struct {
+	Name              string  `json:"name"`
+	ExpectedNodeCount int32   `json:"expected_node_count"`
+	SampleCount       int     `json:"sample_count"`
+	P95NodeCount      float64 `json:"p95_node_count"`
+	PeakNodeCount     int32   `json:"peak_node_count"`
+}
+
+type EventReport struct {
+	EventID     uuid.UUID             `json:"event_id"`
+	EventName   string                `json:"event_name"`
+	StartTime   time.Time             `json:"start_time"`
+	EndTime     time.Time             `json:"end_time"`
+	GeneratedAt time.Time             `json:"generated_at"`
+	HPAs        []EventReportHPA      `json:"hpas"`
+	NodePools   []EventReportNodePool `json:"node_pools"`
+}
+
+type EventReportHPA struct {
+	Name                   string     `json:"name"`
+	Namespace              string     `json:"namespace"`
+	MinReplicas            *int32     `json:"min_replicas"`
+	MaxReplicas            int32      `json:"max_replicas"`
+	PeakReplicas           int32      `json:"peak_replicas"`
+	PeakReplicasAt         *time.Time `json:"peak_replicas_at"`
+	PeakDesiredReplicas    int32      `json:"peak_desired_replicas"`
+	TimeToReadySeconds     *float64   `json:"time_to_ready_seconds"`
+	HitMaxIntervals        []Interval `json:"hit_max_intervals"`
+	UnavailableIntervals   []Interval `json:"unavailable_intervals"`
+	RecommendedMinReplicas int32      `json:"recommended_min_replicas"`
+	RecommendedMaxReplicas int32      `json:"recommended_max_replicas"`
+}
+
+type EventReportNodePool struct {
+	Name                     string     `json:"name"`
+	MaxNode                  int32      `json:"max_node"`
+	PeakNodeCount            int32      `json:"peak_node_count"`
+	PeakNodeCountAt          *time.Time `json:"peak_node_count_at"`
+	PeakRequestedCPUMillis   int64      `json:"peak_requested_cpu_millis"`
+	PeakRequestedMemoryBytes int64      `json:"peak_requested_memory_bytes"`
+	RecommendedNodeCount     int32      `json:"recommended_node_count"`
+}
+
+type EventImport struct {
+	DryRun bool                `json:"dry_run"`
+	Valid  bool                `json:"valid"`
+	Events []EventImportResult `json:"events"`
+}
+
+// EventImportResult holds the validation of an imported event, EventID is set once it is registered
+type EventImportResult struct {
+	Name       string           `json:"name"`
+	Cluster    string           `json:"cluster"`
+	Valid      bool             `json:"valid"`
+	EventID    *uuid.UUID       `json:"event_id,omitempty"`
+	Errors     []string         `json:"errors"`
+	Validation *EventValidation `json:"validation,omitempty"`
+}
+
+type NodePoolStatus struct {
+	CreatedAt              time.Time `json:"created_at"`
+	Count                  int32     `json:"count"`
+	AllocatableCPUMillis   int64     `json:"allocatable_cpu_millis"`
+	AllocatableMemoryBytes int64     `json:"allocatable_memory_bytes"`
+	RequestedCPUMillis     int64     `json:"requested_cpu_millis"`
+	RequestedMemoryBytes   int64     `json:"requested_memory_bytes"`
+	UsageCPUMillis         *int64    `json:"usage_cpu_millis,omitempty"`
+	UsageMemoryBytes       *int64    `json:"usage_memory_bytes,omitempty"`
+}
+
+type HPAStatus struct {
+	CreatedAt           time.Time `json:"created_at"`
+	Replicas            int32     `json:"replicas"`
+	AvailableReplicas   int32     `json:"available_replicas"`
+	ReadyReplicas       int32     `json:"ready_replicas"`
+	UnavailableReplicas int32     `json:"unavailable_replicas"`
+
+	HPAMinReplicas       int32            `json:"hpa_min_replicas"`
+	HPAMaxReplicas       int32            `json:"hpa_max_replicas"`
+	HPACurrentReplicas   int32            `json:"hpa_current_replicas"`
+	HPADesiredReplicas   int32            `json:"hpa_desired_replicas"`
+	ScalingLimited       bool             `json:"scaling_limited"`
+	ScalingLimitedReason string           `json:"scaling_limited_reason,omitempty"`
+	Metrics              []HPAMetricValue `json:"metrics"`
+}
+
+type HPAMetricValue struct {
+	Name    string `json:"name"`
+	Current string `json:"current,omitempty"`
+	Target  string `json:"target,omitempty"`
+}
+
+type PodStatus struct {
+	CreatedAt                time.Time `json:"created_at"`
+	PendingPods              int32     `json:"pending_pods"`
+	UnschedulablePods        int32     `json:"unschedulable_pods"`
+	FailedSchedulingEvents   int32     `json:"failed_scheduling_events"`
+	RestartCount             int32     `json:"restart_count"`
+	OOMKilledContainers      int32     `json:"oom_killed_containers"`
+	StartedPods              int32     `json:"started_pods"`
+	AvgStartupLatencySeconds float64   `json:"avg_startup_latency_seconds"`
+	MaxStartupLatencySeconds float64   `json:"max_startup_latency_seconds"`
+}
+
+type EventStreamHPAStatus struct {
+	ScheduledHPAConfigID uuid.UUID `json:"scheduled_hpa_config_id"`
+	Name                 string    `json:"name"`
+	Namespace            string    `json:"namespace"`
+	HPAStatus
+}
+
+type EventStreamNodePoolStatus struct {
+	UpdatedNodePoolID uuid.UUID `json:"updated_node_pool_id"`
+	NodePoolName      string    `json:"node_pool_name"`
+	NodePoolStatus
+}
+
+type EventStreamEventStatus struct {
+	Status         string `json:"status"`
+	PreviousStatus string `json:"previous_status,omitempty"`
+	Message        string `json:"message,omitempty"`
+}
+
+type NotificationChannel struct {
+	ID         uuid.UUID `json:"id"`
+	Name       string    `json:"name"`
+	Type       string    `json:"type"`
+	URL        string    `json:"url,omitempty"`
+	HasSecret  bool      `json:"has_secret"`
+	Recipients []string  `json:"recipients,omitempty"`
+}
+
+type NotificationSubscription struct {
+	ID          uuid.UUID           `json:"id"`
+	Channel     NotificationChannel `json:"channel"`
+	ClusterID   *uuid.UUID          `json:"cluster_id"`
+	EventID     *uuid.UUID          `json:"event_id"`
+	Transitions []string            `json:"transitions"`
+}
+
+type NotificationDelivery struct {
+	ID          uuid.UUID  `json:"id"`
+	CreatedAt   time.Time  `json:"created_at"`
+	ChannelID   uuid.UUID  `json:"channel_id"`
+	ChannelName string     `json:"channel_name"`
+	EventID     uuid.UUID  `json:"event_id"`
+	Transition  string     `json:"transition"`
+	Status      string     `json:"status"`
+	Attempts    int32      `json:"attempts"`
+	LastError   string     `json:"last_error,omitempty"`
+	DeliveredAt *time.Time `json:"delivered_at"`
+}
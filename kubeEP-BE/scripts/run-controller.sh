@@ -0,0 +1,4 @@
+#!/usr/bin/env bash
+set -e
+
+go build -race -o controller ./cmd/kubeEP-controller && ./controller